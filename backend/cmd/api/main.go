@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Site timezones load even where the host has no zoneinfo
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -16,8 +17,11 @@ import (
 
 	"github.com/justinyeo/hotdesk-booking/backend/internal/config"
 	"github.com/justinyeo/hotdesk-booking/backend/internal/database"
+	"github.com/justinyeo/hotdesk-booking/backend/internal/features/auth"
+	"github.com/justinyeo/hotdesk-booking/backend/internal/features/bookings"
 	"github.com/justinyeo/hotdesk-booking/backend/internal/handlers"
 	customMiddleware "github.com/justinyeo/hotdesk-booking/backend/internal/middleware"
+	"github.com/justinyeo/hotdesk-booking/backend/internal/shared/utils"
 )
 
 func main() {
@@ -37,24 +41,31 @@ func main() {
 	)
 
 	// Initialize database connection pool
-	if cfg.DatabaseURL != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		dbConfig := database.DefaultConfig(cfg.DatabaseURL)
-		_, err := database.Connect(ctx, dbConfig)
-		if err != nil {
-			logger.Warn("Failed to connect to database", zap.Error(err))
-		} else {
-			logger.Info("Database connection pool initialized",
-				zap.Int32("max_connections", dbConfig.MaxConnections),
-				zap.Int32("min_connections", dbConfig.MinConnections),
-			)
-			defer database.Close()
-		}
-	} else {
-		logger.Warn("DATABASE_URL not set, skipping database connection")
+	if cfg.DatabaseURL == "" {
+		logger.Fatal("DATABASE_URL is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dbConfig := database.DefaultConfig(cfg.DatabaseURL)
+	pool, err := database.Connect(ctx, dbConfig)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
+	defer database.Close()
+
+	logger.Info("Database connection pool initialized",
+		zap.Int32("max_connections", dbConfig.MaxConnections),
+		zap.Int32("min_connections", dbConfig.MinConnections),
+	)
+
+	// Initialize JWT manager
+	jwtManager, err := utils.NewJWTManager(cfg.JWTSecret)
+	if err != nil {
+		logger.Fatal("Failed to initialize JWT manager", zap.Error(err))
+	}
+	requireAuth := customMiddleware.RequireAuth(customMiddleware.AuthConfig{JWTValidator: jwtManager})
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -82,7 +93,7 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		AllowMethods: "GET, POST, PUT, PATCH, DELETE, OPTIONS",
 	}))
 	app.Use(customMiddleware.Logger(logger))
 
@@ -90,6 +101,87 @@ func main() {
 	api := app.Group("/api")
 	api.Get("/health", handlers.HealthCheck)
 
+	v1 := api.Group("/v1")
+
+	// Auth
+	authHandler := auth.NewHandler(auth.NewService(auth.NewRepository(pool), jwtManager))
+	authRoutes := v1.Group("/auth")
+	authRoutes.Post("/register", authHandler.Register)
+	authRoutes.Post("/login", authHandler.Login)
+	authRoutes.Post("/refresh", authHandler.Refresh)
+	authRoutes.Post("/logout", authHandler.Logout)
+	authRoutes.Post("/logout-all", requireAuth, authHandler.LogoutAll)
+	authRoutes.Get("/me", requireAuth, authHandler.Me)
+
+	// Bookings
+	bookingsService := bookings.NewService(bookings.NewRepository(pool))
+	bookingsHandler := bookings.NewHandler(bookingsService)
+	bookingRoutes := v1.Group("/bookings", requireAuth)
+	bookingRoutes.Post("/", bookingsHandler.Create)
+	bookingRoutes.Post("/recurring", bookingsHandler.CreateRecurring)
+	bookingRoutes.Post("/group", bookingsHandler.CreateGroup)
+	bookingRoutes.Post("/bulk-cancel", bookingsHandler.BulkCancel)
+	bookingRoutes.Get("/", bookingsHandler.List)
+	bookingRoutes.Get("/:id", bookingsHandler.Get)
+	bookingRoutes.Patch("/:id", bookingsHandler.Update)
+	bookingRoutes.Delete("/:id", bookingsHandler.Cancel)
+	bookingRoutes.Post("/:id/confirm", bookingsHandler.Confirm)
+	bookingRoutes.Post("/:id/check-in", bookingsHandler.CheckIn)
+	bookingRoutes.Post("/:id/check-out", bookingsHandler.CheckOut)
+	bookingRoutes.Post("/:id/move", bookingsHandler.Move)
+	bookingRoutes.Post("/:id/transfers", bookingsHandler.OfferTransfer)
+	bookingRoutes.Patch("/:id/series", bookingsHandler.UpdateSeries)
+	bookingRoutes.Delete("/:id/series", bookingsHandler.CancelSeries)
+
+	// Waitlist
+	waitlistRoutes := v1.Group("/waitlist", requireAuth)
+	waitlistRoutes.Post("/", bookingsHandler.JoinWaitlist)
+	waitlistRoutes.Get("/", bookingsHandler.ListWaitlist)
+	waitlistRoutes.Delete("/:id", bookingsHandler.LeaveWaitlist)
+
+	// Transfers
+	transferRoutes := v1.Group("/transfers", requireAuth)
+	transferRoutes.Get("/", bookingsHandler.ListTransfers)
+	transferRoutes.Post("/:id/accept", bookingsHandler.AcceptTransfer)
+	transferRoutes.Post("/:id/decline", bookingsHandler.DeclineTransfer)
+	transferRoutes.Delete("/:id", bookingsHandler.WithdrawTransfer)
+
+	// Maintenance
+	maintenanceRoutes := v1.Group("/maintenance", requireAuth)
+	maintenanceRoutes.Post("/", bookingsHandler.CreateMaintenance)
+	maintenanceRoutes.Get("/", bookingsHandler.ListMaintenance)
+	maintenanceRoutes.Delete("/:id", bookingsHandler.DeleteMaintenance)
+
+	// Closures
+	closureRoutes := v1.Group("/closures", requireAuth)
+	closureRoutes.Post("/", bookingsHandler.CreateClosure)
+	closureRoutes.Get("/", bookingsHandler.ListClosures)
+	closureRoutes.Delete("/:id", bookingsHandler.DeleteClosure)
+
+	// Opening hours
+	openingHoursRoutes := v1.Group("/opening-hours", requireAuth)
+	openingHoursRoutes.Put("/", bookingsHandler.SetOpeningHours)
+	openingHoursRoutes.Get("/", bookingsHandler.ListOpeningHours)
+	openingHoursRoutes.Delete("/:id", bookingsHandler.DeleteOpeningHours)
+
+	// Quotas
+	quotaRoutes := v1.Group("/quotas", requireAuth)
+	quotaRoutes.Put("/", bookingsHandler.SetQuota)
+	quotaRoutes.Get("/", bookingsHandler.ListQuotas)
+	quotaRoutes.Delete("/:id", bookingsHandler.DeleteQuota)
+	v1.Get("/me/quota", requireAuth, bookingsHandler.MyQuota)
+
+	// Availability
+	v1.Get("/availability", requireAuth, bookingsHandler.Availability)
+	v1.Get("/availability/suggestions", requireAuth, bookingsHandler.Suggestions)
+	v1.Get("/timeline", requireAuth, bookingsHandler.Timeline)
+
+	// Background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go bookings.NewNoShowSweeper(bookingsService, bookings.DefaultNoShowSweepInterval, logger).Run(workerCtx)
+	go bookings.NewHoldSweeper(bookingsService, bookings.DefaultHoldSweepInterval, logger).Run(workerCtx)
+
 	// Graceful shutdown
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -97,6 +189,7 @@ func main() {
 		<-sigChan
 
 		logger.Info("Shutting down server...")
+		stopWorkers()
 		app.Shutdown()
 	}()
 
@@ -193,6 +193,23 @@ func (h *Handler) LogoutAll(c *fiber.Ctx) error {
 	})
 }
 
+// Me handles GET /api/v1/auth/me
+// This endpoint requires authentication (user ID from context)
+func (h *Handler) Me(c *fiber.Ctx) error {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	user, err := h.service.GetCurrentUser(c.Context(), userID)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, toUserResponse(user))
+}
+
 // handleServiceError converts service errors to HTTP responses
 func (h *Handler) handleServiceError(c *fiber.Ctx, err error) error {
 	switch {
@@ -210,6 +227,8 @@ func (h *Handler) handleServiceError(c *fiber.Ctx, err error) error {
 		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Invalid or expired refresh token")
 	case errors.Is(err, ErrSessionNotFound):
 		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Session not found")
+	case errors.Is(err, ErrUserNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "User not found")
 	default:
 		return response.Error(c, fiber.StatusInternalServerError, response.ErrCodeInternalServer, "An unexpected error occurred")
 	}
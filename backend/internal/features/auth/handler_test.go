@@ -28,6 +28,11 @@ func setupTestApp(handler *Handler) *fiber.App {
 		c.Locals("user_id", "user-123")
 		return handler.LogoutAll(c)
 	})
+	api.Get("/me", func(c *fiber.Ctx) error {
+		// Simulate auth middleware setting user_id
+		c.Locals("user_id", "user-123")
+		return handler.Me(c)
+	})
 	return app
 }
 
@@ -521,3 +526,93 @@ func TestHandler_LogoutAll_NoAuth(t *testing.T) {
 		t.Errorf("expected status 401, got %d", resp.StatusCode)
 	}
 }
+
+// ============================================================================
+// Me Handler Tests
+// ============================================================================
+
+func TestHandler_Me_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserByIDFunc: func(_ context.Context, id string) (*User, error) {
+			return &User{
+				ID:        id,
+				Email:     "test@example.com",
+				Role:      RoleMember,
+				Status:    StatusActive,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}, nil
+		},
+	}
+	service := NewService(mockRepo, &MockJWTManager{})
+	handler := NewHandler(service)
+	app := setupTestApp(handler)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/me", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	data, ok := apiResp.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected user object, got %v", apiResp.Data)
+	}
+	if data["id"] != "user-123" {
+		t.Errorf("expected id user-123, got %v", data["id"])
+	}
+	if _, exists := data["password_hash"]; exists {
+		t.Error("expected password_hash to be omitted")
+	}
+}
+
+func TestHandler_Me_UserNotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserByIDFunc: func(_ context.Context, _ string) (*User, error) {
+			return nil, ErrUserNotFound
+		},
+	}
+	service := NewService(mockRepo, &MockJWTManager{})
+	handler := NewHandler(service)
+	app := setupTestApp(handler)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/me", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected status 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Me_NoAuth(t *testing.T) {
+	service := NewService(&MockRepository{}, &MockJWTManager{})
+	handler := NewHandler(service)
+
+	// Create app without setting user_id in context
+	app := fiber.New()
+	app.Get("/api/v1/auth/me", handler.Me)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/me", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
@@ -255,6 +255,11 @@ func (s *Service) LogoutAll(ctx context.Context, userID string) (int64, error) {
 	return s.repo.DeleteAllUserSessions(ctx, userID)
 }
 
+// GetCurrentUser retrieves the user identified by an access token's subject
+func (s *Service) GetCurrentUser(ctx context.Context, userID string) (*User, error) {
+	return s.repo.GetUserByID(ctx, userID)
+}
+
 // isValidEmail validates email format using regex
 func isValidEmail(email string) bool {
 	return emailRegex.MatchString(email)
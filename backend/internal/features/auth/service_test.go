@@ -506,6 +506,43 @@ func TestLogoutAll_NoSessions(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// GetCurrentUser Tests
+// ============================================================================
+
+func TestGetCurrentUser_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserByIDFunc: func(_ context.Context, id string) (*User, error) {
+			return &User{ID: id, Email: "test@example.com", Role: RoleMember, Status: StatusActive}, nil
+		},
+	}
+
+	service := NewService(mockRepo, &MockJWTManager{})
+	user, err := service.GetCurrentUser(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.ID != "user-123" {
+		t.Errorf("expected user-123, got %s", user.ID)
+	}
+}
+
+func TestGetCurrentUser_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserByIDFunc: func(_ context.Context, _ string) (*User, error) {
+			return nil, ErrUserNotFound
+		},
+	}
+
+	service := NewService(mockRepo, &MockJWTManager{})
+	_, err := service.GetCurrentUser(context.Background(), "user-123")
+
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
 // ============================================================================
 // Email Validation Tests
 // ============================================================================
@@ -0,0 +1,258 @@
+package bookings
+
+import (
+	"sort"
+	"time"
+)
+
+// freeIntervals returns the parts of window not covered by any busy interval, in chronological order.
+// Busy intervals may be unsorted, overlap each other or extend past the window.
+func freeIntervals(window Interval, busy []Interval) []Interval {
+	sorted := make([]Interval, len(busy))
+	copy(sorted, busy)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	var free []Interval
+	cursor := window.StartTime
+	for _, interval := range sorted {
+		if !interval.EndTime.After(cursor) {
+			continue
+		}
+		if !interval.StartTime.Before(window.EndTime) {
+			break
+		}
+		if interval.StartTime.After(cursor) {
+			free = append(free, Interval{StartTime: cursor, EndTime: interval.StartTime})
+		}
+		cursor = interval.EndTime
+	}
+	if window.EndTime.After(cursor) {
+		free = append(free, Interval{StartTime: cursor, EndTime: window.EndTime})
+	}
+
+	return free
+}
+
+// bookableIntervals returns the parts of window a new booking on desk could cover. blocked holds
+// the ranges existing bookings keep the desk, each including its own cleaning buffer; a new booking
+// also needs the desk's buffer free after it, so every blocked range is widened by it at the start.
+// This mirrors the no_overlapping_bookings constraint.
+func bookableIntervals(window Interval, desk Desk, blocked []Interval) []Interval {
+	buffer := time.Duration(desk.CleaningBufferMinutes) * time.Minute
+	widened := make([]Interval, len(blocked))
+	for i, interval := range blocked {
+		widened[i] = Interval{StartTime: interval.StartTime.Add(-buffer), EndTime: interval.EndTime}
+	}
+	return freeIntervals(window, widened)
+}
+
+// subtractIntervals returns the parts of intervals not covered by any of remove, in chronological
+// order. Intervals must be chronological and non-overlapping.
+func subtractIntervals(intervals, remove []Interval) []Interval {
+	if len(remove) == 0 {
+		return intervals
+	}
+	var rest []Interval
+	for _, interval := range intervals {
+		rest = append(rest, freeIntervals(interval, remove)...)
+	}
+	return rest
+}
+
+// occupancyFreeIntervals returns the parts of window a new booking on the desk could cover: clear
+// of its busy ranges with the desk's cleaning buffer respected, and outside its wing's closures.
+// A booking may end right when a closure starts; no buffer is kept before a closure.
+func occupancyFreeIntervals(window Interval, occupancy *DeskOccupancy) []Interval {
+	return subtractIntervals(bookableIntervals(window, occupancy.Desk, occupancy.Busy), occupancy.Closed)
+}
+
+// overlapsAny reports whether interval overlaps any of intervals
+func overlapsAny(interval Interval, intervals []Interval) bool {
+	for _, other := range intervals {
+		if interval.StartTime.Before(other.EndTime) && other.StartTime.Before(interval.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// closureIntervals returns the time ranges of closures
+func closureIntervals(closures []*Closure) []Interval {
+	intervals := make([]Interval, 0, len(closures))
+	for _, closure := range closures {
+		intervals = append(intervals, Interval{StartTime: closure.StartTime, EndTime: closure.EndTime})
+	}
+	return intervals
+}
+
+// blockedInterval returns the range a booking keeps its desk: until its actual end for early
+// check-outs, followed by its cleaning buffer
+func blockedInterval(booking *Booking) Interval {
+	end := booking.EndTime
+	if booking.ActualEndTime != nil {
+		end = *booking.ActualEndTime
+	}
+	return Interval{
+		StartTime: booking.StartTime,
+		EndTime:   end.Add(time.Duration(booking.BufferMinutes) * time.Minute),
+	}
+}
+
+// isWholeWindow reports whether free consists of a single interval covering window
+func isWholeWindow(window Interval, free []Interval) bool {
+	return len(free) == 1 && free[0].StartTime.Equal(window.StartTime) && free[0].EndTime.Equal(window.EndTime)
+}
+
+// openingHoursOn returns the opening hours of the day starting at day, a midnight in the site
+// timezone, for a wing, or for the whole
+// office when wing is nil. The wing's own schedule entry for the weekday wins over the office-wide
+// one, and weekdays without either keep the default opening hours. ok is false on closed days.
+func openingHoursOn(day time.Time, wing *string, settings *Settings) (opening Interval, ok bool) {
+	opens, closes := settings.OpeningStart, settings.OpeningEnd
+
+	var entry *OpeningHours
+	for _, hours := range settings.Schedule {
+		if hours.Weekday != day.Weekday() {
+			continue
+		}
+		if hours.Wing == nil && entry == nil || hours.Wing != nil && wing != nil && *hours.Wing == *wing {
+			entry = hours
+		}
+	}
+	if entry != nil {
+		if entry.Opens == nil || entry.Closes == nil {
+			return Interval{}, false
+		}
+		opens, closes = time.Duration(*entry.Opens), time.Duration(*entry.Closes)
+	}
+
+	return Interval{StartTime: atTimeOfDay(day, opens), EndTime: atTimeOfDay(day, closes)}, true
+}
+
+// openingIntervals returns each day's opening hours for a wing that overlap window, clipped to window
+func openingIntervals(window Interval, wing *string, settings *Settings) []Interval {
+	var open []Interval
+	for day := startOfDay(window.StartTime, settings.location()); day.Before(window.EndTime); day = day.AddDate(0, 0, 1) {
+		opening, ok := openingHoursOn(day, wing, settings)
+		if !ok {
+			continue
+		}
+		opening = clipInterval(opening, window)
+		if opening.EndTime.After(opening.StartTime) {
+			open = append(open, opening)
+		}
+	}
+	return open
+}
+
+// intersectIntervals returns the overlap of two chronological lists of non-overlapping intervals
+func intersectIntervals(a, b []Interval) []Interval {
+	var overlap []Interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].StartTime, a[i].EndTime
+		if b[j].StartTime.After(start) {
+			start = b[j].StartTime
+		}
+		if b[j].EndTime.Before(end) {
+			end = b[j].EndTime
+		}
+		if end.After(start) {
+			overlap = append(overlap, Interval{StartTime: start, EndTime: end})
+		}
+		if a[i].EndTime.Before(b[j].EndTime) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return overlap
+}
+
+// clipInterval trims interval to window; the result is empty when they do not overlap
+func clipInterval(interval, window Interval) Interval {
+	if interval.StartTime.Before(window.StartTime) {
+		interval.StartTime = window.StartTime
+	}
+	if interval.EndTime.After(window.EndTime) {
+		interval.EndTime = window.EndTime
+	}
+	return interval
+}
+
+// buildTimeline breaks day into a busy segment per booking, a maintenance segment per block, a
+// closed segment per closure and, for bookable desks, free segments within the open intervals.
+// Cleaning buffers are neither busy nor free. Owners are shown to admins and on the actor's own
+// bookings only.
+func buildTimeline(day Interval, desk *DeskBookings, open []Interval, actor Actor) []TimelineSegment {
+	segments := make([]TimelineSegment, 0, 2*(len(desk.Bookings)+len(desk.Maintenance)+len(desk.Closures))+1)
+	blocked := make([]Interval, 0, len(desk.Bookings)+len(desk.Maintenance))
+	for _, booking := range desk.Bookings {
+		blocked = append(blocked, blockedInterval(booking))
+
+		// A checked-out booking only occupies the desk until its actual end
+		interval := Interval{StartTime: booking.StartTime, EndTime: booking.EndTime}
+		if booking.ActualEndTime != nil {
+			interval.EndTime = *booking.ActualEndTime
+		}
+		interval = clipInterval(interval, day)
+		if !interval.EndTime.After(interval.StartTime) {
+			continue
+		}
+
+		segment := TimelineSegment{
+			Type:      SegmentBusy,
+			StartTime: interval.StartTime,
+			EndTime:   interval.EndTime,
+			BookingID: &booking.ID,
+			Status:    &booking.Status,
+		}
+		if actor.IsAdmin() || booking.UserID == actor.UserID {
+			segment.UserID = &booking.UserID
+		}
+		segments = append(segments, segment)
+	}
+
+	for _, block := range desk.Maintenance {
+		blocked = append(blocked, Interval{StartTime: block.StartTime, EndTime: block.EndTime})
+
+		interval := clipInterval(Interval{StartTime: block.StartTime, EndTime: block.EndTime}, day)
+		if !interval.EndTime.After(interval.StartTime) {
+			continue
+		}
+		segments = append(segments, TimelineSegment{
+			Type:          SegmentMaintenance,
+			StartTime:     interval.StartTime,
+			EndTime:       interval.EndTime,
+			MaintenanceID: &block.ID,
+			Reason:        &block.Reason,
+		})
+	}
+
+	for _, closure := range desk.Closures {
+		interval := clipInterval(Interval{StartTime: closure.StartTime, EndTime: closure.EndTime}, day)
+		if !interval.EndTime.After(interval.StartTime) {
+			continue
+		}
+		segments = append(segments, TimelineSegment{
+			Type:      SegmentClosed,
+			StartTime: interval.StartTime,
+			EndTime:   interval.EndTime,
+			ClosureID: &closure.ID,
+			Reason:    &closure.Reason,
+		})
+	}
+
+	if desk.Desk.Status == deskStatusAvailable {
+		bookable := subtractIntervals(bookableIntervals(day, desk.Desk, blocked), closureIntervals(desk.Closures))
+		for _, free := range intersectIntervals(bookable, open) {
+			segments = append(segments, TimelineSegment{Type: SegmentFree, StartTime: free.StartTime, EndTime: free.EndTime})
+		}
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].StartTime.Before(segments[j].StartTime)
+	})
+	return segments
+}
@@ -0,0 +1,287 @@
+package bookings
+
+import (
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// freeIntervals Tests
+// ============================================================================
+
+func TestFreeIntervals(t *testing.T) {
+	base := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+	window := Interval{StartTime: at(0), EndTime: at(10)}
+
+	tests := []struct {
+		name     string
+		busy     []Interval
+		expected []Interval
+	}{
+		{
+			name:     "no bookings",
+			busy:     nil,
+			expected: []Interval{{at(0), at(10)}},
+		},
+		{
+			name:     "booking in the middle",
+			busy:     []Interval{{at(2), at(4)}},
+			expected: []Interval{{at(0), at(2)}, {at(4), at(10)}},
+		},
+		{
+			name:     "bookings past both edges",
+			busy:     []Interval{{at(-2), at(1)}, {at(9), at(12)}},
+			expected: []Interval{{at(1), at(9)}},
+		},
+		{
+			name:     "unsorted back-to-back bookings",
+			busy:     []Interval{{at(5), at(7)}, {at(3), at(5)}},
+			expected: []Interval{{at(0), at(3)}, {at(7), at(10)}},
+		},
+		{
+			name:     "overlapping bookings",
+			busy:     []Interval{{at(1), at(6)}, {at(2), at(3)}},
+			expected: []Interval{{at(0), at(1)}, {at(6), at(10)}},
+		},
+		{
+			name:     "fully booked",
+			busy:     []Interval{{at(-1), at(4)}, {at(4), at(11)}},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			free := freeIntervals(window, tt.busy)
+			if len(free) != len(tt.expected) {
+				t.Fatalf("expected %d free intervals, got %d: %v", len(tt.expected), len(free), free)
+			}
+			for i := range free {
+				if !free[i].StartTime.Equal(tt.expected[i].StartTime) || !free[i].EndTime.Equal(tt.expected[i].EndTime) {
+					t.Errorf("interval %d: expected %v, got %v", i, tt.expected[i], free[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOpeningIntervals(t *testing.T) {
+	settings := &Settings{OpeningStart: 8 * time.Hour, OpeningEnd: 22 * time.Hour}
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	open := openingIntervals(Interval{StartTime: day.Add(10 * time.Hour), EndTime: day.Add(33 * time.Hour)}, nil, settings)
+
+	expected := []Interval{
+		{day.Add(10 * time.Hour), day.Add(22 * time.Hour)},
+		{day.Add(32 * time.Hour), day.Add(33 * time.Hour)},
+	}
+	if len(open) != len(expected) {
+		t.Fatalf("expected %d intervals, got %v", len(expected), open)
+	}
+	for i := range open {
+		if !open[i].StartTime.Equal(expected[i].StartTime) || !open[i].EndTime.Equal(expected[i].EndTime) {
+			t.Errorf("interval %d: expected %v, got %v", i, expected[i], open[i])
+		}
+	}
+}
+
+func TestOpeningHoursOn_Schedule(t *testing.T) {
+	hours := func(h int) *TimeOfDay {
+		offset := TimeOfDay(time.Duration(h) * time.Hour)
+		return &offset
+	}
+	east, west := WingEast, WingWest
+	settings := &Settings{
+		OpeningStart: 8 * time.Hour,
+		OpeningEnd:   22 * time.Hour,
+		Schedule: []*OpeningHours{
+			{Weekday: time.Saturday, Opens: hours(10), Closes: hours(16)},
+			{Weekday: time.Sunday},
+			{Wing: &east, Weekday: time.Monday, Opens: hours(7), Closes: hours(22)},
+			{Wing: &east, Weekday: time.Saturday, Opens: hours(9), Closes: hours(16)},
+		},
+	}
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	saturday, sunday := monday.AddDate(0, 0, 5), monday.AddDate(0, 0, 6)
+
+	tests := []struct {
+		name   string
+		day    time.Time
+		wing   *string
+		open   bool
+		opens  time.Duration
+		closes time.Duration
+	}{
+		{"default hours", monday, nil, true, 8 * time.Hour, 22 * time.Hour},
+		{"wing override", monday, &east, true, 7 * time.Hour, 22 * time.Hour},
+		{"other wing keeps default", monday, &west, true, 8 * time.Hour, 22 * time.Hour},
+		{"office-wide entry", saturday, &west, true, 10 * time.Hour, 16 * time.Hour},
+		{"wing override of office-wide entry", saturday, &east, true, 9 * time.Hour, 16 * time.Hour},
+		{"closed for every wing", sunday, &east, false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opening, ok := openingHoursOn(tt.day, tt.wing, settings)
+			if ok != tt.open {
+				t.Fatalf("expected open to be %v, got %v", tt.open, ok)
+			}
+			if ok && (!opening.StartTime.Equal(tt.day.Add(tt.opens)) || !opening.EndTime.Equal(tt.day.Add(tt.closes))) {
+				t.Errorf("expected %v-%v, got %v-%v", tt.opens, tt.closes, opening.StartTime, opening.EndTime)
+			}
+		})
+	}
+
+	// Closed days leave a gap in the intervals of a window
+	open := openingIntervals(Interval{StartTime: saturday, EndTime: saturday.AddDate(0, 0, 3)}, &west, settings)
+	if len(open) != 2 || !open[0].StartTime.Equal(saturday.Add(10*time.Hour)) || !open[1].StartTime.Equal(sunday.Add(32*time.Hour)) {
+		t.Errorf("expected Saturday and Monday hours only, got %v", open)
+	}
+}
+
+func TestIntersectIntervals(t *testing.T) {
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+
+	a := []Interval{{at(0), at(4)}, {at(6), at(12)}}
+	b := []Interval{{at(2), at(7)}, {at(10), at(20)}}
+
+	overlap := intersectIntervals(a, b)
+
+	expected := []Interval{{at(2), at(4)}, {at(6), at(7)}, {at(10), at(12)}}
+	if len(overlap) != len(expected) {
+		t.Fatalf("expected %d intervals, got %v", len(expected), overlap)
+	}
+	for i := range overlap {
+		if !overlap[i].StartTime.Equal(expected[i].StartTime) || !overlap[i].EndTime.Equal(expected[i].EndTime) {
+			t.Errorf("interval %d: expected %v, got %v", i, expected[i], overlap[i])
+		}
+	}
+}
+
+func TestBookableIntervals_CleaningBuffer(t *testing.T) {
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hours, minutes int) time.Time {
+		return base.Add(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	}
+	desk := Desk{ID: 1, CleaningBufferMinutes: 15}
+
+	// The booking runs 11:00-13:00 and keeps its own 15-minute buffer after it
+	blocked := []Interval{{at(11, 0), at(13, 15)}}
+
+	free := bookableIntervals(Interval{StartTime: at(9, 0), EndTime: at(17, 0)}, desk, blocked)
+
+	// A new booking must end 15 minutes before 11:00 so its own buffer fits
+	expected := []Interval{{at(9, 0), at(10, 45)}, {at(13, 15), at(17, 0)}}
+	if len(free) != len(expected) {
+		t.Fatalf("expected %d intervals, got %v", len(expected), free)
+	}
+	for i := range free {
+		if !free[i].StartTime.Equal(expected[i].StartTime) || !free[i].EndTime.Equal(expected[i].EndTime) {
+			t.Errorf("interval %d: expected %v, got %v", i, expected[i], free[i])
+		}
+	}
+}
+
+func TestBlockedInterval(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	checkedOut := start.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		booking  *Booking
+		expected time.Time
+	}{
+		{"no buffer", &Booking{StartTime: start, EndTime: start.Add(2 * time.Hour)}, start.Add(2 * time.Hour)},
+		{"buffer", &Booking{StartTime: start, EndTime: start.Add(2 * time.Hour), BufferMinutes: 15}, start.Add(135 * time.Minute)},
+		{"checked out early", &Booking{StartTime: start, EndTime: start.Add(2 * time.Hour), ActualEndTime: &checkedOut, BufferMinutes: 15}, start.Add(75 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := blockedInterval(tt.booking)
+			if !blocked.StartTime.Equal(start) || !blocked.EndTime.Equal(tt.expected) {
+				t.Errorf("expected %v-%v, got %v-%v", start, tt.expected, blocked.StartTime, blocked.EndTime)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Site Timezone Tests
+// ============================================================================
+
+// loadLocation loads an IANA zone for tests that cross DST changes
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestOpeningIntervals_DSTChange(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+	settings := &Settings{OpeningStart: 8 * time.Hour, OpeningEnd: 22 * time.Hour, Location: london}
+
+	// The clocks go forward at 01:00 UTC on 29 March 2026 and back at 01:00 UTC on 25 October
+	tests := []struct {
+		name  string
+		day   time.Time
+		opens time.Time
+		close time.Time
+	}{
+		{"before spring change", time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 28, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)},
+		{"spring change", time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC), time.Date(2026, 3, 29, 21, 0, 0, 0, time.UTC)},
+		{"autumn change", time.Date(2026, 10, 24, 23, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC), time.Date(2026, 10, 25, 22, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A window covering the whole local day, whatever its length
+			day := startOfDay(tt.day, london)
+			open := openingIntervals(Interval{StartTime: day, EndTime: day.AddDate(0, 0, 1)}, nil, settings)
+			if len(open) != 1 || !open[0].StartTime.Equal(tt.opens) || !open[0].EndTime.Equal(tt.close) {
+				t.Errorf("expected %v-%v, got %v", tt.opens, tt.close, open)
+			}
+		})
+	}
+}
+
+func TestHoursWithinDay_DSTChange(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+
+	// 29 March 2026 is 23 hours long in London: it ends at 23:00 UTC
+	day := time.Date(2026, 3, 29, 0, 0, 0, 0, london)
+	start := time.Date(2026, 3, 29, 22, 0, 0, 0, time.UTC)
+	if hours := hoursWithinDay(start, start.Add(90*time.Minute), day); hours != 1 {
+		t.Errorf("expected 1 hour before local midnight, got %v", hours)
+	}
+
+	// 25 October 2026 is 25 hours long
+	day = time.Date(2026, 10, 25, 0, 0, 0, 0, london)
+	if hours := hoursWithinDay(day, day.AddDate(0, 0, 2), day); hours != 25 {
+		t.Errorf("expected a 25 hour day, got %v", hours)
+	}
+}
+
+func TestStartOfDay_SiteTimezone(t *testing.T) {
+	singapore := loadLocation(t, "Asia/Singapore")
+
+	// 17:00 UTC is already 01:00 the next day in Singapore
+	day := startOfDay(time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC), singapore)
+	if !day.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, singapore)) {
+		t.Errorf("expected 11 March in Singapore, got %v", day)
+	}
+
+	// Dates parsed from YYYY-MM-DD keep their calendar date
+	date := dateIn(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), singapore)
+	if !date.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, singapore)) {
+		t.Errorf("expected 10 March in Singapore, got %v", date)
+	}
+}
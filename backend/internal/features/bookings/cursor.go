@@ -0,0 +1,49 @@
+package bookings
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// cursorPayload is the JSON form of a BookingCursor before base64 encoding
+type cursorPayload struct {
+	StartTime time.Time `json:"s"`
+	ID        int       `json:"i"`
+	Before    bool      `json:"b,omitempty"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c *BookingCursor) Encode() string {
+	data, _ := json.Marshal(cursorPayload{StartTime: c.StartTime, ID: c.ID, Before: c.Before})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeBookingCursor parses a token produced by BookingCursor.Encode
+func DecodeBookingCursor(token string) (*BookingCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID <= 0 || payload.StartTime.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &BookingCursor{StartTime: payload.StartTime, ID: payload.ID, Before: payload.Before}, nil
+}
+
+// cursorAfter returns the cursor for the page following booking
+func cursorAfter(booking *Booking) *BookingCursor {
+	return &BookingCursor{StartTime: booking.StartTime, ID: booking.ID}
+}
+
+// cursorBefore returns the cursor for the page preceding booking
+func cursorBefore(booking *Booking) *BookingCursor {
+	return &BookingCursor{StartTime: booking.StartTime, ID: booking.ID, Before: true}
+}
@@ -0,0 +1,44 @@
+package bookings
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Cursor Tests
+// ============================================================================
+
+func TestBookingCursor_RoundTrip(t *testing.T) {
+	cursor := &BookingCursor{
+		StartTime: time.Date(2026, time.March, 10, 9, 0, 0, 123000, time.UTC),
+		ID:        42,
+		Before:    true,
+	}
+
+	decoded, err := DecodeBookingCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !decoded.StartTime.Equal(cursor.StartTime) || decoded.ID != cursor.ID || !decoded.Before {
+		t.Errorf("expected %+v, got %+v", cursor, decoded)
+	}
+}
+
+func TestDecodeBookingCursor_Invalid(t *testing.T) {
+	tests := []string{
+		"not base64!",
+		"bm90IGpzb24",                            // "not json"
+		"eyJpIjo0Mn0",                            // {"i":42} without a start time
+		"eyJzIjoiMjAyNi0wMy0xMFQwOTowMDowMFoifQ", // start time without an id
+	}
+
+	for _, token := range tests {
+		t.Run(token, func(t *testing.T) {
+			if _, err := DecodeBookingCursor(token); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
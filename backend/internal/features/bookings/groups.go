@@ -0,0 +1,38 @@
+package bookings
+
+// maxGroupSize caps how many attendees a single group booking may have
+const maxGroupSize = 50
+
+// pickAdjacentDesks returns n desks that are free for the whole window, as close together as
+// possible. occupancy is ordered by desk number; among every run of n free desks the one spanning
+// the fewest positions wins, the earliest on ties. When fewer than n desks are free, all of them
+// are returned.
+func pickAdjacentDesks(occupancy []*DeskOccupancy, window Interval, n int) []Desk {
+	var positions []int
+	for i, desk := range occupancy {
+		if isWholeWindow(window, occupancyFreeIntervals(window, desk)) {
+			positions = append(positions, i)
+		}
+	}
+
+	if len(positions) <= n {
+		desks := make([]Desk, 0, len(positions))
+		for _, position := range positions {
+			desks = append(desks, occupancy[position].Desk)
+		}
+		return desks
+	}
+
+	best := 0
+	for i := 1; i+n <= len(positions); i++ {
+		if positions[i+n-1]-positions[i] < positions[best+n-1]-positions[best] {
+			best = i
+		}
+	}
+
+	desks := make([]Desk, 0, n)
+	for _, position := range positions[best : best+n] {
+		desks = append(desks, occupancy[position].Desk)
+	}
+	return desks
+}
@@ -0,0 +1,87 @@
+package bookings
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// pickAdjacentDesks Tests
+// ============================================================================
+
+func TestPickAdjacentDesks(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	window := Interval{StartTime: base, EndTime: base.Add(8 * time.Hour)}
+	morning := []Interval{{StartTime: base, EndTime: base.Add(time.Hour)}}
+
+	// occupancy builds desks 1..n in desk number order; busy desks have a morning booking
+	occupancy := func(n int, busy ...int) []*DeskOccupancy {
+		isBusy := map[int]bool{}
+		for _, id := range busy {
+			isBusy[id] = true
+		}
+		var desks []*DeskOccupancy
+		for id := 1; id <= n; id++ {
+			desk := &DeskOccupancy{Desk: Desk{ID: id, DeskNumber: fmt.Sprintf("E%d", id)}}
+			if isBusy[id] {
+				desk.Busy = morning
+			}
+			desks = append(desks, desk)
+		}
+		return desks
+	}
+
+	tests := []struct {
+		name      string
+		occupancy []*DeskOccupancy
+		n         int
+		expected  []int
+	}{
+		{
+			name:      "all free takes the first run",
+			occupancy: occupancy(5),
+			n:         3,
+			expected:  []int{1, 2, 3},
+		},
+		{
+			name:      "skips a gap to find a contiguous run",
+			occupancy: occupancy(6, 2),
+			n:         3,
+			expected:  []int{3, 4, 5},
+		},
+		{
+			name:      "tightest run when none is contiguous",
+			occupancy: occupancy(8, 2, 4, 5, 7),
+			n:         3,
+			expected:  []int{1, 3, 6},
+		},
+		{
+			name:      "fewer free desks than requested",
+			occupancy: occupancy(4, 1, 3),
+			n:         3,
+			expected:  []int{2, 4},
+		},
+		{
+			name:      "nothing free",
+			occupancy: occupancy(2, 1, 2),
+			n:         1,
+			expected:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desks := pickAdjacentDesks(tt.occupancy, window, tt.n)
+			if len(desks) != len(tt.expected) {
+				t.Fatalf("expected desks %v, got %v", tt.expected, desks)
+			}
+			for i, desk := range desks {
+				if desk.ID != tt.expected[i] {
+					t.Errorf("expected desks %v, got %v", tt.expected, desks)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,1486 @@
+package bookings
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/justinyeo/hotdesk-booking/backend/internal/middleware"
+	"github.com/justinyeo/hotdesk-booking/backend/internal/shared/response"
+)
+
+// Booking-specific error codes
+const (
+	ErrCodeBookingConflict     = "BOOKING_CONFLICT"
+	ErrCodeOutsideOpeningHours = "OUTSIDE_OPENING_HOURS"
+	ErrCodeDailyLimitExceeded  = "DAILY_LIMIT_EXCEEDED"
+	ErrCodeBookingNotActive    = "BOOKING_NOT_ACTIVE"
+	ErrCodeSeriesConflict      = "SERIES_CONFLICT"
+	ErrCodeBookingStarted      = "BOOKING_STARTED"
+	ErrCodeNotSeriesBooking    = "NOT_SERIES_BOOKING"
+	ErrCodeWaitlistClosed      = "WAITLIST_ENTRY_CLOSED"
+	ErrCodeCheckInNotOpen      = "CHECK_IN_NOT_OPEN"
+	ErrCodeCheckInClosed       = "CHECK_IN_CLOSED"
+	ErrCodeAlreadyCheckedIn    = "ALREADY_CHECKED_IN"
+	ErrCodeNotCheckedIn        = "NOT_CHECKED_IN"
+	ErrCodeInvalidTransition   = "INVALID_STATUS_TRANSITION"
+	ErrCodeDeskNotAvailable    = "DESK_NOT_AVAILABLE"
+	ErrCodeTransferClosed      = "TRANSFER_CLOSED"
+	ErrCodeTransferPending     = "TRANSFER_PENDING"
+	ErrCodeGroupConflict       = "GROUP_CONFLICT"
+	ErrCodeDeskNotFound        = "DESK_NOT_FOUND"
+	ErrCodeUserNotFound        = "USER_NOT_FOUND"
+	ErrCodeNoDeskAvailable     = "NO_DESK_AVAILABLE"
+	ErrCodeHoldExpired         = "HOLD_EXPIRED"
+	ErrCodeMaintenanceConflict = "MAINTENANCE_CONFLICT"
+	ErrCodeClosed              = "OFFICE_CLOSED"
+	ErrCodeQuotaExceeded       = "QUOTA_EXCEEDED"
+	ErrCodeLeadTimeTooShort    = "LEAD_TIME_TOO_SHORT"
+	ErrCodeBeyondAdvanceWindow = "BEYOND_ADVANCE_WINDOW"
+)
+
+// Page sizes of booking listings; every listing is paged so that long histories stay cheap
+const (
+	defaultBookingPageSize = 50
+	maxBookingPageSize     = 200
+)
+
+// Handler handles HTTP requests for bookings
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new bookings handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateBookingRequest represents the request body for creating a booking.
+// Setting Hold reserves the desk tentatively until the hold is confirmed or expires.
+type CreateBookingRequest struct {
+	DeskID    int       `json:"desk_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Hold      bool      `json:"hold"`
+}
+
+// CreateSeriesRequest represents the request body for creating a recurring booking series
+type CreateSeriesRequest struct {
+	DeskID    int       `json:"desk_id"`
+	StartTime time.Time `json:"start_time"` // Start of the first occurrence
+	EndTime   time.Time `json:"end_time"`   // End of the first occurrence
+	RRule     string    `json:"rrule"`
+	ExDates   []string  `json:"exdates"` // RFC3339 occurrence starts or YYYY-MM-DD dates
+	Partial   bool      `json:"partial"`
+}
+
+// CreateGroupRequest represents the request body for booking desks for a team.
+// Either every attendee names a desk_id, or wing is set and desks are picked for them.
+type CreateGroupRequest struct {
+	StartTime time.Time       `json:"start_time"`
+	EndTime   time.Time       `json:"end_time"`
+	Attendees []GroupAttendee `json:"attendees"`
+	Wing      *string         `json:"wing"`
+	Features  []string        `json:"features"`
+}
+
+// GroupConflictDetails represents the error details when a group cannot be booked
+type GroupConflictDetails struct {
+	Failures []GroupFailure `json:"failures"`
+}
+
+// JoinWaitlistRequest represents the request body for joining the waitlist.
+// Exactly one of DeskID and Wing must be set.
+type JoinWaitlistRequest struct {
+	DeskID    *int      `json:"desk_id"`
+	Wing      *string   `json:"wing"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// SeriesConflictDetails represents the error details when a series cannot be booked
+type SeriesConflictDetails struct {
+	Skipped []SkippedOccurrence `json:"skipped"`
+}
+
+// MoveBookingRequest represents the request body for moving a booking to another desk and/or time
+type MoveBookingRequest struct {
+	DeskID    *int       `json:"desk_id"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Force     bool       `json:"force"` // Admins only
+	Reason    string     `json:"reason"`
+}
+
+// OfferTransferRequest represents the request body for offering a booking to a colleague.
+// Setting SwapBookingID turns the offer into a swap for one of the colleague's bookings.
+type OfferTransferRequest struct {
+	ToUserID      string `json:"to_user_id"`
+	SwapBookingID *int   `json:"swap_booking_id"`
+}
+
+// BulkCancelRequest represents the request body for an admin cancelling bookings on closed desks.
+// At least one of DeskIDs and Wing is required.
+type BulkCancelRequest struct {
+	DeskIDs   []int     `json:"desk_ids"`
+	Wing      *string   `json:"wing"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+	DryRun    bool      `json:"dry_run"`
+}
+
+// CreateMaintenanceRequest represents the request body for blocking a desk for maintenance.
+// Policy is one of reject (default), cancel or move.
+type CreateMaintenanceRequest struct {
+	DeskID    int               `json:"desk_id"`
+	StartTime time.Time         `json:"start_time"`
+	EndTime   time.Time         `json:"end_time"`
+	Reason    string            `json:"reason"`
+	Policy    MaintenancePolicy `json:"policy"`
+}
+
+// CreateClosureRequest represents the request body for closing the office or a wing.
+// Either date, with an optional inclusive end_date, closes whole days (YYYY-MM-DD), or start_time
+// and end_time close part of a day.
+type CreateClosureRequest struct {
+	Wing      *string   `json:"wing"`
+	Date      string    `json:"date"`
+	EndDate   string    `json:"end_date"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+}
+
+// SetOpeningHoursRequest represents the request body for setting a weekday's opening hours.
+// weekday runs from 0 (Sunday) to 6; opens_at and closes_at are HH:MM times, both left out to
+// close the day. wing limits the entry to one wing.
+type SetOpeningHoursRequest struct {
+	Wing     *string `json:"wing"`
+	Weekday  *int    `json:"weekday"`
+	OpensAt  string  `json:"opens_at"`
+	ClosesAt string  `json:"closes_at"`
+}
+
+// SetQuotaRequest represents the request body for setting a role's weekly or monthly quota.
+// Leaving out max_hours or max_bookings leaves that measure uncapped.
+type SetQuotaRequest struct {
+	Role        string `json:"role"`
+	Period      string `json:"period"`
+	MaxHours    *int   `json:"max_hours"`
+	MaxBookings *int   `json:"max_bookings"`
+}
+
+// QuotaExceededDetails represents the error details when a booking would exceed a quota
+type QuotaExceededDetails struct {
+	Quota *Quota `json:"quota"`
+}
+
+// BookingWindowDetails represents the error details when a booking starts outside the booking window
+type BookingWindowDetails struct {
+	BookingWindow BookingWindow `json:"booking_window"`
+}
+
+// MaintenanceConflictDetails represents the error details when a maintenance block cannot be placed
+type MaintenanceConflictDetails struct {
+	Policy   MaintenancePolicy `json:"policy"`
+	Bookings []*Booking        `json:"bookings"`
+}
+
+// ConflictDetails represents the error details when a booking conflicts
+type ConflictDetails struct {
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// UpdateBookingRequest represents the request body for rescheduling a booking
+type UpdateBookingRequest struct {
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+}
+
+// Create handles POST /api/v1/bookings
+func (h *Handler) Create(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req CreateBookingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	// Validate required fields
+	if req.DeskID <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Desk ID is required")
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time and end time are required")
+	}
+
+	booking, err := h.service.CreateBooking(c.Context(), &CreateBookingInput{
+		DeskID:    req.DeskID,
+		UserID:    actor.UserID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Hold:      req.Hold,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, booking)
+}
+
+// CreateRecurring handles POST /api/v1/bookings/recurring
+func (h *Handler) CreateRecurring(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req CreateSeriesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	// Validate required fields
+	if req.DeskID <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Desk ID is required")
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time and end time are required")
+	}
+	if req.RRule == "" {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Recurrence rule is required")
+	}
+
+	exdates, err := parseExDates(req.ExDates, req.StartTime)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	}
+
+	result, err := h.service.CreateRecurringBooking(c.Context(), &CreateSeriesInput{
+		DeskID:    req.DeskID,
+		UserID:    actor.UserID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		RRule:     req.RRule,
+		ExDates:   exdates,
+		Partial:   req.Partial,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, result)
+}
+
+// List handles GET /api/v1/bookings
+// Members see their own bookings; admins see all bookings and may filter by user_id.
+func (h *Handler) List(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	filter, err := parseBookingFilter(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	}
+
+	page, err := h.service.ListBookings(c.Context(), actor, filter)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	bookings := page.Bookings
+	if bookings == nil {
+		bookings = []*Booking{}
+	}
+
+	var nextCursor, prevCursor string
+	if page.Next != nil {
+		nextCursor = page.Next.Encode()
+	}
+	if page.Prev != nil {
+		prevCursor = page.Prev.Encode()
+	}
+
+	return response.SuccessWithCursors(c, fiber.StatusOK, bookings, nextCursor, prevCursor)
+}
+
+// CreateGroup handles POST /api/v1/bookings/group
+func (h *Handler) CreateGroup(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req CreateGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time and end time are required")
+	}
+	for _, attendee := range req.Attendees {
+		if _, err := uuid.Parse(attendee.UserID); err != nil {
+			return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Every attendee needs a valid user_id")
+		}
+	}
+
+	result, err := h.service.CreateGroupBooking(c.Context(), &CreateGroupInput{
+		OrganizerID: actor.UserID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Attendees:   req.Attendees,
+		Wing:        req.Wing,
+		Features:    req.Features,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, result)
+}
+
+// Get handles GET /api/v1/bookings/:id
+func (h *Handler) Get(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	booking, err := h.service.GetBooking(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// Update handles PATCH /api/v1/bookings/:id
+func (h *Handler) Update(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	var req UpdateBookingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.StartTime == nil && req.EndTime == nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time or end time is required")
+	}
+
+	booking, err := h.service.UpdateBooking(c.Context(), actor, id, &UpdateBookingInput{
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// Move handles POST /api/v1/bookings/:id/move
+func (h *Handler) Move(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	var req MoveBookingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.DeskID == nil && req.StartTime == nil && req.EndTime == nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Desk ID, start time or end time is required")
+	}
+	if req.DeskID != nil && *req.DeskID <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid desk ID")
+	}
+
+	booking, err := h.service.MoveBooking(c.Context(), actor, id, &MoveBookingInput{
+		DeskID:    req.DeskID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Force:     req.Force,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// Cancel handles DELETE /api/v1/bookings/:id?reason=
+func (h *Handler) Cancel(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	booking, err := h.service.CancelBooking(c.Context(), actor, id, c.Query("reason"))
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// Confirm handles POST /api/v1/bookings/:id/confirm
+func (h *Handler) Confirm(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	booking, err := h.service.ConfirmHold(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// CheckIn handles POST /api/v1/bookings/:id/check-in
+func (h *Handler) CheckIn(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	booking, err := h.service.CheckIn(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// CheckOut handles POST /api/v1/bookings/:id/check-out
+func (h *Handler) CheckOut(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	booking, err := h.service.CheckOut(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, booking)
+}
+
+// UpdateSeries handles PATCH /api/v1/bookings/:id/series?scope=this|following|all
+func (h *Handler) UpdateSeries(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	scope := SeriesScope(c.Query("scope"))
+	if !scope.IsValid() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Scope must be one of this, following, all")
+	}
+
+	var req UpdateBookingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.StartTime == nil && req.EndTime == nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time or end time is required")
+	}
+
+	bookings, err := h.service.UpdateSeriesBookings(c.Context(), actor, id, scope, &UpdateBookingInput{
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, bookings)
+}
+
+// CancelSeries handles DELETE /api/v1/bookings/:id/series?scope=this|following|all&reason=
+func (h *Handler) CancelSeries(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	scope := SeriesScope(c.Query("scope"))
+	if !scope.IsValid() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Scope must be one of this, following, all")
+	}
+
+	bookings, err := h.service.CancelSeriesBookings(c.Context(), actor, id, scope, c.Query("reason"))
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, bookings)
+}
+
+// BulkCancel handles POST /api/v1/bookings/bulk-cancel
+func (h *Handler) BulkCancel(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req BulkCancelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time and end time are required")
+	}
+
+	result, err := h.service.BulkCancelBookings(c.Context(), actor, &BulkCancelInput{
+		DeskIDs:   req.DeskIDs,
+		Wing:      req.Wing,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Reason:    req.Reason,
+		DryRun:    req.DryRun,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, result)
+}
+
+// JoinWaitlist handles POST /api/v1/waitlist
+func (h *Handler) JoinWaitlist(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req JoinWaitlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Start time and end time are required")
+	}
+
+	entry, err := h.service.JoinWaitlist(c.Context(), &JoinWaitlistInput{
+		UserID:    actor.UserID,
+		DeskID:    req.DeskID,
+		Wing:      req.Wing,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, entry)
+}
+
+// ListWaitlist handles GET /api/v1/waitlist
+func (h *Handler) ListWaitlist(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	entries, err := h.service.ListWaitlist(c.Context(), actor)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	if entries == nil {
+		entries = []*WaitlistEntry{}
+	}
+
+	return response.Success(c, fiber.StatusOK, entries)
+}
+
+// LeaveWaitlist handles DELETE /api/v1/waitlist/:id
+func (h *Handler) LeaveWaitlist(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid waitlist entry ID")
+	}
+
+	entry, err := h.service.LeaveWaitlist(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, entry)
+}
+
+// OfferTransfer handles POST /api/v1/bookings/:id/transfers
+func (h *Handler) OfferTransfer(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid booking ID")
+	}
+
+	var req OfferTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if _, err := uuid.Parse(req.ToUserID); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "A valid to_user_id is required")
+	}
+	if req.SwapBookingID != nil && *req.SwapBookingID <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid swap booking ID")
+	}
+
+	transfer, err := h.service.OfferTransfer(c.Context(), actor, &OfferTransferInput{
+		BookingID:     id,
+		ToUserID:      req.ToUserID,
+		SwapBookingID: req.SwapBookingID,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, transfer)
+}
+
+// ListTransfers handles GET /api/v1/transfers
+func (h *Handler) ListTransfers(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	transfers, err := h.service.ListTransfers(c.Context(), actor)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	if transfers == nil {
+		transfers = []*BookingTransfer{}
+	}
+
+	return response.Success(c, fiber.StatusOK, transfers)
+}
+
+// AcceptTransfer handles POST /api/v1/transfers/:id/accept
+func (h *Handler) AcceptTransfer(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid transfer ID")
+	}
+
+	result, err := h.service.AcceptTransfer(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, result)
+}
+
+// DeclineTransfer handles POST /api/v1/transfers/:id/decline
+func (h *Handler) DeclineTransfer(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid transfer ID")
+	}
+
+	transfer, err := h.service.DeclineTransfer(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, transfer)
+}
+
+// WithdrawTransfer handles DELETE /api/v1/transfers/:id
+func (h *Handler) WithdrawTransfer(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid transfer ID")
+	}
+
+	transfer, err := h.service.WithdrawTransfer(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, transfer)
+}
+
+// CreateMaintenance handles POST /api/v1/maintenance
+func (h *Handler) CreateMaintenance(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req CreateMaintenanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	if req.DeskID <= 0 || req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Desk ID, start time, and end time are required")
+	}
+
+	result, err := h.service.CreateMaintenanceBlock(c.Context(), actor, &CreateMaintenanceInput{
+		DeskID:    req.DeskID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Reason:    req.Reason,
+		Policy:    req.Policy,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, result)
+}
+
+// ListMaintenance handles GET /api/v1/maintenance?desk_id=&start=&end=
+func (h *Handler) ListMaintenance(c *fiber.Ctx) error {
+	filter, err := parseMaintenanceFilter(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	}
+
+	blocks, err := h.service.ListMaintenanceBlocks(c.Context(), filter)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, blocks)
+}
+
+// DeleteMaintenance handles DELETE /api/v1/maintenance/:id
+func (h *Handler) DeleteMaintenance(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid maintenance block ID")
+	}
+
+	block, err := h.service.DeleteMaintenanceBlock(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, block)
+}
+
+// CreateClosure handles POST /api/v1/closures
+func (h *Handler) CreateClosure(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req CreateClosureRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	input := &CreateClosureInput{Wing: req.Wing, Reason: req.Reason}
+	switch {
+	case req.Date != "":
+		date, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "date must be a YYYY-MM-DD date")
+		}
+		input.Date = &date
+		if req.EndDate != "" {
+			endDate, err := time.Parse("2006-01-02", req.EndDate)
+			if err != nil {
+				return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "end_date must be a YYYY-MM-DD date")
+			}
+			input.EndDate = &endDate
+		}
+	case !req.StartTime.IsZero() && !req.EndTime.IsZero():
+		input.StartTime, input.EndTime = req.StartTime, req.EndTime
+	default:
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Either date or start time and end time are required")
+	}
+
+	closure, err := h.service.CreateClosure(c.Context(), actor, input)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, closure)
+}
+
+// ListClosures handles GET /api/v1/closures?wing=&start=&end=
+func (h *Handler) ListClosures(c *fiber.Ctx) error {
+	filter, err := parseClosureFilter(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	}
+
+	closures, err := h.service.ListClosures(c.Context(), filter)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, closures)
+}
+
+// DeleteClosure handles DELETE /api/v1/closures/:id
+func (h *Handler) DeleteClosure(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid closure ID")
+	}
+
+	closure, err := h.service.DeleteClosure(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, closure)
+}
+
+// SetOpeningHours handles PUT /api/v1/opening-hours
+func (h *Handler) SetOpeningHours(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req SetOpeningHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+	if req.Weekday == nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "weekday is required")
+	}
+
+	input := &SetOpeningHoursInput{Wing: req.Wing, Weekday: time.Weekday(*req.Weekday)}
+	if req.OpensAt != "" {
+		opens, err := parseTimeOfDay(req.OpensAt)
+		if err != nil {
+			return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "opens_at must be an HH:MM time")
+		}
+		input.Opens = &opens
+	}
+	if req.ClosesAt != "" {
+		closes, err := parseTimeOfDay(req.ClosesAt)
+		if err != nil {
+			return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "closes_at must be an HH:MM time")
+		}
+		input.Closes = &closes
+	}
+
+	hours, err := h.service.SetOpeningHours(c.Context(), actor, input)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, hours)
+}
+
+// ListOpeningHours handles GET /api/v1/opening-hours
+func (h *Handler) ListOpeningHours(c *fiber.Ctx) error {
+	schedule, err := h.service.ListOpeningHours(c.Context())
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, schedule)
+}
+
+// DeleteOpeningHours handles DELETE /api/v1/opening-hours/:id
+func (h *Handler) DeleteOpeningHours(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid opening hours ID")
+	}
+
+	hours, err := h.service.DeleteOpeningHours(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, hours)
+}
+
+// SetQuota handles PUT /api/v1/quotas
+func (h *Handler) SetQuota(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	var req SetQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeBadRequest, "Invalid request body")
+	}
+
+	quota, err := h.service.SetQuota(c.Context(), actor, &SetQuotaInput{
+		Role:        req.Role,
+		Period:      QuotaPeriod(req.Period),
+		MaxHours:    req.MaxHours,
+		MaxBookings: req.MaxBookings,
+	})
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, quota)
+}
+
+// ListQuotas handles GET /api/v1/quotas
+func (h *Handler) ListQuotas(c *fiber.Ctx) error {
+	quotas, err := h.service.ListQuotas(c.Context())
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, quotas)
+}
+
+// DeleteQuota handles DELETE /api/v1/quotas/:id
+func (h *Handler) DeleteQuota(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Invalid quota ID")
+	}
+
+	quota, err := h.service.DeleteQuota(c.Context(), actor, id)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, quota)
+}
+
+// MyQuota handles GET /api/v1/me/quota
+func (h *Handler) MyQuota(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	statuses, err := h.service.GetQuotaStatus(c.Context(), actor)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, statuses)
+}
+
+// Availability handles GET /api/v1/availability?start=&end=&wing=&features=
+// features is a comma-separated list of attributes every returned desk must have.
+func (h *Handler) Availability(c *fiber.Ctx) error {
+	filter, err := parseAvailabilityFilter(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	}
+
+	availability, err := h.service.SearchAvailability(c.Context(), filter)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, availability)
+}
+
+// Timeline handles GET /api/v1/timeline?date=YYYY-MM-DD&wing=
+func (h *Handler) Timeline(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return response.Error(c, fiber.StatusUnauthorized, response.ErrCodeUnauthorized, "Authentication required")
+	}
+
+	date, err := time.Parse("2006-01-02", c.Query("date"))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "date must be a YYYY-MM-DD date")
+	}
+
+	var wing *string
+	if value := c.Query("wing"); value != "" {
+		wing = &value
+	}
+
+	timelines, err := h.service.GetTimeline(c.Context(), actor, date, wing)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, timelines)
+}
+
+// Suggestions handles GET /api/v1/availability/suggestions?desk_id=&start=&end=
+func (h *Handler) Suggestions(c *fiber.Ctx) error {
+	deskID, err := strconv.Atoi(c.Query("desk_id"))
+	if err != nil || deskID <= 0 {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "desk_id must be a positive integer")
+	}
+
+	startTime, endTime, err := parseTimeWindow(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	}
+
+	suggestions, err := h.service.SuggestSlots(c.Context(), deskID, startTime, endTime)
+	if err != nil {
+		return h.handleServiceError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, suggestions)
+}
+
+// handleServiceError converts service errors to HTTP responses
+func (h *Handler) handleServiceError(c *fiber.Ctx, err error) error {
+	var seriesErr *SeriesError
+	if errors.As(err, &seriesErr) {
+		return response.ErrorWithDetails(c, fiber.StatusConflict, ErrCodeSeriesConflict,
+			"Some occurrences of the series could not be scheduled", SeriesConflictDetails{Skipped: seriesErr.Skipped})
+	}
+
+	var groupErr *GroupError
+	if errors.As(err, &groupErr) {
+		return response.ErrorWithDetails(c, fiber.StatusConflict, ErrCodeGroupConflict,
+			"Some attendees of the group could not be booked", GroupConflictDetails{Failures: groupErr.Failures})
+	}
+
+	var maintenanceErr *MaintenanceError
+	if errors.As(err, &maintenanceErr) {
+		return response.ErrorWithDetails(c, fiber.StatusConflict, ErrCodeMaintenanceConflict,
+			"Maintenance overlaps bookings that could not be cleared", MaintenanceConflictDetails{
+				Policy:   maintenanceErr.Policy,
+				Bookings: maintenanceErr.Bookings,
+			})
+	}
+
+	var quotaErr *QuotaError
+	if errors.As(err, &quotaErr) {
+		return response.ErrorWithDetails(c, fiber.StatusUnprocessableEntity, ErrCodeQuotaExceeded,
+			"Booking exceeds your quota for the "+string(quotaErr.Quota.Period), QuotaExceededDetails{Quota: quotaErr.Quota})
+	}
+
+	var windowErr *BookingWindowError
+	if errors.As(err, &windowErr) {
+		details := BookingWindowDetails{BookingWindow: windowErr.Window}
+		if errors.Is(err, ErrLeadTimeTooShort) {
+			return response.ErrorWithDetails(c, fiber.StatusUnprocessableEntity, ErrCodeLeadTimeTooShort,
+				"Bookings must be made at least "+strconv.Itoa(windowErr.Window.MinLeadMinutes)+" minutes before they start", details)
+		}
+		return response.ErrorWithDetails(c, fiber.StatusUnprocessableEntity, ErrCodeBeyondAdvanceWindow,
+			"Bookings can start at most "+strconv.Itoa(*windowErr.Window.MaxAdvanceDays)+" days ahead", details)
+	}
+
+	var conflictErr *ConflictError
+	if errors.As(err, &conflictErr) {
+		return response.ErrorWithDetails(c, fiber.StatusConflict, ErrCodeBookingConflict,
+			"Desk is already booked for the requested time", ConflictDetails{Suggestions: conflictErr.Suggestions})
+	}
+
+	switch {
+	case errors.Is(err, ErrBookingNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Booking not found")
+	case errors.Is(err, ErrNotBookingOwner):
+		return response.Error(c, fiber.StatusForbidden, response.ErrCodeForbidden, "You can only access your own bookings")
+	case errors.Is(err, ErrBookingConflict):
+		return response.Error(c, fiber.StatusConflict, ErrCodeBookingConflict, "Desk is already booked for the requested time")
+	case errors.Is(err, ErrInvalidTimeRange):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "End time must be after start time")
+	case errors.Is(err, ErrOutsideOpeningHours):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeOutsideOpeningHours, "Booking must be within opening hours")
+	case errors.Is(err, ErrDailyLimitExceeded):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeDailyLimitExceeded, "Booking exceeds the daily hour limit")
+	case errors.Is(err, ErrBookingNotActive):
+		return response.Error(c, fiber.StatusConflict, ErrCodeBookingNotActive, "Booking is no longer active")
+	case errors.Is(err, ErrBookingStarted):
+		return response.Error(c, fiber.StatusConflict, ErrCodeBookingStarted, "Occurrences that have already started cannot be changed")
+	case errors.Is(err, ErrNotSeriesBooking):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeNotSeriesBooking, "Booking is not part of a recurring series")
+	case errors.Is(err, ErrInvalidSeriesScope):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Scope must be one of this, following, all")
+	case errors.Is(err, ErrCheckInNotOpen):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeCheckInNotOpen, "Check-in opens shortly before the booking starts")
+	case errors.Is(err, ErrCheckInClosed):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeCheckInClosed, "Check-in grace period has ended")
+	case errors.Is(err, ErrAlreadyCheckedIn):
+		return response.Error(c, fiber.StatusConflict, ErrCodeAlreadyCheckedIn, "Booking is already checked in")
+	case errors.Is(err, ErrInvalidStatusTransition):
+		return response.Error(c, fiber.StatusConflict, ErrCodeInvalidTransition, err.Error())
+	case errors.Is(err, ErrHoldExpired):
+		return response.Error(c, fiber.StatusConflict, ErrCodeHoldExpired, "Hold has expired; book the desk again")
+	case errors.Is(err, ErrNotCheckedIn):
+		return response.Error(c, fiber.StatusConflict, ErrCodeNotCheckedIn, "Booking must be checked in before checking out")
+	case errors.Is(err, ErrWaitlistEntryNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Waitlist entry not found")
+	case errors.Is(err, ErrWaitlistEntryClosed):
+		return response.Error(c, fiber.StatusConflict, ErrCodeWaitlistClosed, "Waitlist entry is no longer waiting")
+	case errors.Is(err, ErrInvalidWaitlistTarget):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Either desk_id or wing (East or West) is required")
+	case errors.Is(err, ErrInvalidGroup):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "List 1 to 50 distinct attendees, each with a distinct desk_id unless a wing is given")
+	case errors.Is(err, ErrTransferNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Transfer not found")
+	case errors.Is(err, ErrTransferClosed):
+		return response.Error(c, fiber.StatusConflict, ErrCodeTransferClosed, "Transfer is no longer pending")
+	case errors.Is(err, ErrTransferPending):
+		return response.Error(c, fiber.StatusConflict, ErrCodeTransferPending, "Booking already has a pending transfer")
+	case errors.Is(err, ErrTransferRecipientNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Recipient not found")
+	case errors.Is(err, ErrInvalidTransferRecipient):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "A booking cannot be transferred to its owner")
+	case errors.Is(err, ErrInvalidSwapBooking):
+		return response.Error(c, fiber.StatusUnprocessableEntity, response.ErrCodeValidation, "Swap booking must be an upcoming confirmed booking of the recipient")
+	case errors.Is(err, ErrNotTransferParty):
+		return response.Error(c, fiber.StatusForbidden, response.ErrCodeForbidden, "You can only answer transfers addressed to you")
+	case errors.Is(err, ErrAdminOnly):
+		return response.Error(c, fiber.StatusForbidden, response.ErrCodeForbidden, "Only admins can do this")
+	case errors.Is(err, ErrDeskNotAvailable):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeDeskNotAvailable, "Desk is not available for booking")
+	case errors.Is(err, ErrDeskNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Desk not found")
+	case errors.Is(err, ErrInvalidBulkCancel):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Choose desk_ids or a wing, and give a reason")
+	case errors.Is(err, ErrMaintenanceNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Maintenance block not found")
+	case errors.Is(err, ErrInvalidMaintenance):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Give a reason and a policy of reject, cancel or move")
+	case errors.Is(err, ErrClosed):
+		return response.Error(c, fiber.StatusUnprocessableEntity, ErrCodeClosed, "The office is closed for part of the requested time")
+	case errors.Is(err, ErrClosureNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Closure not found")
+	case errors.Is(err, ErrInvalidClosure):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Give a reason for the closure")
+	case errors.Is(err, ErrOpeningHoursNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Opening hours not found")
+	case errors.Is(err, ErrInvalidOpeningHours):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Give a weekday from 0 (Sunday) to 6 and opens_at before closes_at, or neither to close the day")
+	case errors.Is(err, ErrQuotaNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "Quota not found")
+	case errors.Is(err, ErrInvalidQuota):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Give a role of member or admin, a period of week or month and a positive max_hours or max_bookings")
+	case errors.Is(err, ErrUserNotFound):
+		return response.Error(c, fiber.StatusNotFound, response.ErrCodeNotFound, "User not found")
+	case errors.Is(err, ErrInvalidWing):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Wing must be East or West")
+	case errors.Is(err, ErrInvalidRecurrence):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, err.Error())
+	case errors.Is(err, ErrTooManyOccurrences):
+		return response.Error(c, fiber.StatusBadRequest, response.ErrCodeValidation, "Recurrence rule produces too many occurrences")
+	default:
+		return response.Error(c, fiber.StatusInternalServerError, response.ErrCodeInternalServer, "An unexpected error occurred")
+	}
+}
+
+// actorFromContext builds the Actor from the values set by the auth middleware
+func actorFromContext(c *fiber.Ctx) (Actor, bool) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return Actor{}, false
+	}
+	return Actor{UserID: userID, Role: middleware.GetRole(c)}, true
+}
+
+// parseBookingFilter builds a BookingFilter from the query string
+func parseBookingFilter(c *fiber.Ctx) (*BookingFilter, error) {
+	filter := &BookingFilter{}
+
+	if userID := c.Query("user_id"); userID != "" {
+		filter.UserID = &userID
+	}
+
+	if deskID := c.Query("desk_id"); deskID != "" {
+		id, err := strconv.Atoi(deskID)
+		if err != nil {
+			return nil, errors.New("desk_id must be an integer")
+		}
+		filter.DeskID = &id
+	}
+
+	if status := c.Query("status"); status != "" {
+		bookingStatus := BookingStatus(status)
+		if !bookingStatus.IsValid() {
+			return nil, errors.New("status is not a valid booking status")
+		}
+		filter.Status = &bookingStatus
+	}
+
+	if start := c.Query("start"); start != "" {
+		startTime, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, errors.New("start must be an RFC3339 timestamp")
+		}
+		filter.StartDate = &startTime
+	}
+
+	if end := c.Query("end"); end != "" {
+		endTime, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return nil, errors.New("end must be an RFC3339 timestamp")
+		}
+		filter.EndDate = &endTime
+	}
+
+	switch sort := SortDirection(c.Query("sort", string(SortDesc))); sort {
+	case SortAsc, SortDesc:
+		filter.Sort = sort
+	default:
+		return nil, errors.New("sort must be asc or desc")
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		bookingCursor, err := DecodeBookingCursor(cursor)
+		if err != nil {
+			return nil, errors.New("cursor is invalid")
+		}
+		filter.Cursor = bookingCursor
+	}
+
+	filter.Limit = c.QueryInt("limit", defaultBookingPageSize)
+	if filter.Limit < 1 || filter.Limit > maxBookingPageSize {
+		return nil, errors.New("limit must be between 1 and " + strconv.Itoa(maxBookingPageSize))
+	}
+	if c.Query("offset") != "" {
+		return nil, errors.New("offset is not supported; page with cursor instead")
+	}
+
+	return filter, nil
+}
+
+// parseTimeWindow parses the required start and end query parameters
+func parseTimeWindow(c *fiber.Ctx) (time.Time, time.Time, error) {
+	start, end := c.Query("start"), c.Query("end")
+	if start == "" || end == "" {
+		return time.Time{}, time.Time{}, errors.New("start and end are required")
+	}
+
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("start must be an RFC3339 timestamp")
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("end must be an RFC3339 timestamp")
+	}
+
+	return startTime, endTime, nil
+}
+
+// parseMaintenanceFilter builds a MaintenanceFilter from the query string
+func parseMaintenanceFilter(c *fiber.Ctx) (*MaintenanceFilter, error) {
+	filter := &MaintenanceFilter{}
+
+	if deskID := c.Query("desk_id"); deskID != "" {
+		id, err := strconv.Atoi(deskID)
+		if err != nil {
+			return nil, errors.New("desk_id must be an integer")
+		}
+		filter.DeskID = &id
+	}
+
+	if start := c.Query("start"); start != "" {
+		startTime, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, errors.New("start must be an RFC3339 timestamp")
+		}
+		filter.StartTime = &startTime
+	}
+
+	if end := c.Query("end"); end != "" {
+		endTime, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return nil, errors.New("end must be an RFC3339 timestamp")
+		}
+		filter.EndTime = &endTime
+	}
+
+	return filter, nil
+}
+
+// parseClosureFilter builds a ClosureFilter from the query string
+func parseClosureFilter(c *fiber.Ctx) (*ClosureFilter, error) {
+	filter := &ClosureFilter{}
+
+	if wing := c.Query("wing"); wing != "" {
+		filter.Wing = &wing
+	}
+
+	if start := c.Query("start"); start != "" {
+		startTime, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, errors.New("start must be an RFC3339 timestamp")
+		}
+		filter.StartTime = &startTime
+	}
+
+	if end := c.Query("end"); end != "" {
+		endTime, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return nil, errors.New("end must be an RFC3339 timestamp")
+		}
+		filter.EndTime = &endTime
+	}
+
+	return filter, nil
+}
+
+// parseTimeOfDay parses an HH:MM time of day; 24:00 stands for the end of the day
+func parseTimeOfDay(value string) (TimeOfDay, error) {
+	if value == "24:00" {
+		return TimeOfDay(24 * time.Hour), nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return TimeOfDay(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), nil
+}
+
+// parseAvailabilityFilter builds an AvailabilityFilter from the query string
+func parseAvailabilityFilter(c *fiber.Ctx) (*AvailabilityFilter, error) {
+	startTime, endTime, err := parseTimeWindow(c)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &AvailabilityFilter{StartTime: startTime, EndTime: endTime}
+
+	if wing := c.Query("wing"); wing != "" {
+		filter.Wing = &wing
+	}
+
+	if features := c.Query("features"); features != "" {
+		for _, feature := range strings.Split(features, ",") {
+			if feature = strings.TrimSpace(feature); feature != "" {
+				filter.Features = append(filter.Features, feature)
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+// parseExDates parses exception dates given as RFC3339 occurrence starts or YYYY-MM-DD dates.
+// Dates are combined with the first occurrence's time of day.
+func parseExDates(values []string, start time.Time) ([]time.Time, error) {
+	exdates := make([]time.Time, 0, len(values))
+	for _, value := range values {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			exdates = append(exdates, t)
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, errors.New("exdates must be RFC3339 timestamps or YYYY-MM-DD dates")
+		}
+		exdates = append(exdates, time.Date(date.Year(), date.Month(), date.Day(),
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()))
+	}
+	return exdates, nil
+}
@@ -0,0 +1,1872 @@
+package bookings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/justinyeo/hotdesk-booking/backend/internal/middleware"
+	"github.com/justinyeo/hotdesk-booking/backend/internal/shared/response"
+)
+
+// setupTestApp creates a Fiber app for testing with the given authenticated user
+func setupTestApp(handler *Handler, userID, role string) *fiber.App {
+	app := fiber.New()
+	api := app.Group("/api/v1/bookings", func(c *fiber.Ctx) error {
+		// Simulate auth middleware setting user_id and role
+		c.Locals(middleware.UserIDKey, userID)
+		c.Locals(middleware.RoleKey, role)
+		return c.Next()
+	})
+	api.Post("/", handler.Create)
+	api.Post("/recurring", handler.CreateRecurring)
+	api.Post("/group", handler.CreateGroup)
+	api.Post("/bulk-cancel", handler.BulkCancel)
+	api.Get("/", handler.List)
+	api.Get("/:id", handler.Get)
+	api.Patch("/:id", handler.Update)
+	api.Delete("/:id", handler.Cancel)
+	api.Post("/:id/confirm", handler.Confirm)
+	api.Post("/:id/check-in", handler.CheckIn)
+	api.Post("/:id/check-out", handler.CheckOut)
+	api.Post("/:id/move", handler.Move)
+	api.Post("/:id/transfers", handler.OfferTransfer)
+	api.Patch("/:id/series", handler.UpdateSeries)
+	api.Delete("/:id/series", handler.CancelSeries)
+
+	waitlist := app.Group("/api/v1/waitlist", func(c *fiber.Ctx) error {
+		c.Locals(middleware.UserIDKey, userID)
+		c.Locals(middleware.RoleKey, role)
+		return c.Next()
+	})
+	waitlist.Post("/", handler.JoinWaitlist)
+	waitlist.Get("/", handler.ListWaitlist)
+	waitlist.Delete("/:id", handler.LeaveWaitlist)
+
+	v1 := app.Group("/api/v1", func(c *fiber.Ctx) error {
+		c.Locals(middleware.UserIDKey, userID)
+		c.Locals(middleware.RoleKey, role)
+		return c.Next()
+	})
+	v1.Get("/availability", handler.Availability)
+	v1.Get("/availability/suggestions", handler.Suggestions)
+	v1.Get("/timeline", handler.Timeline)
+	v1.Get("/transfers", handler.ListTransfers)
+	v1.Post("/transfers/:id/accept", handler.AcceptTransfer)
+	v1.Post("/transfers/:id/decline", handler.DeclineTransfer)
+	v1.Delete("/transfers/:id", handler.WithdrawTransfer)
+	v1.Post("/maintenance", handler.CreateMaintenance)
+	v1.Get("/maintenance", handler.ListMaintenance)
+	v1.Delete("/maintenance/:id", handler.DeleteMaintenance)
+	v1.Post("/closures", handler.CreateClosure)
+	v1.Get("/closures", handler.ListClosures)
+	v1.Delete("/closures/:id", handler.DeleteClosure)
+	v1.Put("/opening-hours", handler.SetOpeningHours)
+	v1.Get("/opening-hours", handler.ListOpeningHours)
+	v1.Delete("/opening-hours/:id", handler.DeleteOpeningHours)
+	v1.Put("/quotas", handler.SetQuota)
+	v1.Get("/quotas", handler.ListQuotas)
+	v1.Delete("/quotas/:id", handler.DeleteQuota)
+	v1.Get("/me/quota", handler.MyQuota)
+	return app
+}
+
+// parseResponse parses the API response
+func parseResponse(t *testing.T, body io.Reader) response.APIResponse {
+	var resp response.APIResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return resp
+}
+
+// ============================================================================
+// Create Handler Tests
+// ============================================================================
+
+func TestHandler_Create_Success(t *testing.T) {
+	var gotUserID string
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(_ context.Context, input *CreateBookingInput) (*Booking, error) {
+			gotUserID = input.UserID
+			return &Booking{ID: 1, DeskID: input.DeskID, UserID: input.UserID, Status: StatusConfirmed}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected status 201, got %d", resp.StatusCode)
+	}
+	if gotUserID != "user-123" {
+		t.Errorf("expected booking for user-123, got %s", gotUserID)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if !apiResp.Success {
+		t.Error("expected success to be true")
+	}
+}
+
+func TestHandler_Create_MissingDesk(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != response.ErrCodeValidation {
+		t.Error("expected validation error")
+	}
+}
+
+func TestHandler_Create_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(_ context.Context, _ *CreateBookingInput) (*Booking, error) {
+			return nil, ErrBookingConflict
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeBookingConflict {
+		t.Errorf("expected %s error, got %v", ErrCodeBookingConflict, apiResp.Error)
+	}
+}
+
+func TestHandler_Create_PolicyErrors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		expectedCode string
+	}{
+		{
+			"outside opening hours",
+			`{"desk_id":1,"start_time":"2026-03-10T06:00:00Z","end_time":"2026-03-10T23:00:00Z"}`,
+			ErrCodeOutsideOpeningHours,
+		},
+		{
+			"daily limit exceeded",
+			`{"desk_id":1,"start_time":"2026-03-10T08:00:00Z","end_time":"2026-03-10T20:00:00Z"}`,
+			ErrCodeDailyLimitExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+			req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != fiber.StatusUnprocessableEntity {
+				t.Errorf("expected status 422, got %d", resp.StatusCode)
+			}
+
+			apiResp := parseResponse(t, resp.Body)
+			if apiResp.Error == nil || apiResp.Error.Code != tc.expectedCode {
+				t.Errorf("expected %s error, got %v", tc.expectedCode, apiResp.Error)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Get Handler Tests
+// ============================================================================
+
+func TestHandler_Get_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings/42", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Get_NotFound(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings/999", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected status 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Get_OtherMember(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-456", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings/42", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Get_InvalidID(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings/abc", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+// ============================================================================
+// List Handler Tests
+// ============================================================================
+
+func TestHandler_List_Empty(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	data, ok := apiResp.Data.([]interface{})
+	if !ok || len(data) != 0 {
+		t.Errorf("expected empty list, got %v", apiResp.Data)
+	}
+}
+
+func TestHandler_List_DefaultPageSize(t *testing.T) {
+	var gotFilter *BookingFilter
+	mockRepo := &MockRepository{
+		GetUserBookingsFunc: func(_ context.Context, filter *BookingFilter) (*BookingPage, error) {
+			gotFilter = filter
+			return &BookingPage{Bookings: []*Booking{}}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotFilter.Limit != defaultBookingPageSize {
+		t.Errorf("expected the default page size %d, got %d", defaultBookingPageSize, gotFilter.Limit)
+	}
+}
+
+func TestHandler_List_InvalidStatus(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings?status=unknown", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_List_Cursors(t *testing.T) {
+	booking := testBooking()
+	var gotFilter *BookingFilter
+	mockRepo := &MockRepository{
+		GetUserBookingsFunc: func(_ context.Context, filter *BookingFilter) (*BookingPage, error) {
+			gotFilter = filter
+			return &BookingPage{Bookings: []*Booking{booking}, Next: cursorAfter(booking)}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	cursor := cursorBefore(booking).Encode()
+	req := httptest.NewRequest("GET", "/api/v1/bookings?sort=asc&limit=1&cursor="+cursor, nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotFilter.Sort != SortAsc || gotFilter.Cursor == nil || gotFilter.Cursor.ID != booking.ID || !gotFilter.Cursor.Before {
+		t.Errorf("expected ascending filter with the decoded cursor, got %+v", gotFilter)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Meta.NextCursor != cursorAfter(booking).Encode() {
+		t.Errorf("expected next cursor in meta, got %q", apiResp.Meta.NextCursor)
+	}
+	if apiResp.Meta.PrevCursor != "" {
+		t.Errorf("expected no prev cursor, got %q", apiResp.Meta.PrevCursor)
+	}
+}
+
+func TestHandler_List_InvalidPagination(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+	cursor := cursorAfter(testBooking()).Encode()
+
+	tests := []string{
+		"sort=sideways",
+		"cursor=garbage",
+		"cursor=" + cursor + "&offset=10",
+		"offset=10",
+		"limit=0",
+		"limit=201",
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/bookings?"+query, nil)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Update Handler Tests
+// ============================================================================
+
+func TestHandler_Update_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+		UpdateBookingFunc: func(_ context.Context, _ int, _ *UpdateBookingInput) (*Booking, error) {
+			return nil, ErrBookingConflict
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"end_time":"2026-03-10T12:00:00Z"}`
+	req := httptest.NewRequest("PATCH", "/api/v1/bookings/42", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Update_EmptyBody(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("PATCH", "/api/v1/bookings/42", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+// ============================================================================
+// Cancel Handler Tests
+// ============================================================================
+
+func TestHandler_Cancel_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	req := httptest.NewRequest("DELETE", "/api/v1/bookings/42", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_BulkCancel_MemberForbidden(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"wing":"East","start_time":"2026-03-10T00:00:00Z","end_time":"2026-03-11T00:00:00Z","reason":"power outage"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/bulk-cancel", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_BulkCancel_DryRun(t *testing.T) {
+	var gotInput *BulkCancelInput
+	mockRepo := &MockRepository{
+		GetBulkCancelFunc: func(_ context.Context, input *BulkCancelInput) ([]*Booking, error) {
+			gotInput = input
+			return []*Booking{testBooking()}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	reqBody := `{"desk_ids":[1,2],"start_time":"2026-03-10T00:00:00Z","end_time":"2026-03-11T00:00:00Z","reason":"power outage","dry_run":true}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/bulk-cancel", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotInput == nil || len(gotInput.DeskIDs) != 2 || gotInput.Reason != "power outage" {
+		t.Errorf("expected the desks and reason to reach the preview, got %+v", gotInput)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	data, ok := apiResp.Data.(map[string]interface{})
+	if !ok || data["dry_run"] != true || data["count"] != float64(1) {
+		t.Errorf("expected a dry run result with 1 booking, got %v", apiResp.Data)
+	}
+}
+
+func TestHandler_CreateMaintenance_Success(t *testing.T) {
+	var gotInput *CreateMaintenanceInput
+	mockRepo := &MockRepository{
+		CreateMaintenanceFunc: func(_ context.Context, input *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+			gotInput = input
+			return &MaintenanceInsertResult{
+				Block:     &MaintenanceBlock{ID: 5, DeskID: input.DeskID, Reason: input.Reason},
+				Cancelled: []*Booking{testBooking()},
+			}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	reqBody := `{"desk_id":7,"start_time":"2026-03-09T13:00:00Z","end_time":"2026-03-09T18:00:00Z","reason":"monitor replacement","policy":"cancel"}`
+	req := httptest.NewRequest("POST", "/api/v1/maintenance", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+	if gotInput == nil || gotInput.DeskID != 7 || gotInput.Policy != MaintenanceCancel || gotInput.CreatedBy != "admin-1" {
+		t.Errorf("expected the desk, policy and admin to reach the repository, got %+v", gotInput)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	data, ok := apiResp.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected an object, got %v", apiResp.Data)
+	}
+	if cancelled, ok := data["cancelled"].([]interface{}); !ok || len(cancelled) != 1 {
+		t.Errorf("expected 1 cancelled booking, got %v", data["cancelled"])
+	}
+}
+
+func TestHandler_CreateMaintenance_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateMaintenanceFunc: func(_ context.Context, _ *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+			return &MaintenanceInsertResult{Conflicts: []*Booking{testBooking()}}, ErrBookingConflict
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-09T13:00:00Z","end_time":"2026-03-09T18:00:00Z","reason":"monitor replacement"}`
+	req := httptest.NewRequest("POST", "/api/v1/maintenance", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Fatalf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeMaintenanceConflict {
+		t.Fatalf("expected MAINTENANCE_CONFLICT, got %+v", apiResp.Error)
+	}
+	details, ok := apiResp.Error.Details.(map[string]interface{})
+	if !ok || details["policy"] != string(MaintenanceReject) {
+		t.Fatalf("expected details naming the reject policy, got %v", apiResp.Error.Details)
+	}
+	if bookings, ok := details["bookings"].([]interface{}); !ok || len(bookings) != 1 {
+		t.Errorf("expected 1 conflicting booking, got %v", details["bookings"])
+	}
+}
+
+func TestHandler_CreateMaintenance_MemberForbidden(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-09T13:00:00Z","end_time":"2026-03-09T18:00:00Z","reason":"monitor replacement"}`
+	req := httptest.NewRequest("POST", "/api/v1/maintenance", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_DeleteMaintenance_NotFound(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "admin-1", "admin")
+
+	req := httptest.NewRequest("DELETE", "/api/v1/maintenance/99", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected status 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_CreateClosure_WholeDay(t *testing.T) {
+	var gotInput *CreateClosureInput
+	mockRepo := &MockRepository{
+		CreateClosureFunc: func(_ context.Context, input *CreateClosureInput) (*Closure, error) {
+			gotInput = input
+			return &Closure{ID: 2, Wing: input.Wing, StartTime: input.StartTime, EndTime: input.EndTime, Reason: input.Reason}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	reqBody := `{"wing":"West","date":"2026-12-25","reason":"Christmas Day"}`
+	req := httptest.NewRequest("POST", "/api/v1/closures", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+	if gotInput == nil || *gotInput.Wing != WingWest || !gotInput.StartTime.Equal(christmas) || !gotInput.EndTime.Equal(christmas.AddDate(0, 0, 1)) {
+		t.Errorf("expected the West wing to close for Christmas Day, got %+v", gotInput)
+	}
+}
+
+func TestHandler_CreateClosure_MemberForbidden(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"date":"2026-12-25","reason":"Christmas Day"}`
+	req := httptest.NewRequest("POST", "/api/v1/closures", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_CreateBooking_Closed(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetClosuresFunc: func(_ context.Context, _ *ClosureFilter) ([]*Closure, error) {
+			return []*Closure{{ID: 2, StartTime: testDay(), EndTime: testDay().Add(24 * time.Hour), Reason: "public holiday"}}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", resp.StatusCode)
+	}
+	if apiResp := parseResponse(t, resp.Body); apiResp.Error == nil || apiResp.Error.Code != ErrCodeClosed {
+		t.Errorf("expected error code %s, got %+v", ErrCodeClosed, apiResp.Error)
+	}
+}
+
+func TestHandler_CreateBooking_QuotaExceeded(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, _ string, _, _ time.Time) (*QuotaUsage, error) {
+			return &QuotaUsage{Hours: 20, Bookings: 4}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", resp.StatusCode)
+	}
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeQuotaExceeded {
+		t.Fatalf("expected error code %s, got %+v", ErrCodeQuotaExceeded, apiResp.Error)
+	}
+	details, ok := apiResp.Error.Details.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected details, got %v", apiResp.Error.Details)
+	}
+	if quota, ok := details["quota"].(map[string]interface{}); !ok || quota["period"] != "week" || quota["max_hours"] != float64(20) {
+		t.Errorf("expected the weekly quota in the details, got %v", details["quota"])
+	}
+}
+
+func TestHandler_MyQuota(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, userID string, _, _ time.Time) (*QuotaUsage, error) {
+			if userID != "user-123" {
+				t.Errorf("expected usage of the caller, got %s", userID)
+			}
+			return &QuotaUsage{Hours: 8, Bookings: 2}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/me/quota", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	apiResp := parseResponse(t, resp.Body)
+	statuses, ok := apiResp.Data.([]interface{})
+	if !ok || len(statuses) != 1 {
+		t.Fatalf("expected one quota status, got %v", apiResp.Data)
+	}
+	status := statuses[0].(map[string]interface{})
+	if status["remaining_hours"] != float64(12) || status["remaining_bookings"] != float64(3) {
+		t.Errorf("expected 12 hours and 3 bookings left, got %v", status)
+	}
+}
+
+func TestHandler_SetQuota_MemberForbidden(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"role":"member","period":"week","max_hours":20}`
+	req := httptest.NewRequest("PUT", "/api/v1/quotas", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_CreateBooking_LeadTimeTooShort(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return windowTestSettings(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	// testDay has already passed, so it is always within the lead time
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", resp.StatusCode)
+	}
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeLeadTimeTooShort {
+		t.Fatalf("expected error code %s, got %+v", ErrCodeLeadTimeTooShort, apiResp.Error)
+	}
+	details, ok := apiResp.Error.Details.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected details, got %v", apiResp.Error.Details)
+	}
+	if window, ok := details["booking_window"].(map[string]interface{}); !ok || window["min_lead_minutes"] != float64(10) || window["max_advance_days"] != float64(14) {
+		t.Errorf("expected the member booking window in the details, got %v", details["booking_window"])
+	}
+}
+
+func TestHandler_SetOpeningHours(t *testing.T) {
+	var gotInput *SetOpeningHoursInput
+	mockRepo := &MockRepository{
+		SetOpeningHoursFunc: func(_ context.Context, input *SetOpeningHoursInput) (*OpeningHours, error) {
+			gotInput = input
+			return &OpeningHours{ID: 3, Wing: input.Wing, Weekday: input.Weekday, Opens: input.Opens, Closes: input.Closes}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	reqBody := `{"wing":"East","weekday":6,"opens_at":"10:30","closes_at":"24:00"}`
+	req := httptest.NewRequest("PUT", "/api/v1/opening-hours", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotInput == nil || gotInput.Weekday != time.Saturday || time.Duration(*gotInput.Opens) != 10*time.Hour+30*time.Minute {
+		t.Errorf("expected Saturday from 10:30, got %+v", gotInput)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	data, ok := apiResp.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected an object, got %v", apiResp.Data)
+	}
+	if data["opens_at"] != "10:30" || data["closes_at"] != "24:00" {
+		t.Errorf("expected the hours as HH:MM, got %v-%v", data["opens_at"], data["closes_at"])
+	}
+}
+
+func TestHandler_SetOpeningHours_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		body     string
+		expected int
+	}{
+		{"member", "member", `{"weekday":6,"opens_at":"10:00","closes_at":"16:00"}`, fiber.StatusForbidden},
+		{"missing weekday", "admin", `{"opens_at":"10:00","closes_at":"16:00"}`, fiber.StatusBadRequest},
+		{"malformed time", "admin", `{"weekday":6,"opens_at":"10am","closes_at":"16:00"}`, fiber.StatusBadRequest},
+		{"inverted hours", "admin", `{"weekday":6,"opens_at":"16:00","closes_at":"10:00"}`, fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-1", tt.role)
+
+			req := httptest.NewRequest("PUT", "/api/v1/opening-hours", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestHandler_Cancel_InvalidTransition(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			booking.Status = StatusNoShow
+			return booking, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("DELETE", "/api/v1/bookings/42?reason=oops", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeInvalidTransition {
+		t.Errorf("expected %s error, got %v", ErrCodeInvalidTransition, apiResp.Error)
+	}
+}
+
+func TestHandler_Cancel_NotFound(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("DELETE", "/api/v1/bookings/999", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected status 404, got %d", resp.StatusCode)
+	}
+}
+
+// ============================================================================
+// CreateRecurring Tests
+// ============================================================================
+
+func TestHandler_CreateRecurring_Success(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z","rrule":"FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4","exdates":["2026-03-12"]}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/recurring", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	data, ok := apiResp.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected series result data, got %v", apiResp.Data)
+	}
+	bookings, ok := data["bookings"].([]interface{})
+	if !ok || len(bookings) != 3 {
+		t.Errorf("expected 3 bookings after exdate, got %v", data["bookings"])
+	}
+}
+
+func TestHandler_CreateRecurring_InvalidRule(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z","rrule":"FREQ=WEEKLY"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/recurring", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_CreateRecurring_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateSeriesFunc: func(_ context.Context, _ *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+			return &SeriesInsertResult{Conflicts: occurrences[1:2]}, ErrBookingConflict
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z","rrule":"FREQ=DAILY;COUNT=3"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/recurring", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeSeriesConflict {
+		t.Fatalf("expected %s error, got %v", ErrCodeSeriesConflict, apiResp.Error)
+	}
+	details, ok := apiResp.Error.Details.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected error details, got %v", apiResp.Error.Details)
+	}
+	if skipped, ok := details["skipped"].([]interface{}); !ok || len(skipped) != 1 {
+		t.Errorf("expected 1 skipped occurrence, got %v", details["skipped"])
+	}
+}
+
+// ============================================================================
+// Series Edit Handler Tests
+// ============================================================================
+
+func TestHandler_UpdateSeries_InvalidScope(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("PATCH", "/api/v1/bookings/42/series?scope=everything", bytes.NewBufferString(`{"end_time":"2026-03-10T12:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_CancelSeries_NotSeries(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("DELETE", "/api/v1/bookings/42/series?scope=following", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeNotSeriesBooking {
+		t.Errorf("expected %s error, got %v", ErrCodeNotSeriesBooking, apiResp.Error)
+	}
+}
+
+// ============================================================================
+// Waitlist Handler Tests
+// ============================================================================
+
+func TestHandler_JoinWaitlist_Success(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"wing":"East","start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/waitlist", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected status 201, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_JoinWaitlist_MissingTarget(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/waitlist", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_LeaveWaitlist_Closed(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetWaitlistEntryFunc: func(_ context.Context, id int) (*WaitlistEntry, error) {
+			return &WaitlistEntry{ID: id, UserID: "user-123", Status: WaitlistFulfilled}, nil
+		},
+		CancelWaitlistFunc: func(_ context.Context, _ int) (*WaitlistEntry, error) {
+			return nil, ErrWaitlistEntryClosed
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("DELETE", "/api/v1/waitlist/5", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeWaitlistClosed {
+		t.Errorf("expected %s error, got %v", ErrCodeWaitlistClosed, apiResp.Error)
+	}
+}
+
+// ============================================================================
+// Confirm Handler Tests
+// ============================================================================
+
+func TestHandler_Confirm_Expired(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return heldBooking(time.Now().Add(-time.Minute)), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/confirm", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeHoldExpired {
+		t.Errorf("expected %s error, got %v", ErrCodeHoldExpired, apiResp.Error)
+	}
+}
+
+// ============================================================================
+// CheckIn Handler Tests
+// ============================================================================
+
+func TestHandler_CheckIn_Closed(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	// testBooking is in the past, so its grace period has ended
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/check-in", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeCheckInClosed {
+		t.Errorf("expected %s error, got %v", ErrCodeCheckInClosed, apiResp.Error)
+	}
+}
+
+func TestHandler_CheckOut_NotCheckedIn(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/check-out", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeNotCheckedIn {
+		t.Errorf("expected %s error, got %v", ErrCodeNotCheckedIn, apiResp.Error)
+	}
+}
+
+// ============================================================================
+// CreateGroup Handler Tests
+// ============================================================================
+
+const (
+	testAttendeeA = "6a1c1f0e-2b7d-4d0c-9f6a-1e2b3c4d5e6f"
+	testAttendeeB = "7b2d2a1f-3c8e-4e1d-8a7b-2f3c4d5e6f70"
+)
+
+func TestHandler_CreateGroup_Success(t *testing.T) {
+	var gotInput *CreateGroupInput
+	mockRepo := &MockRepository{
+		CreateGroupFunc: func(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+			gotInput = input
+			return (&MockRepository{}).CreateGroup(ctx, input, assignments)
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T17:00:00Z","attendees":[` +
+		`{"user_id":"` + testAttendeeA + `","desk_id":1},{"user_id":"` + testAttendeeB + `","desk_id":2}]}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/group", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected status 201, got %d", resp.StatusCode)
+	}
+	if gotInput == nil || gotInput.OrganizerID != "user-123" || len(gotInput.Attendees) != 2 {
+		t.Errorf("expected a group of 2 organized by user-123, got %+v", gotInput)
+	}
+}
+
+func TestHandler_CreateGroup_InvalidAttendee(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	reqBody := `{"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T17:00:00Z","attendees":[{"user_id":"alice","desk_id":1}]}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/group", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_CreateGroup_Failures(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateGroupFunc: func(_ context.Context, _ *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+			return &GroupInsertResult{Conflicts: assignments[1:]}, ErrBookingConflict
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"start_time":"2026-03-10T09:00:00Z","end_time":"2026-03-10T17:00:00Z","attendees":[` +
+		`{"user_id":"` + testAttendeeA + `","desk_id":1},{"user_id":"` + testAttendeeB + `","desk_id":2}]}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/group", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeGroupConflict {
+		t.Fatalf("expected %s error, got %v", ErrCodeGroupConflict, apiResp.Error)
+	}
+	details, ok := apiResp.Error.Details.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected error details, got %v", apiResp.Error.Details)
+	}
+	failures, ok := details["failures"].([]interface{})
+	if !ok || len(failures) != 1 {
+		t.Fatalf("expected 1 failure, got %v", details["failures"])
+	}
+	failure := failures[0].(map[string]interface{})
+	if failure["user_id"] != testAttendeeB || failure["desk_id"] != float64(2) || failure["reason"] != ErrCodeBookingConflict {
+		t.Errorf("expected desk 2 of %s to conflict, got %v", testAttendeeB, failure)
+	}
+}
+
+// ============================================================================
+// Move Handler Tests
+// ============================================================================
+
+func TestHandler_Move_Success(t *testing.T) {
+	var gotMove BookingMove
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			booking.StartTime = time.Date(2099, 3, 10, 9, 0, 0, 0, time.UTC)
+			booking.EndTime = booking.StartTime.Add(2 * time.Hour)
+			return booking, nil
+		},
+		MoveBookingFunc: func(_ context.Context, id int, move BookingMove) (*Booking, error) {
+			gotMove = move
+			return &Booking{ID: id, DeskID: move.DeskID, UserID: "user-123", Status: StatusConfirmed}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/move", bytes.NewBufferString(`{"desk_id":7}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotMove.DeskID != 7 {
+		t.Errorf("expected move to desk 7, got %d", gotMove.DeskID)
+	}
+}
+
+func TestHandler_Move_NothingToMove(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/move", bytes.NewBufferString(`{"reason":"no reason"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Move_ForceAsMember(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/move", bytes.NewBufferString(`{"desk_id":7,"force":true}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Move_DeskNotAvailable(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+		MoveBookingFunc: func(_ context.Context, _ int, _ BookingMove) (*Booking, error) {
+			return nil, ErrDeskNotAvailable
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "admin-1", "admin")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/move", bytes.NewBufferString(`{"desk_id":7,"force":true}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeDeskNotAvailable {
+		t.Errorf("expected %s error, got %v", ErrCodeDeskNotAvailable, apiResp.Error)
+	}
+}
+
+// ============================================================================
+// Transfer Handler Tests
+// ============================================================================
+
+// upcomingBooking returns testBooking moved far enough ahead to be transferable
+func upcomingBooking() *Booking {
+	booking := testBooking()
+	booking.StartTime = time.Date(2099, 3, 10, 9, 0, 0, 0, time.UTC)
+	booking.EndTime = booking.StartTime.Add(2 * time.Hour)
+	return booking
+}
+
+func TestHandler_OfferTransfer_Success(t *testing.T) {
+	const recipient = "0b6f8f4e-8a43-4c1e-9d36-2f1d1c3e5a77"
+	var gotInput *OfferTransferInput
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return upcomingBooking(), nil
+		},
+		CreateTransferFunc: func(_ context.Context, fromUserID string, input *OfferTransferInput) (*BookingTransfer, error) {
+			gotInput = input
+			return &BookingTransfer{ID: 1, BookingID: input.BookingID, FromUserID: fromUserID, ToUserID: input.ToUserID, Status: TransferPending}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"to_user_id":"` + recipient + `"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/transfers", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected status 201, got %d", resp.StatusCode)
+	}
+	if gotInput == nil || gotInput.BookingID != 42 || gotInput.ToUserID != recipient {
+		t.Errorf("expected offer of booking 42 to %s, got %+v", recipient, gotInput)
+	}
+}
+
+func TestHandler_OfferTransfer_InvalidRecipient(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/transfers", bytes.NewBufferString(`{"to_user_id":"bob"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_OfferTransfer_AlreadyPending(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return upcomingBooking(), nil
+		},
+		CreateTransferFunc: func(_ context.Context, _ string, _ *OfferTransferInput) (*BookingTransfer, error) {
+			return nil, ErrTransferPending
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"to_user_id":"0b6f8f4e-8a43-4c1e-9d36-2f1d1c3e5a77"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings/42/transfers", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeTransferPending {
+		t.Errorf("expected %s error, got %v", ErrCodeTransferPending, apiResp.Error)
+	}
+}
+
+func TestHandler_ListTransfers_Empty(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/transfers", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if transfers, ok := apiResp.Data.([]interface{}); !ok || len(transfers) != 0 {
+		t.Errorf("expected an empty list, got %v", apiResp.Data)
+	}
+}
+
+func TestHandler_AcceptTransfer_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return upcomingBooking(), nil
+		},
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-456", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/transfers/7/accept", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_AcceptTransfer_Closed(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			transfer := pendingTransfer()
+			transfer.Status = TransferCancelled
+			return transfer, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-456", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/transfers/7/accept", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeTransferClosed {
+		t.Errorf("expected %s error, got %v", ErrCodeTransferClosed, apiResp.Error)
+	}
+}
+
+func TestHandler_DeclineTransfer_NotRecipient(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-789", "member")
+
+	req := httptest.NewRequest("POST", "/api/v1/transfers/7/decline", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+// ============================================================================
+// Availability Handler Tests
+// ============================================================================
+
+func TestHandler_Availability_Success(t *testing.T) {
+	var gotFilter *AvailabilityFilter
+	mockRepo := &MockRepository{
+		GetDeskOccupancyFunc: func(_ context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			gotFilter = filter
+			return []*DeskOccupancy{{Desk: Desk{ID: 1, DeskNumber: "W1", Wing: WingWest, Features: []string{"standing"}}}}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("GET",
+		"/api/v1/availability?start=2026-03-02T09:00:00Z&end=2026-03-02T17:00:00Z&wing=West&features=standing,%20dual_monitor", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotFilter == nil || gotFilter.Wing == nil || *gotFilter.Wing != WingWest {
+		t.Fatalf("expected wing West, got %+v", gotFilter)
+	}
+	if len(gotFilter.Features) != 2 || gotFilter.Features[0] != "standing" || gotFilter.Features[1] != "dual_monitor" {
+		t.Errorf("expected features [standing dual_monitor], got %v", gotFilter.Features)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	desks, ok := apiResp.Data.([]interface{})
+	if !ok || len(desks) != 1 {
+		t.Fatalf("expected 1 desk, got %v", apiResp.Data)
+	}
+}
+
+func TestHandler_Availability_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing end", "start=2026-03-02T09:00:00Z"},
+		{"bad start", "start=monday&end=2026-03-02T17:00:00Z"},
+		{"end before start", "start=2026-03-02T17:00:00Z&end=2026-03-02T09:00:00Z"},
+		{"unknown wing", "start=2026-03-02T09:00:00Z&end=2026-03-02T17:00:00Z&wing=North"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+			req := httptest.NewRequest("GET", "/api/v1/availability?"+tt.query, nil)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestHandler_Create_ConflictSuggestions(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(_ context.Context, _ *CreateBookingInput) (*Booking, error) {
+			return nil, ErrBookingConflict
+		},
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			return []*DeskOccupancy{{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}}}, nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	reqBody := `{"desk_id":1,"start_time":"2099-03-10T09:00:00Z","end_time":"2099-03-10T11:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	if apiResp.Error == nil || apiResp.Error.Code != ErrCodeBookingConflict {
+		t.Fatalf("expected %s error, got %v", ErrCodeBookingConflict, apiResp.Error)
+	}
+	details, ok := apiResp.Error.Details.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected conflict details, got %v", apiResp.Error.Details)
+	}
+	suggestions, ok := details["suggestions"].([]interface{})
+	if !ok || len(suggestions) == 0 {
+		t.Errorf("expected suggestions, got %v", details["suggestions"])
+	}
+}
+
+func TestHandler_Suggestions(t *testing.T) {
+	app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/availability/suggestions?desk_id=1&start=2099-03-10T09:00:00Z&end=2099-03-10T11:00:00Z", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_Suggestions_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"missing desk", "start=2099-03-10T09:00:00Z&end=2099-03-10T11:00:00Z", fiber.StatusBadRequest},
+		{"missing window", "desk_id=1", fiber.StatusBadRequest},
+		{"unknown desk", "desk_id=99&start=2099-03-10T09:00:00Z&end=2099-03-10T11:00:00Z", fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				GetDeskFunc: func(_ context.Context, _ int) (*Desk, error) {
+					return nil, ErrDeskNotFound
+				},
+			}
+			app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+			req := httptest.NewRequest("GET", "/api/v1/availability/suggestions?"+tt.query, nil)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Timeline Handler Tests
+// ============================================================================
+
+func TestHandler_Timeline_Success(t *testing.T) {
+	var gotWing *string
+	mockRepo := &MockRepository{
+		GetDeskBookingsFunc: func(_ context.Context, wing *string, _, _ time.Time) ([]*DeskBookings, error) {
+			gotWing = wing
+			return testDeskBookings(), nil
+		},
+	}
+	app := setupTestApp(NewHandler(NewService(mockRepo)), "user-123", "member")
+
+	req := httptest.NewRequest("GET", "/api/v1/timeline?date=2026-03-10&wing=East", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotWing == nil || *gotWing != WingEast {
+		t.Errorf("expected wing East, got %v", gotWing)
+	}
+
+	apiResp := parseResponse(t, resp.Body)
+	desks, ok := apiResp.Data.([]interface{})
+	if !ok || len(desks) != 2 {
+		t.Fatalf("expected 2 desks, got %v", apiResp.Data)
+	}
+}
+
+func TestHandler_Timeline_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing date", "wing=East"},
+		{"bad date", "date=10/03/2026"},
+		{"unknown wing", "date=2026-03-10&wing=North"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(NewHandler(NewService(&MockRepository{})), "user-123", "member")
+
+			req := httptest.NewRequest("GET", "/api/v1/timeline?"+tt.query, nil)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
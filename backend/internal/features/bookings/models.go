@@ -3,6 +3,8 @@
 package bookings
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -10,6 +12,8 @@ import (
 type BookingStatus string
 
 const (
+	// StatusHeld indicates a tentative hold that expires unless confirmed
+	StatusHeld BookingStatus = "held"
 	// StatusConfirmed indicates a confirmed booking
 	StatusConfirmed BookingStatus = "confirmed"
 	// StatusCancelled indicates a cancelled booking
@@ -20,19 +24,55 @@ const (
 	StatusNoShow BookingStatus = "no_show"
 )
 
-// Booking represents a desk booking in the system
+// IsValid reports whether the status is one of the known booking statuses
+func (s BookingStatus) IsValid() bool {
+	switch s {
+	case StatusHeld, StatusConfirmed, StatusCancelled, StatusCompleted, StatusNoShow:
+		return true
+	}
+	return false
+}
+
+// SeriesScope selects which occurrences of a recurring series an edit applies to
+type SeriesScope string
+
+const (
+	// ScopeThis applies the edit to the selected occurrence only
+	ScopeThis SeriesScope = "this"
+	// ScopeFollowing applies the edit to the selected occurrence and all later ones
+	ScopeFollowing SeriesScope = "following"
+	// ScopeAll applies the edit to every upcoming occurrence of the series
+	ScopeAll SeriesScope = "all"
+)
+
+// IsValid reports whether the scope is one of the known series scopes
+func (s SeriesScope) IsValid() bool {
+	switch s {
+	case ScopeThis, ScopeFollowing, ScopeAll:
+		return true
+	}
+	return false
+}
+
+// Booking represents a desk booking in the system.
+// StartTime and EndTime always keep the originally booked window.
 type Booking struct {
-	ID            int           `json:"id"`
-	DeskID        int           `json:"desk_id"`
-	UserID        string        `json:"user_id"`
-	StartTime     time.Time     `json:"start_time"`
-	EndTime       time.Time     `json:"end_time"`
-	Status        BookingStatus `json:"status"`
-	CheckedInAt   *time.Time    `json:"checked_in_at,omitempty"`
-	ActualEndTime *time.Time    `json:"actual_end_time,omitempty"`
-	CancelledAt   *time.Time    `json:"cancelled_at,omitempty"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	ID                    int           `json:"id"`
+	DeskID                int           `json:"desk_id"`
+	UserID                string        `json:"user_id"`
+	SeriesID              *int          `json:"series_id,omitempty"`
+	GroupID               *int          `json:"group_id,omitempty"`
+	StartTime             time.Time     `json:"start_time"`
+	EndTime               time.Time     `json:"end_time"`
+	Status                BookingStatus `json:"status"`
+	CheckedInAt           *time.Time    `json:"checked_in_at,omitempty"`
+	ActualEndTime         *time.Time    `json:"actual_end_time,omitempty"`         // Set on check-out
+	ActualDurationMinutes *int          `json:"actual_duration_minutes,omitempty"` // Time used, set on check-out
+	BufferMinutes         int           `json:"buffer_minutes"`                    // Cleaning gap kept free after the booking
+	HoldExpiresAt         *time.Time    `json:"hold_expires_at,omitempty"`         // When a hold is released unless confirmed
+	CancelledAt           *time.Time    `json:"cancelled_at,omitempty"`
+	CreatedAt             time.Time     `json:"created_at"`
+	UpdatedAt             time.Time     `json:"updated_at"`
 }
 
 // CreateBookingInput represents the input for creating a new booking
@@ -41,6 +81,7 @@ type CreateBookingInput struct {
 	UserID    string
 	StartTime time.Time
 	EndTime   time.Time
+	Hold      bool // Create a tentative hold that expires after settings.hold_ttl_minutes
 }
 
 // UpdateBookingInput represents the input for updating an existing booking
@@ -51,6 +92,228 @@ type UpdateBookingInput struct {
 	CheckedInAt   *time.Time
 	ActualEndTime *time.Time
 	CancelledAt   *time.Time
+	ChangedBy     *string // Who changed Status; nil for system changes
+	Reason        string  // Why Status changed
+}
+
+// MoveBookingInput represents the input for moving a booking to another desk and/or time.
+// Nil fields keep the booking's current value.
+type MoveBookingInput struct {
+	DeskID    *int
+	StartTime *time.Time
+	EndTime   *time.Time
+	Force     bool // Admins only: skip the settings policies and allow moving a started booking
+	Reason    string
+}
+
+// BookingMove represents the resolved destination of a booking move and who made it
+type BookingMove struct {
+	DeskID    int
+	StartTime time.Time
+	EndTime   time.Time
+	ActorID   *string
+	Reason    string
+	Forced    bool
+}
+
+// BulkCancelInput selects the active bookings an admin cancels in one action, e.g. when a wing
+// closes. At least one of DeskIDs and Wing must be set; when both are, desks must match both.
+type BulkCancelInput struct {
+	DeskIDs   []int
+	Wing      *string
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+	DryRun    bool // Only preview the bookings that would be cancelled
+}
+
+// BulkCancelResult lists the bookings a bulk cancellation cancelled, or would cancel on a dry run
+type BulkCancelResult struct {
+	DryRun   bool       `json:"dry_run"`
+	Count    int        `json:"count"`
+	Bookings []*Booking `json:"bookings"`
+}
+
+// MaintenancePolicy decides what happens to the bookings a new maintenance block overlaps
+type MaintenancePolicy string
+
+const (
+	// MaintenanceReject refuses the block while it overlaps any booking
+	MaintenanceReject MaintenancePolicy = "reject"
+	// MaintenanceCancel cancels the overlapping bookings
+	MaintenanceCancel MaintenancePolicy = "cancel"
+	// MaintenanceMove moves each overlapping booking to an equivalent free desk in the same wing
+	MaintenanceMove MaintenancePolicy = "move"
+)
+
+// IsValid reports whether the policy is one of the known maintenance policies
+func (p MaintenancePolicy) IsValid() bool {
+	switch p {
+	case MaintenanceReject, MaintenanceCancel, MaintenanceMove:
+		return true
+	}
+	return false
+}
+
+// MaintenanceBlock takes a desk out of service for a time range
+type MaintenanceBlock struct {
+	ID        int       `json:"id"`
+	DeskID    int       `json:"desk_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateMaintenanceInput represents the input for blocking a desk for maintenance
+type CreateMaintenanceInput struct {
+	DeskID    int
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+	Policy    MaintenancePolicy
+	CreatedBy string
+}
+
+// MaintenanceResult represents a created maintenance block and the bookings it displaced
+type MaintenanceResult struct {
+	Block     *MaintenanceBlock `json:"block"`
+	Cancelled []*Booking        `json:"cancelled"`
+	Moved     []*Booking        `json:"moved"`
+}
+
+// MaintenanceInsertResult represents the outcome of inserting a maintenance block.
+// Conflicts is only set when the block was rolled back.
+type MaintenanceInsertResult struct {
+	Block     *MaintenanceBlock
+	Cancelled []*Booking
+	Moved     []*Booking
+	Conflicts []*Booking
+}
+
+// MaintenanceFilter represents filters for listing maintenance blocks
+type MaintenanceFilter struct {
+	DeskID    *int
+	StartTime *time.Time
+	EndTime   *time.Time
+}
+
+// Closure closes the office, or one wing of it, for a time range: whole days for public holidays
+// and shutdowns, or part of a day for blackouts
+type Closure struct {
+	ID        int       `json:"id"`
+	Wing      *string   `json:"wing"` // nil closes every wing
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateClosureInput represents the input for closing the office or a wing. Either Date, with an
+// optional inclusive EndDate, closes whole days, or StartTime and EndTime close a time range.
+type CreateClosureInput struct {
+	Wing      *string
+	Date      *time.Time
+	EndDate   *time.Time
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+	CreatedBy string
+}
+
+// ClosureFilter represents filters for querying closures. Wing and DeskID match the closures
+// that apply to them, office-wide ones included.
+type ClosureFilter struct {
+	Wing      *string
+	DeskID    *int
+	StartTime *time.Time
+	EndTime   *time.Time
+}
+
+// TimeOfDay is an offset from midnight, written as "HH:MM" in JSON
+type TimeOfDay time.Duration
+
+// MarshalJSON writes the time of day as "HH:MM"
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	d := time.Duration(t)
+	return json.Marshal(fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60))
+}
+
+// OpeningHours is a weekday's entry in the weekly opening schedule. Entries without a wing apply
+// to the whole office and a wing's own entry overrides them; Opens and Closes are nil on closed days.
+type OpeningHours struct {
+	ID        int          `json:"id"`
+	Wing      *string      `json:"wing"`
+	Weekday   time.Weekday `json:"weekday"` // 0 is Sunday
+	Opens     *TimeOfDay   `json:"opens_at"`
+	Closes    *TimeOfDay   `json:"closes_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// SetOpeningHoursInput represents the input for setting a weekday's hours, for the whole office or
+// one wing. Leaving Opens and Closes nil closes the day.
+type SetOpeningHoursInput struct {
+	Wing    *string
+	Weekday time.Weekday
+	Opens   *TimeOfDay
+	Closes  *TimeOfDay
+}
+
+// QuotaPeriod is the span a booking quota counts over, in the site timezone
+type QuotaPeriod string
+
+const (
+	// QuotaWeek counts from Monday 00:00 to the next Monday
+	QuotaWeek QuotaPeriod = "week"
+	// QuotaMonth counts over a calendar month
+	QuotaMonth QuotaPeriod = "month"
+)
+
+// IsValid reports whether the period is one of the quota_period enum values
+func (p QuotaPeriod) IsValid() bool {
+	return p == QuotaWeek || p == QuotaMonth
+}
+
+// Quota caps how many hours and how many bookings users of a role may hold per week or month.
+// A nil limit leaves that measure uncapped.
+type Quota struct {
+	ID          int         `json:"id"`
+	Role        string      `json:"role"`
+	Period      QuotaPeriod `json:"period"`
+	MaxHours    *int        `json:"max_hours"`
+	MaxBookings *int        `json:"max_bookings"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// SetQuotaInput represents the input for setting a role's quota for a period
+type SetQuotaInput struct {
+	Role        string
+	Period      QuotaPeriod
+	MaxHours    *int
+	MaxBookings *int
+}
+
+// QuotaUsage is what a user has booked within a quota period. Hours are clipped to the period and
+// bookings are counted in the period they start in.
+type QuotaUsage struct {
+	Hours    float64
+	Bookings int
+}
+
+// QuotaStatus reports a user's allowance under one quota for the current period.
+// Remaining values are nil for measures the quota leaves uncapped.
+type QuotaStatus struct {
+	Period            QuotaPeriod `json:"period"`
+	PeriodStart       time.Time   `json:"period_start"`
+	PeriodEnd         time.Time   `json:"period_end"`
+	MaxHours          *int        `json:"max_hours"`
+	UsedHours         float64     `json:"used_hours"`
+	RemainingHours    *float64    `json:"remaining_hours"`
+	MaxBookings       *int        `json:"max_bookings"`
+	UsedBookings      int         `json:"used_bookings"`
+	RemainingBookings *int        `json:"remaining_bookings"`
 }
 
 // BookingFilter represents filters for querying bookings
@@ -60,8 +323,36 @@ type BookingFilter struct {
 	Status    *BookingStatus
 	StartDate *time.Time
 	EndDate   *time.Time
+	Sort      SortDirection  // Order by (start_time, id); defaults to SortDesc
+	Cursor    *BookingCursor // Resume after or before a position of a previous page
 	Limit     int
-	Offset    int
+	Offset    int // Deprecated: the API refuses offset; page with Cursor instead
+}
+
+// SortDirection orders booking listings by start time
+type SortDirection string
+
+const (
+	// SortAsc lists the earliest bookings first
+	SortAsc SortDirection = "asc"
+	// SortDesc lists the latest bookings first
+	SortDesc SortDirection = "desc"
+)
+
+// BookingCursor marks a position in a booking listing ordered by (start_time, id).
+// Before selects the page preceding the position instead of the one following it.
+type BookingCursor struct {
+	StartTime time.Time
+	ID        int
+	Before    bool
+}
+
+// BookingPage is one page of a booking listing.
+// Next and Prev are nil when there is no page in that direction.
+type BookingPage struct {
+	Bookings []*Booking
+	Next     *BookingCursor
+	Prev     *BookingCursor
 }
 
 // DeskAvailabilityCheck represents the parameters for checking desk availability
@@ -71,3 +362,353 @@ type DeskAvailabilityCheck struct {
 	EndTime          time.Time
 	ExcludeBookingID *int // Exclude this booking ID when checking (for updates)
 }
+
+// Settings represents the global booking policies stored in the settings table
+type Settings struct {
+	OpeningStart              time.Duration // Offset from midnight, for weekdays the schedule leaves out
+	OpeningEnd                time.Duration // Offset from midnight, for weekdays the schedule leaves out
+	Schedule                  []*OpeningHours
+	Location                  *time.Location // Site timezone that days and opening hours are in
+	DailyHourLimit            int
+	Quotas                    []*Quota                 // Weekly and monthly quotas of every role
+	BookingWindows            map[string]BookingWindow // Per role; roles left out are unrestricted
+	CheckInGracePeriodMinutes int
+	CleaningBufferMinutes     int // Gap between bookings on a desk, unless the desk overrides it
+}
+
+// BookingWindow limits how far ahead and how soon before its start a role may make a booking
+type BookingWindow struct {
+	MaxAdvanceDays *int `json:"max_advance_days"` // Days after today, in the site timezone; nil is unlimited
+	MinLeadMinutes int  `json:"min_lead_minutes"`
+}
+
+// restricts reports whether the window refuses any booking start
+func (w BookingWindow) restricts() bool {
+	return w.MaxAdvanceDays != nil || w.MinLeadMinutes > 0
+}
+
+// roleDependent reports whether a quota or a restricted booking window depends on the user's role
+func (s *Settings) roleDependent() bool {
+	restricted := len(s.Quotas) > 0
+	for _, window := range s.BookingWindows {
+		restricted = restricted || window.restricts()
+	}
+	return restricted
+}
+
+// location returns the site timezone, UTC when none is set
+func (s *Settings) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+// Series represents a recurring booking series expanded from an RRULE
+type Series struct {
+	ID        int         `json:"id"`
+	UserID    string      `json:"user_id"`
+	DeskID    int         `json:"desk_id"`
+	RRule     string      `json:"rrule"`
+	StartTime time.Time   `json:"start_time"` // Start of the first occurrence
+	EndTime   time.Time   `json:"end_time"`   // End of the first occurrence
+	ExDates   []time.Time `json:"exdates"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Occurrence represents a single expanded instance of a recurrence rule
+type Occurrence struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// SkippedOccurrence represents an occurrence that could not be booked and why
+type SkippedOccurrence struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+}
+
+// CreateSeriesInput represents the input for creating a recurring booking series
+type CreateSeriesInput struct {
+	DeskID    int
+	UserID    string
+	StartTime time.Time // Start of the first occurrence
+	EndTime   time.Time // End of the first occurrence
+	RRule     string
+	ExDates   []time.Time
+	Partial   bool // Book only the free occurrences instead of failing the whole series
+}
+
+// SeriesInsertResult represents the outcome of inserting a series' occurrences
+type SeriesInsertResult struct {
+	Series    *Series
+	Bookings  []*Booking
+	Conflicts []Occurrence
+}
+
+// SeriesResult represents the outcome of creating a recurring booking series
+type SeriesResult struct {
+	Series   *Series             `json:"series"`
+	Bookings []*Booking          `json:"bookings"`
+	Skipped  []SkippedOccurrence `json:"skipped"`
+}
+
+// BookingReschedule represents a new time window for an existing booking
+type BookingReschedule struct {
+	BookingID int
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// RescheduleResult represents the outcome of rescheduling several bookings at once
+type RescheduleResult struct {
+	Bookings  []*Booking
+	Conflicts []Occurrence
+}
+
+// WaitlistStatus represents the status of a waitlist entry
+type WaitlistStatus string
+
+const (
+	// WaitlistWaiting indicates the member is still waiting for a slot
+	WaitlistWaiting WaitlistStatus = "waiting"
+	// WaitlistFulfilled indicates the entry was promoted to a confirmed booking
+	WaitlistFulfilled WaitlistStatus = "fulfilled"
+	// WaitlistCancelled indicates the member left the waitlist
+	WaitlistCancelled WaitlistStatus = "cancelled"
+)
+
+// Wings a desk can belong to, mirroring the wing_type enum
+const (
+	WingEast = "East"
+	WingWest = "West"
+)
+
+// WaitlistEntry represents a member waiting for a desk, or any desk in a wing, for a time range
+type WaitlistEntry struct {
+	ID          int            `json:"id"`
+	UserID      string         `json:"user_id"`
+	DeskID      *int           `json:"desk_id,omitempty"`
+	Wing        *string        `json:"wing,omitempty"`
+	StartTime   time.Time      `json:"start_time"`
+	EndTime     time.Time      `json:"end_time"`
+	Status      WaitlistStatus `json:"status"`
+	BookingID   *int           `json:"booking_id,omitempty"`
+	FulfilledAt *time.Time     `json:"fulfilled_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// JoinWaitlistInput represents the input for joining the waitlist.
+// Exactly one of DeskID and Wing must be set.
+type JoinWaitlistInput struct {
+	UserID    string
+	DeskID    *int
+	Wing      *string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// GroupAttendee represents one attendee of a group booking and, when desks are named, their desk
+type GroupAttendee struct {
+	UserID string `json:"user_id"`
+	DeskID *int   `json:"desk_id,omitempty"`
+}
+
+// CreateGroupInput represents the input for booking desks for several attendees at once.
+// Either every attendee names a desk, or Wing is set and adjacent free desks are picked for them.
+type CreateGroupInput struct {
+	OrganizerID string
+	StartTime   time.Time
+	EndTime     time.Time
+	Attendees   []GroupAttendee
+	Wing        *string
+	Features    []string // Picked desks must have every listed feature
+}
+
+// GroupAssignment represents the desk an attendee of a group booking is booked on
+type GroupAssignment struct {
+	UserID string
+	DeskID int
+}
+
+// BookingGroup represents a set of bookings made together for a team
+type BookingGroup struct {
+	ID          int       `json:"id"`
+	OrganizerID string    `json:"organizer_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GroupFailure represents an attendee, and the desk where known, that kept a group from being booked
+type GroupFailure struct {
+	UserID string `json:"user_id"`
+	DeskID *int   `json:"desk_id,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// GroupInsertResult represents the outcome of inserting a group's bookings.
+// Conflicts and MissingUsers are only set when the group was rolled back.
+type GroupInsertResult struct {
+	Group        *BookingGroup
+	Bookings     []*Booking
+	Conflicts    []GroupAssignment
+	MissingUsers []GroupAssignment
+}
+
+// GroupResult represents a booked group and one booking per attendee
+type GroupResult struct {
+	Group    *BookingGroup `json:"group"`
+	Bookings []*Booking    `json:"bookings"`
+}
+
+// TransferStatus represents the status of a booking transfer offer
+type TransferStatus string
+
+const (
+	// TransferPending indicates the recipient has not answered yet
+	TransferPending TransferStatus = "pending"
+	// TransferAccepted indicates the bookings changed hands
+	TransferAccepted TransferStatus = "accepted"
+	// TransferDeclined indicates the recipient turned the offer down
+	TransferDeclined TransferStatus = "declined"
+	// TransferCancelled indicates the offer was withdrawn or went stale
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// BookingTransfer represents an offer to hand a booking to a colleague.
+// When SwapBookingID is set the offer is a swap: the recipient's booking goes to the offerer in return.
+type BookingTransfer struct {
+	ID            int            `json:"id"`
+	BookingID     int            `json:"booking_id"`
+	FromUserID    string         `json:"from_user_id"`
+	ToUserID      string         `json:"to_user_id"`
+	SwapBookingID *int           `json:"swap_booking_id,omitempty"`
+	Status        TransferStatus `json:"status"`
+	RespondedAt   *time.Time     `json:"responded_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// OfferTransferInput represents the input for offering a booking to a colleague
+type OfferTransferInput struct {
+	BookingID     int
+	ToUserID      string
+	SwapBookingID *int
+}
+
+// TransferResult represents an accepted transfer and the bookings that changed hands
+type TransferResult struct {
+	Transfer *BookingTransfer `json:"transfer"`
+	Bookings []*Booking       `json:"bookings"`
+}
+
+// Interval represents a half-open [StartTime, EndTime) span of time
+type Interval struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// deskStatusAvailable mirrors the desk_status enum value for desks that can be booked
+const deskStatusAvailable = "available"
+
+// Desk represents a bookable desk
+type Desk struct {
+	ID                    int      `json:"id"`
+	DeskNumber            string   `json:"desk_number"`
+	Wing                  string   `json:"wing"`
+	Features              []string `json:"features"`
+	Status                string   `json:"status"`
+	CleaningBufferMinutes int      `json:"cleaning_buffer_minutes"` // Effective buffer: the desk's override or the global setting
+}
+
+// AvailabilityFilter represents the parameters for searching desk availability
+type AvailabilityFilter struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Wing      *string
+	Features  []string // Desks must have every listed feature
+}
+
+// DeskOccupancy represents a desk within a search window: the ranges its active bookings, cleaning
+// buffer included, and maintenance blocks keep it busy, and the closures of its wing, each in
+// chronological order
+type DeskOccupancy struct {
+	Desk   Desk
+	Busy   []Interval
+	Closed []Interval
+}
+
+// DeskAvailability represents a desk with free time in a search window.
+// FullyAvailable desks have a single free interval covering the whole window.
+type DeskAvailability struct {
+	Desk           Desk       `json:"desk"`
+	FullyAvailable bool       `json:"fully_available"`
+	FreeIntervals  []Interval `json:"free_intervals"`
+}
+
+// SuggestionKind describes how a suggested slot differs from the requested one
+type SuggestionKind string
+
+const (
+	// SuggestionSameDesk offers the requested desk at a different time
+	SuggestionSameDesk SuggestionKind = "same_desk"
+	// SuggestionNearbyDesk offers the requested time at a nearby desk in the same wing
+	SuggestionNearbyDesk SuggestionKind = "nearby_desk"
+)
+
+// Suggestion represents a free slot offered in place of a conflicting booking
+type Suggestion struct {
+	Kind       SuggestionKind `json:"kind"`
+	DeskID     int            `json:"desk_id"`
+	DeskNumber string         `json:"desk_number"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+}
+
+// SegmentType distinguishes busy and free segments of a desk timeline
+type SegmentType string
+
+const (
+	// SegmentBusy is a stretch of time taken by a booking
+	SegmentBusy SegmentType = "busy"
+	// SegmentFree is a stretch of opening hours the desk can be booked for
+	SegmentFree SegmentType = "free"
+	// SegmentMaintenance is a stretch of time the desk is blocked for maintenance
+	SegmentMaintenance SegmentType = "maintenance"
+	// SegmentClosed is a stretch of time the desk's wing is closed
+	SegmentClosed SegmentType = "closed"
+)
+
+// TimelineSegment represents a busy, free, maintenance or closed stretch of a desk's day.
+// Busy segments carry the booking; UserID is only shown to admins and to the booking's owner.
+// Maintenance and closed segments carry the block or closure and its reason.
+type TimelineSegment struct {
+	Type          SegmentType    `json:"type"`
+	StartTime     time.Time      `json:"start_time"`
+	EndTime       time.Time      `json:"end_time"`
+	BookingID     *int           `json:"booking_id,omitempty"`
+	Status        *BookingStatus `json:"status,omitempty"`
+	UserID        *string        `json:"user_id,omitempty"`
+	MaintenanceID *int           `json:"maintenance_id,omitempty"`
+	ClosureID     *int           `json:"closure_id,omitempty"`
+	Reason        *string        `json:"reason,omitempty"`
+}
+
+// DeskBookings represents a desk with its active bookings, maintenance blocks and the closures of
+// its wing within a time range, each in chronological order
+type DeskBookings struct {
+	Desk        Desk
+	Bookings    []*Booking
+	Maintenance []*MaintenanceBlock
+	Closures    []*Closure
+}
+
+// DeskTimeline represents a desk's day broken into chronological busy and free segments
+type DeskTimeline struct {
+	Desk     Desk              `json:"desk"`
+	Segments []TimelineSegment `json:"segments"`
+}
@@ -0,0 +1,132 @@
+package bookings
+
+import (
+	"context"
+	"time"
+)
+
+// quotaTracker checks ranges against one user's weekly and monthly quotas. Usage is loaded once
+// per quota period and ranges that pass are added to it, so a recurring series is checked as a
+// whole against every period it reaches before anything is booked.
+type quotaTracker struct {
+	loadUsage quotaUsageFunc
+	userID    string
+	loc       *time.Location
+	quotas    []*Quota
+	usage     map[quotaWindow]*QuotaUsage
+}
+
+// quotaUsageFunc loads the hours a user has booked within a range and how many bookings start in it
+type quotaUsageFunc func(ctx context.Context, userID string, startTime, endTime time.Time) (*QuotaUsage, error)
+
+// quotaWindow identifies one period of a quota by its start
+type quotaWindow struct {
+	period QuotaPeriod
+	start  int64
+}
+
+// newQuotaTracker returns a tracker for the quotas of role, loading usage through loadUsage
+func newQuotaTracker(settings *Settings, userID, role string, loadUsage quotaUsageFunc) *quotaTracker {
+	tracker := &quotaTracker{
+		loadUsage: loadUsage,
+		userID:    userID,
+		loc:       settings.location(),
+		usage:     make(map[quotaWindow]*QuotaUsage),
+	}
+	for _, quota := range settings.Quotas {
+		if quota.Role == role {
+			tracker.quotas = append(tracker.quotas, quota)
+		}
+	}
+	return tracker
+}
+
+// userQuotaTracker loads the quotas of the user's role. The role is only looked up when some quota
+// is configured.
+func (s *Service) userQuotaTracker(ctx context.Context, settings *Settings, userID string) (*quotaTracker, error) {
+	var role string
+	if len(settings.Quotas) > 0 {
+		var err error
+		role, err = s.repo.GetUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newQuotaTracker(settings, userID, role, s.repo.GetUserQuotaUsage), nil
+}
+
+// add checks that booking the range keeps the user within each quota for every period the range
+// overlaps, and counts it towards them when it does. Hours are split at period boundaries in the
+// site timezone; the booking itself counts towards the period it starts in. existing is a booking
+// the range replaces and is not counted.
+func (t *quotaTracker) add(ctx context.Context, startTime, endTime time.Time, existing *Booking) error {
+	updated := make(map[quotaWindow]QuotaUsage, len(t.quotas))
+	for _, quota := range t.quotas {
+		periodStart, periodEnd := quotaPeriodBounds(quota.Period, startTime, t.loc)
+		for ; periodStart.Before(endTime); periodStart, periodEnd = quotaPeriodBounds(quota.Period, periodEnd, t.loc) {
+			usage, err := t.usageIn(ctx, quota.Period, periodStart, periodEnd)
+			if err != nil {
+				return err
+			}
+
+			period := Interval{StartTime: periodStart, EndTime: periodEnd}
+			next := *usage
+			if existing != nil {
+				next.Hours -= hoursWithin(Interval{StartTime: existing.StartTime, EndTime: existing.EndTime}, period)
+				if !existing.StartTime.Before(periodStart) && existing.StartTime.Before(periodEnd) {
+					next.Bookings--
+				}
+			}
+			next.Hours += hoursWithin(Interval{StartTime: startTime, EndTime: endTime}, period)
+			startsHere := !periodStart.After(startTime)
+			if startsHere {
+				next.Bookings++
+			}
+
+			if (quota.MaxHours != nil && next.Hours > float64(*quota.MaxHours)) ||
+				(startsHere && quota.MaxBookings != nil && next.Bookings > *quota.MaxBookings) {
+				return &QuotaError{Quota: quota}
+			}
+			updated[quotaWindow{period: quota.Period, start: periodStart.Unix()}] = next
+		}
+	}
+
+	for window, usage := range updated {
+		*t.usage[window] = usage
+	}
+	return nil
+}
+
+// usageIn returns the user's usage in a quota period, loading it on first use
+func (t *quotaTracker) usageIn(ctx context.Context, period QuotaPeriod, periodStart, periodEnd time.Time) (*QuotaUsage, error) {
+	window := quotaWindow{period: period, start: periodStart.Unix()}
+	if usage, ok := t.usage[window]; ok {
+		return usage, nil
+	}
+	usage, err := t.loadUsage(ctx, t.userID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	t.usage[window] = usage
+	return usage, nil
+}
+
+// quotaPeriodBounds returns the week, from Monday, or the calendar month containing t, in loc
+func quotaPeriodBounds(period QuotaPeriod, t time.Time, loc *time.Location) (time.Time, time.Time) {
+	day := startOfDay(t, loc)
+	if period == QuotaWeek {
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	}
+	start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// hoursWithin returns how many hours of interval fall inside period
+func hoursWithin(interval, period Interval) float64 {
+	clipped := clipInterval(interval, period)
+	if !clipped.EndTime.After(clipped.StartTime) {
+		return 0
+	}
+	return clipped.EndTime.Sub(clipped.StartTime).Hours()
+}
@@ -0,0 +1,129 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// quotaPeriodBounds Tests
+// ============================================================================
+
+func TestQuotaPeriodBounds(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+
+	tests := []struct {
+		name   string
+		period QuotaPeriod
+		at     time.Time
+		start  time.Time
+		length time.Duration
+	}{
+		{
+			name:   "week from Monday",
+			period: QuotaWeek,
+			at:     time.Date(2026, time.March, 12, 15, 0, 0, 0, london),
+			start:  time.Date(2026, time.March, 9, 0, 0, 0, 0, london),
+			length: 7 * 24 * time.Hour,
+		},
+		{
+			name:   "Sunday closes the week",
+			period: QuotaWeek,
+			at:     time.Date(2026, time.March, 15, 21, 0, 0, 0, london),
+			start:  time.Date(2026, time.March, 9, 0, 0, 0, 0, london),
+			length: 7 * 24 * time.Hour,
+		},
+		{
+			name:   "week the clocks go forward",
+			period: QuotaWeek,
+			at:     time.Date(2026, time.March, 29, 12, 0, 0, 0, london),
+			start:  time.Date(2026, time.March, 23, 0, 0, 0, 0, london),
+			length: 7*24*time.Hour - time.Hour,
+		},
+		{
+			name:   "calendar month",
+			period: QuotaMonth,
+			at:     time.Date(2026, time.February, 28, 23, 30, 0, 0, london),
+			start:  time.Date(2026, time.February, 1, 0, 0, 0, 0, london),
+			length: 28 * 24 * time.Hour,
+		},
+		{
+			name:   "month the clocks go back",
+			period: QuotaMonth,
+			at:     time.Date(2026, time.October, 1, 0, 0, 0, 0, london),
+			start:  time.Date(2026, time.October, 1, 0, 0, 0, 0, london),
+			length: 31*24*time.Hour + time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := quotaPeriodBounds(tt.period, tt.at, london)
+			if !start.Equal(tt.start) {
+				t.Errorf("expected the period to start at %v, got %v", tt.start, start)
+			}
+			if end.Sub(start) != tt.length {
+				t.Errorf("expected the period to last %v, got %v", tt.length, end.Sub(start))
+			}
+		})
+	}
+}
+
+// ============================================================================
+// quotaTracker Tests
+// ============================================================================
+
+func TestQuotaTracker_SplitsHoursAtPeriodBoundary(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+	maxHours := 10
+	settings := &Settings{
+		Location: london,
+		Quotas:   []*Quota{{Role: "member", Period: QuotaWeek, MaxHours: &maxHours}},
+	}
+	sunday := time.Date(2026, time.March, 15, 22, 0, 0, 0, london)
+	monday := time.Date(2026, time.March, 16, 0, 0, 0, 0, london)
+
+	tests := []struct {
+		name       string
+		mondayWeek float64
+		wantErr    bool
+	}{
+		{name: "next week has room for the hours after midnight", mondayWeek: 8},
+		{name: "hours after midnight take next week over", mondayWeek: 9, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadUsage := func(_ context.Context, _ string, startTime, _ time.Time) (*QuotaUsage, error) {
+				if startTime.Equal(monday) {
+					return &QuotaUsage{Hours: tt.mondayWeek, Bookings: 1}, nil
+				}
+				return &QuotaUsage{Hours: 7, Bookings: 1}, nil
+			}
+			tracker := newQuotaTracker(settings, "user-123", "member", loadUsage)
+
+			err := tracker.add(context.Background(), sunday, sunday.Add(4*time.Hour), nil)
+			if tt.wantErr {
+				var quotaErr *QuotaError
+				if !errors.As(err, &quotaErr) {
+					t.Fatalf("expected a quota error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			previous := tracker.usage[quotaWindow{period: QuotaWeek, start: monday.AddDate(0, 0, -7).Unix()}]
+			if previous.Hours != 9 || previous.Bookings != 2 {
+				t.Errorf("expected 2 hours and the booking in the week it starts in, got %+v", previous)
+			}
+			next := tracker.usage[quotaWindow{period: QuotaWeek, start: monday.Unix()}]
+			if next.Hours != 10 || next.Bookings != 1 {
+				t.Errorf("expected only 2 hours in the following week, got %+v", next)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package bookings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSeriesOccurrences caps how many bookings a single recurrence rule may expand to
+const MaxSeriesOccurrences = 366
+
+// maxRecurrencePeriods bounds the expansion loop for rules that rarely match (e.g. the 31st)
+const maxRecurrencePeriods = 5000
+
+var (
+	// ErrInvalidRecurrence is returned when a recurrence rule cannot be parsed or expanded
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+	// ErrTooManyOccurrences is returned when a recurrence rule expands beyond MaxSeriesOccurrences
+	ErrTooManyOccurrences = errors.New("recurrence rule produces too many occurrences")
+)
+
+// Frequency represents the RRULE FREQ part
+type Frequency string
+
+const (
+	// FrequencyDaily repeats every INTERVAL days
+	FrequencyDaily Frequency = "DAILY"
+	// FrequencyWeekly repeats every INTERVAL weeks
+	FrequencyWeekly Frequency = "WEEKLY"
+	// FrequencyMonthly repeats every INTERVAL months
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+// weekdayCodes maps RFC 5545 two-letter day codes to weekdays
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceRule is the supported subset of an RFC 5545 RRULE
+type RecurrenceRule struct {
+	Freq      Frequency
+	Interval  int
+	ByDay     []time.Weekday
+	Count     int
+	Until     *time.Time
+	untilDate bool // UNTIL was a DATE value and is compared by calendar day
+	WeekStart time.Weekday
+}
+
+// ParseRRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=16".
+// The rule must be bounded by COUNT or UNTIL.
+func ParseRRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("%w: rule is empty", ErrInvalidRecurrence)
+	}
+
+	rule := &RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrence, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq := Frequency(strings.ToUpper(val))
+			if freq != FrequencyDaily && freq != FrequencyWeekly && freq != FrequencyMonthly {
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRecurrence, val)
+			}
+			rule.Freq = freq
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, dateOnly, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+			rule.untilDate = dateOnly
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(val), ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return nil, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRecurrence, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			day, ok := weekdayCodes[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("%w: unsupported WKST value %q", ErrInvalidRecurrence, val)
+			}
+			rule.WeekStart = day
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalidRecurrence, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL must not both be set", ErrInvalidRecurrence)
+	}
+	if rule.Count == 0 && rule.Until == nil {
+		return nil, fmt.Errorf("%w: COUNT or UNTIL is required", ErrInvalidRecurrence)
+	}
+
+	return rule, nil
+}
+
+// parseUntil parses an UNTIL value in UTC date-time, floating date-time or date form
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: UNTIL must be a DATE or DATE-TIME value", ErrInvalidRecurrence)
+}
+
+// Expand returns the occurrences of the rule for a first occurrence starting at start
+// and lasting duration. Occurrences whose start matches an entry in exdates are removed
+// after expansion, so they still count towards COUNT as in RFC 5545.
+func (r *RecurrenceRule) Expand(start time.Time, duration time.Duration, exdates []time.Time) ([]Occurrence, error) {
+	var starts []time.Time
+	done := false
+
+	emit := func(candidate time.Time) error {
+		if candidate.Before(start) {
+			return nil
+		}
+		if r.Until != nil && r.afterUntil(candidate) {
+			done = true
+			return nil
+		}
+		starts = append(starts, candidate)
+		if r.Count > 0 && len(starts) >= r.Count {
+			done = true
+		}
+		if len(starts) > MaxSeriesOccurrences {
+			return ErrTooManyOccurrences
+		}
+		return nil
+	}
+
+	for period := 0; period < maxRecurrencePeriods && !done; period++ {
+		for _, candidate := range r.periodCandidates(start, period) {
+			if err := emit(candidate); err != nil {
+				return nil, err
+			}
+			if done {
+				break
+			}
+		}
+	}
+
+	occurrences := make([]Occurrence, 0, len(starts))
+	for _, occurrenceStart := range starts {
+		if isExcluded(occurrenceStart, exdates) {
+			continue
+		}
+		occurrences = append(occurrences, Occurrence{
+			StartTime: occurrenceStart,
+			EndTime:   occurrenceStart.Add(duration),
+		})
+	}
+
+	return occurrences, nil
+}
+
+// periodCandidates returns the sorted candidate starts within the nth period of the rule
+func (r *RecurrenceRule) periodCandidates(start time.Time, n int) []time.Time {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	var candidates []time.Time
+	switch r.Freq {
+	case FrequencyDaily:
+		candidate := at(start.Year(), start.Month(), start.Day()+n*r.Interval)
+		if r.matchesByDay(candidate.Weekday()) {
+			candidates = append(candidates, candidate)
+		}
+	case FrequencyWeekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := at(start.Year(), start.Month(), start.Day()-offset+n*7*r.Interval)
+		for i := 0; i < 7; i++ {
+			candidate := at(weekStart.Year(), weekStart.Month(), weekStart.Day()+i)
+			if r.weeklyMatch(candidate.Weekday(), start.Weekday()) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	case FrequencyMonthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(n*r.Interval), 1, 0, 0, 0, 0, start.Location())
+		if len(r.ByDay) == 0 {
+			candidate := at(first.Year(), first.Month(), start.Day())
+			// Skip months that do not have this day (e.g. the 31st)
+			if candidate.Month() == first.Month() {
+				candidates = append(candidates, candidate)
+			}
+		} else {
+			for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+				if r.matchesByDay(day.Weekday()) {
+					candidates = append(candidates, at(day.Year(), day.Month(), day.Day()))
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return candidates
+}
+
+// matchesByDay reports whether the weekday is allowed by BYDAY (all days when unset)
+func (r *RecurrenceRule) matchesByDay(day time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// weeklyMatch applies BYDAY for weekly rules, defaulting to the first occurrence's weekday
+func (r *RecurrenceRule) weeklyMatch(day, startDay time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return day == startDay
+	}
+	return r.matchesByDay(day)
+}
+
+// afterUntil reports whether the candidate falls after UNTIL (inclusive bound)
+func (r *RecurrenceRule) afterUntil(candidate time.Time) bool {
+	if r.untilDate {
+		y, m, d := candidate.Date()
+		candidateDate := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return candidateDate.After(*r.Until)
+	}
+	return candidate.After(*r.Until)
+}
+
+// anchorExDates moves each exception date to the series' local start time on its date in start's
+// location, so exceptions keep matching occurrences that follow the site's clock across DST changes
+func anchorExDates(exdates []time.Time, start time.Time, loc *time.Location) []time.Time {
+	clock := wallClock(start, loc)
+	anchored := make([]time.Time, 0, len(exdates))
+	for _, exdate := range exdates {
+		anchored = append(anchored, atTimeOfDay(dateIn(exdate.In(start.Location()), loc), clock))
+	}
+	return anchored
+}
+
+// isExcluded reports whether start matches one of the exception dates
+func isExcluded(start time.Time, exdates []time.Time) bool {
+	for _, exdate := range exdates {
+		if start.Equal(exdate) {
+			return true
+		}
+	}
+	return false
+}
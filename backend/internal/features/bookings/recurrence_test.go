@@ -0,0 +1,136 @@
+package bookings
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// ParseRRule Tests
+// ============================================================================
+
+func TestParseRRule_Valid(t *testing.T) {
+	rule, err := ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=16")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rule.Freq != FrequencyWeekly || rule.Interval != 2 || rule.Count != 16 {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+	if len(rule.ByDay) != 2 || rule.ByDay[0] != time.Tuesday || rule.ByDay[1] != time.Thursday {
+		t.Errorf("unexpected BYDAY: %v", rule.ByDay)
+	}
+}
+
+func TestParseRRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"missing freq", "COUNT=3"},
+		{"unsupported freq", "FREQ=YEARLY;COUNT=3"},
+		{"unbounded", "FREQ=DAILY"},
+		{"count and until", "FREQ=DAILY;COUNT=3;UNTIL=20260401"},
+		{"bad interval", "FREQ=DAILY;INTERVAL=0;COUNT=3"},
+		{"bad byday", "FREQ=WEEKLY;BYDAY=XX;COUNT=3"},
+		{"bad until", "FREQ=DAILY;UNTIL=tomorrow"},
+		{"unsupported part", "FREQ=DAILY;COUNT=3;BYHOUR=9"},
+		{"malformed part", "FREQ=DAILY;COUNT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRRule(tt.rule)
+			if !errors.Is(err, ErrInvalidRecurrence) {
+				t.Errorf("expected ErrInvalidRecurrence, got %v", err)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Expand Tests
+// ============================================================================
+
+// expandRule parses and expands a rule for a two-hour occurrence starting at start
+func expandRule(t *testing.T, value string, start time.Time, exdates []time.Time) []Occurrence {
+	t.Helper()
+	rule, err := ParseRRule(value)
+	if err != nil {
+		t.Fatalf("failed to parse rule: %v", err)
+	}
+	occurrences, err := rule.Expand(start, 2*time.Hour, exdates)
+	if err != nil {
+		t.Fatalf("failed to expand rule: %v", err)
+	}
+	return occurrences
+}
+
+func TestExpand_WeeklyByDay(t *testing.T) {
+	// testDay is a Tuesday
+	start := testDay().Add(9 * time.Hour)
+	occurrences := expandRule(t, "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4", start, nil)
+
+	expected := []time.Time{start, start.AddDate(0, 0, 2), start.AddDate(0, 0, 7), start.AddDate(0, 0, 9)}
+	if len(occurrences) != len(expected) {
+		t.Fatalf("expected %d occurrences, got %d", len(expected), len(occurrences))
+	}
+	for i, occurrence := range occurrences {
+		if !occurrence.StartTime.Equal(expected[i]) {
+			t.Errorf("occurrence %d: expected %v, got %v", i, expected[i], occurrence.StartTime)
+		}
+		if occurrence.EndTime.Sub(occurrence.StartTime) != 2*time.Hour {
+			t.Errorf("occurrence %d: expected 2h duration", i)
+		}
+	}
+}
+
+func TestExpand_UntilIsInclusive(t *testing.T) {
+	start := testDay().Add(9 * time.Hour)
+	occurrences := expandRule(t, "FREQ=DAILY;UNTIL=20260313", start, nil)
+
+	if len(occurrences) != 4 {
+		t.Errorf("expected 4 occurrences through the UNTIL date, got %d", len(occurrences))
+	}
+}
+
+func TestExpand_ExDatesCountTowardsCount(t *testing.T) {
+	start := testDay().Add(9 * time.Hour)
+	occurrences := expandRule(t, "FREQ=DAILY;COUNT=3", start, []time.Time{start.AddDate(0, 0, 1)})
+
+	if len(occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences, got %d", len(occurrences))
+	}
+	if !occurrences[1].StartTime.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("expected the excluded day to be skipped, got %v", occurrences[1].StartTime)
+	}
+}
+
+func TestExpand_MonthlySkipsShortMonths(t *testing.T) {
+	start := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	occurrences := expandRule(t, "FREQ=MONTHLY;COUNT=3", start, nil)
+
+	expected := []time.Month{time.January, time.March, time.May}
+	if len(occurrences) != len(expected) {
+		t.Fatalf("expected %d occurrences, got %d", len(expected), len(occurrences))
+	}
+	for i, occurrence := range occurrences {
+		if occurrence.StartTime.Month() != expected[i] || occurrence.StartTime.Day() != 31 {
+			t.Errorf("occurrence %d: expected %s 31, got %v", i, expected[i], occurrence.StartTime)
+		}
+	}
+}
+
+func TestExpand_TooManyOccurrences(t *testing.T) {
+	rule, err := ParseRRule("FREQ=DAILY;COUNT=1000")
+	if err != nil {
+		t.Fatalf("failed to parse rule: %v", err)
+	}
+
+	_, err = rule.Expand(testDay().Add(9*time.Hour), time.Hour, nil)
+	if !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("expected ErrTooManyOccurrences, got %v", err)
+	}
+}
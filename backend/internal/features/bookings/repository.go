@@ -2,12 +2,14 @@ package bookings
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,10 +20,78 @@ var (
 	ErrBookingConflict = errors.New("booking conflicts with an existing reservation")
 	// ErrDeskNotAvailable is returned when the desk is not available for booking
 	ErrDeskNotAvailable = errors.New("desk is not available for the requested time slot")
+	// ErrDeskNotFound is returned when a desk is not found
+	ErrDeskNotFound = errors.New("desk not found")
+	// ErrSettingsNotFound is returned when the settings row is missing
+	ErrSettingsNotFound = errors.New("settings not found")
+	// ErrWaitlistEntryNotFound is returned when a waitlist entry is not found
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	// ErrWaitlistEntryClosed is returned when leaving a waitlist entry that is no longer waiting
+	ErrWaitlistEntryClosed = errors.New("waitlist entry is no longer waiting")
+	// ErrTransferNotFound is returned when a booking transfer is not found
+	ErrTransferNotFound = errors.New("transfer not found")
+	// ErrHoldExpired is returned when confirming a hold after it has expired
+	ErrHoldExpired = errors.New("hold has expired")
+	// ErrTransferClosed is returned when answering a transfer that is no longer pending
+	ErrTransferClosed = errors.New("transfer is no longer pending")
+	// ErrTransferPending is returned when offering a booking that already has a pending transfer
+	ErrTransferPending = errors.New("booking already has a pending transfer")
+	// ErrTransferRecipientNotFound is returned when offering a booking to a user that does not exist
+	ErrTransferRecipientNotFound = errors.New("transfer recipient not found")
+	// ErrMaintenanceNotFound is returned when a maintenance block is not found
+	ErrMaintenanceNotFound = errors.New("maintenance block not found")
+	// ErrClosureNotFound is returned when a closure is not found
+	ErrClosureNotFound = errors.New("closure not found")
+	// ErrOpeningHoursNotFound is returned when an opening schedule entry is not found
+	ErrOpeningHoursNotFound = errors.New("opening hours not found")
+	// ErrQuotaNotFound is returned when a booking quota is not found
+	ErrQuotaNotFound = errors.New("quota not found")
+	// ErrUserNotFound is returned when a booking's owner does not exist
+	ErrUserNotFound = errors.New("user not found")
 )
 
-// PostgreSQL error code for exclusion_violation
-const pgExclusionViolation = "23P01"
+// Notification types written by the bookings feature
+const (
+	notificationWaitlistPromoted  = "waitlist_promoted"
+	notificationTransferOffered   = "transfer_offered"
+	notificationTransferAccepted  = "transfer_accepted"
+	notificationTransferDeclined  = "transfer_declined"
+	notificationTransferCancelled = "transfer_cancelled"
+	notificationBookingCancelled  = "booking_cancelled"
+	notificationBookingMoved      = "booking_moved"
+)
+
+// PostgreSQL error codes mapped to domain errors
+const (
+	pgExclusionViolation  = "23P01"
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// bookingColumns lists the booking columns in the order expected by scanBooking
+const bookingColumns = `id, desk_id, user_id, series_id, group_id, start_time, end_time, status,
+		checked_in_at, actual_end_time, actual_duration_minutes, buffer_minutes, hold_expires_at, cancelled_at,
+		created_at, updated_at`
+
+// maintenanceColumns lists the maintenance block columns in the order expected by scanMaintenanceBlock
+const maintenanceColumns = `id, desk_id, start_time, end_time, reason, created_by, created_at`
+
+// closureColumns lists the closure columns in the order expected by scanClosure
+const closureColumns = `id, wing, start_time, end_time, reason, created_by, created_at`
+
+// openingHoursColumns lists the opening schedule columns in the order expected by scanOpeningHours
+const openingHoursColumns = `id, wing, weekday, opens_at, closes_at, updated_at`
+
+// quotaColumns lists the booking quota columns in the order expected by scanQuota
+const quotaColumns = `id, role, period, max_hours, max_bookings, updated_at`
+
+// waitlistColumns lists the waitlist entry columns in the order expected by scanWaitlistEntry
+const waitlistColumns = `id, user_id, desk_id, wing, start_time, end_time, status,
+		booking_id, fulfilled_at, created_at, updated_at`
+
+// transferColumns lists the booking transfer columns in the order expected by scanTransfer
+const transferColumns = `id, booking_id, from_user_id, to_user_id, swap_booking_id, status,
+		responded_at, created_at, updated_at`
 
 // Repository provides database operations for booking-related entities
 type Repository struct {
@@ -33,34 +103,33 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 	return &Repository{db: db}
 }
 
-// CreateBooking inserts a new booking into the database
+// querier is satisfied by both the pool and a transaction, so that reads shared with
+// transactional writes can run inside the caller's transaction
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// CreateBooking inserts a new booking into the database. Holds get their expiry from
+// settings.hold_ttl_minutes, measured on the database clock like the hold sweeper.
 func (r *Repository) CreateBooking(ctx context.Context, input *CreateBookingInput) (*Booking, error) {
 	query := `
-		INSERT INTO bookings (desk_id, user_id, start_time, end_time)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, desk_id, user_id, start_time, end_time, status,
-		          checked_in_at, actual_end_time, cancelled_at, created_at, updated_at
+		INSERT INTO bookings (desk_id, user_id, start_time, end_time, status, hold_expires_at)
+		SELECT $1, $2, $3, $4,
+		       CASE WHEN $5 THEN 'held' ELSE 'confirmed' END::booking_status,
+		       CASE WHEN $5 THEN NOW() + make_interval(mins => hold_ttl_minutes) END
+		FROM settings
+		WHERE id = 1
+		RETURNING ` + bookingColumns + `
 	`
 
-	var booking Booking
-	err := r.db.QueryRow(ctx, query,
+	booking, err := scanBooking(r.db.QueryRow(ctx, query,
 		input.DeskID,
 		input.UserID,
 		input.StartTime,
 		input.EndTime,
-	).Scan(
-		&booking.ID,
-		&booking.DeskID,
-		&booking.UserID,
-		&booking.StartTime,
-		&booking.EndTime,
-		&booking.Status,
-		&booking.CheckedInAt,
-		&booking.ActualEndTime,
-		&booking.CancelledAt,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
+		input.Hold,
+	))
 
 	if err != nil {
 		// Check for exclusion constraint violation (overlapping booking)
@@ -68,35 +137,24 @@ func (r *Repository) CreateBooking(ctx context.Context, input *CreateBookingInpu
 		if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
 			return nil, ErrBookingConflict
 		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSettingsNotFound
+		}
 		return nil, fmt.Errorf("failed to create booking: %w", err)
 	}
 
-	return &booking, nil
+	return booking, nil
 }
 
 // GetBookingByID retrieves a booking by its ID
 func (r *Repository) GetBookingByID(ctx context.Context, id int) (*Booking, error) {
 	query := `
-		SELECT id, desk_id, user_id, start_time, end_time, status,
-		       checked_in_at, actual_end_time, cancelled_at, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings
 		WHERE id = $1
 	`
 
-	var booking Booking
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&booking.ID,
-		&booking.DeskID,
-		&booking.UserID,
-		&booking.StartTime,
-		&booking.EndTime,
-		&booking.Status,
-		&booking.CheckedInAt,
-		&booking.ActualEndTime,
-		&booking.CancelledAt,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
+	booking, err := scanBooking(r.db.QueryRow(ctx, query, id))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -105,14 +163,16 @@ func (r *Repository) GetBookingByID(ctx context.Context, id int) (*Booking, erro
 		return nil, fmt.Errorf("failed to get booking: %w", err)
 	}
 
-	return &booking, nil
+	return booking, nil
 }
 
-// GetUserBookings retrieves bookings for a user with optional filters
-func (r *Repository) GetUserBookings(ctx context.Context, filter *BookingFilter) ([]*Booking, error) {
+// GetUserBookings retrieves a page of bookings matching the filter, ordered by (start_time, id).
+// With a cursor the page continues after it, or ends before it when the cursor points backwards;
+// such pages are read in reverse and flipped back. One extra row is fetched to tell whether
+// another page follows in the reading direction.
+func (r *Repository) GetUserBookings(ctx context.Context, filter *BookingFilter) (*BookingPage, error) {
 	query := `
-		SELECT id, desk_id, user_id, start_time, end_time, status,
-		       checked_in_at, actual_end_time, cancelled_at, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings
 		WHERE 1=1
 	`
@@ -149,11 +209,28 @@ func (r *Repository) GetUserBookings(ctx context.Context, filter *BookingFilter)
 		argNum++
 	}
 
-	query += " ORDER BY start_time DESC"
+	backwards := filter.Cursor != nil && filter.Cursor.Before
+	descending := (filter.Sort != SortAsc) != backwards
+
+	if filter.Cursor != nil {
+		operator := ">"
+		if descending {
+			operator = "<"
+		}
+		query += fmt.Sprintf(" AND (start_time, id) %s ($%d, $%d)", operator, argNum, argNum+1)
+		args = append(args, filter.Cursor.StartTime, filter.Cursor.ID)
+		argNum += 2
+	}
+
+	if descending {
+		query += " ORDER BY start_time DESC, id DESC"
+	} else {
+		query += " ORDER BY start_time ASC, id ASC"
+	}
 
 	if filter.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT $%d", argNum)
-		args = append(args, filter.Limit)
+		args = append(args, filter.Limit+1)
 		argNum++
 	}
 
@@ -168,41 +245,93 @@ func (r *Repository) GetUserBookings(ctx context.Context, filter *BookingFilter)
 	}
 	defer rows.Close()
 
-	var bookings []*Booking
-	for rows.Next() {
-		var booking Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.DeskID,
-			&booking.UserID,
-			&booking.StartTime,
-			&booking.EndTime,
-			&booking.Status,
-			&booking.CheckedInAt,
-			&booking.ActualEndTime,
-			&booking.CancelledAt,
-			&booking.CreatedAt,
-			&booking.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan booking: %w", err)
+	bookings, err := scanBookings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	more := filter.Limit > 0 && len(bookings) > filter.Limit
+	if more {
+		bookings = bookings[:filter.Limit]
+	}
+	if backwards {
+		for i, j := 0, len(bookings)-1; i < j; i, j = i+1, j-1 {
+			bookings[i], bookings[j] = bookings[j], bookings[i]
 		}
-		bookings = append(bookings, &booking)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	page := &BookingPage{Bookings: bookings}
+	if len(bookings) == 0 {
+		// Nothing lies beyond the cursor, but the rows it came from are still there
+		if filter.Cursor != nil {
+			turned := *filter.Cursor
+			turned.Before = !turned.Before
+			if backwards {
+				page.Next = &turned
+			} else {
+				page.Prev = &turned
+			}
+		}
+		return page, nil
 	}
 
-	return bookings, nil
+	first, last := bookings[0], bookings[len(bookings)-1]
+	if more || backwards {
+		page.Next = cursorAfter(last)
+	}
+	if (more && backwards) || (filter.Cursor != nil && !backwards) {
+		page.Prev = cursorBefore(first)
+	}
+
+	return page, nil
 }
 
-// UpdateBooking updates an existing booking's fields
+// UpdateBooking updates an existing booking's fields.
+// A status change must be a valid transition from the current status; the row is locked, the
+// update is conditional on that status and the transition is recorded in audit_logs, all in
+// one transaction. When the time changes, the part of the old range the booking no longer
+// covers is offered to the waitlist in the same transaction.
 func (r *Repository) UpdateBooking(ctx context.Context, id int, input *UpdateBookingInput) (*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var previous *Booking
+	if input.StartTime != nil || input.EndTime != nil {
+		previous = &Booking{}
+		err = tx.QueryRow(ctx, `
+			SELECT desk_id, start_time, end_time
+			FROM bookings
+			WHERE id = $1
+			FOR UPDATE
+		`, id).Scan(&previous.DeskID, &previous.StartTime, &previous.EndTime)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrBookingNotFound
+			}
+			return nil, fmt.Errorf("failed to lock booking: %w", err)
+		}
+	}
+
 	query := `UPDATE bookings SET updated_at = NOW()`
 	args := []interface{}{}
 	argNum := 1
 
+	var fromStatus BookingStatus
+	if input.Status != nil {
+		fromStatus, err = lockBookingStatus(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if fromStatus != *input.Status {
+			if err := checkTransition(fromStatus, *input.Status); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if input.StartTime != nil {
 		query += fmt.Sprintf(", start_time = $%d", argNum)
 		args = append(args, *input.StartTime)
@@ -239,27 +368,20 @@ func (r *Repository) UpdateBooking(ctx context.Context, id int, input *UpdateBoo
 		argNum++
 	}
 
-	query += fmt.Sprintf(`
-		WHERE id = $%d
-		RETURNING id, desk_id, user_id, start_time, end_time, status,
-		          checked_in_at, actual_end_time, cancelled_at, created_at, updated_at
-	`, argNum)
+	query += fmt.Sprintf(" WHERE id = $%d", argNum)
 	args = append(args, id)
+	argNum++
 
-	var booking Booking
-	err := r.db.QueryRow(ctx, query, args...).Scan(
-		&booking.ID,
-		&booking.DeskID,
-		&booking.UserID,
-		&booking.StartTime,
-		&booking.EndTime,
-		&booking.Status,
-		&booking.CheckedInAt,
-		&booking.ActualEndTime,
-		&booking.CancelledAt,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
+	if input.Status != nil {
+		query += fmt.Sprintf(" AND status = $%d", argNum)
+		args = append(args, fromStatus)
+	}
+
+	query += `
+		RETURNING ` + bookingColumns + `
+	`
+
+	booking, err := scanBooking(tx.QueryRow(ctx, query, args...))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -273,146 +395,2508 @@ func (r *Repository) UpdateBooking(ctx context.Context, id int, input *UpdateBoo
 		return nil, fmt.Errorf("failed to update booking: %w", err)
 	}
 
-	return &booking, nil
+	if input.Status != nil && fromStatus != *input.Status {
+		change := StatusChange{ActorID: input.ChangedBy, Reason: input.Reason}
+		if err := recordTransition(ctx, tx, id, fromStatus, *input.Status, change); err != nil {
+			return nil, err
+		}
+	}
+
+	if previous != nil {
+		freed := freeIntervals(
+			Interval{StartTime: previous.StartTime, EndTime: previous.EndTime},
+			[]Interval{{StartTime: booking.StartTime, EndTime: booking.EndTime}},
+		)
+		for _, interval := range freed {
+			if err := promoteWaitlist(ctx, tx, previous.DeskID, interval.StartTime, interval.EndTime); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit booking update: %w", err)
+	}
+
+	return booking, nil
 }
 
-// DeleteBooking performs a soft delete by setting status to 'cancelled'
-func (r *Repository) DeleteBooking(ctx context.Context, id int) error {
-	now := time.Now()
+// DeleteBooking performs a soft delete by setting status to 'cancelled'.
+// Cancelling an already cancelled booking is a no-op; completed and no-show bookings
+// return ErrInvalidStatusTransition. The freed slot is offered to the waitlist in the
+// same transaction.
+func (r *Repository) DeleteBooking(ctx context.Context, id int, change StatusChange) error {
 	query := `
 		UPDATE bookings
-		SET status = 'cancelled', cancelled_at = $1, updated_at = NOW()
-		WHERE id = $2 AND status NOT IN ('cancelled', 'completed', 'no_show')
+		SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $2
+		RETURNING desk_id, start_time, end_time
 	`
 
-	result, err := r.db.Exec(ctx, query, now, id)
-	if err != nil {
+	if err := r.releaseBooking(ctx, id, StatusCancelled, change, query); err != nil {
+		if errors.Is(err, ErrBookingNotFound) || errors.Is(err, ErrInvalidStatusTransition) {
+			return err
+		}
 		return fmt.Errorf("failed to cancel booking: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		// Check if booking exists
-		var exists bool
-		checkQuery := `SELECT EXISTS(SELECT 1 FROM bookings WHERE id = $1)`
-		if err := r.db.QueryRow(ctx, checkQuery, id).Scan(&exists); err != nil {
-			return fmt.Errorf("failed to check booking existence: %w", err)
-		}
-		if !exists {
-			return ErrBookingNotFound
+	return nil
+}
+
+// ConfirmHold turns an unexpired hold into a confirmed booking and records the transition.
+// A hold whose expiry has passed returns ErrHoldExpired even before the sweeper releases it.
+func (r *Repository) ConfirmHold(ctx context.Context, id int, change StatusChange) (*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	from, err := lockBookingStatus(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransition(from, StatusConfirmed); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE bookings
+		SET status = 'confirmed', hold_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'held' AND hold_expires_at > NOW()
+		RETURNING ` + bookingColumns + `
+	`
+
+	booking, err := scanBooking(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHoldExpired
 		}
-		// Booking exists but couldn't be cancelled (already cancelled/completed/no_show)
-		return nil
+		return nil, fmt.Errorf("failed to confirm hold: %w", err)
 	}
 
-	return nil
+	if err := recordTransition(ctx, tx, id, from, StatusConfirmed, change); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit hold confirmation: %w", err)
+	}
+
+	return booking, nil
 }
 
-// IsDeskAvailable checks if a desk is available for the specified time range
-func (r *Repository) IsDeskAvailable(ctx context.Context, check *DeskAvailabilityCheck) (bool, error) {
-	// Use the time_range column and GIST index for efficient overlap detection
-	// Only check against active bookings (not cancelled or no_show)
+// ExpireHolds cancels up to limit holds whose expiry has passed, records each transition and
+// offers the freed slots to the waitlist. Rows are claimed with SKIP LOCKED so concurrent
+// sweepers never release the same hold twice.
+func (r *Repository) ExpireHolds(ctx context.Context, limit int, change StatusChange) ([]*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	query := `
-		SELECT NOT EXISTS (
-			SELECT 1 FROM bookings
-			WHERE desk_id = $1
-			  AND status NOT IN ('cancelled', 'no_show')
-			  AND time_range && tstzrange($2, $3)
+		UPDATE bookings
+		SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM bookings
+			WHERE status = 'held'
+			  AND hold_expires_at <= NOW()
+			ORDER BY hold_expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + bookingColumns + `
 	`
-	args := []interface{}{check.DeskID, check.StartTime, check.EndTime}
-	argNum := 4
 
-	// Exclude a specific booking ID (useful for update operations)
-	if check.ExcludeBookingID != nil {
-		query += fmt.Sprintf(" AND id != $%d", argNum)
-		args = append(args, *check.ExcludeBookingID)
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire holds: %w", err)
+	}
+	expired, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
 	}
 
-	query += ")"
+	for _, booking := range expired {
+		if err := recordTransition(ctx, tx, booking.ID, StatusHeld, StatusCancelled, change); err != nil {
+			return nil, err
+		}
+		if err := promoteWaitlist(ctx, tx, booking.DeskID, booking.StartTime, booking.EndTime); err != nil {
+			return nil, err
+		}
+	}
 
-	var available bool
-	err := r.db.QueryRow(ctx, query, args...).Scan(&available)
-	if err != nil {
-		return false, fmt.Errorf("failed to check desk availability: %w", err)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit expired holds: %w", err)
 	}
 
-	return available, nil
+	return expired, nil
 }
 
-// GetUserDailyHours calculates total booked hours for a user on a specific day
-// Only counts confirmed and completed bookings (excludes cancelled and no_show)
-func (r *Repository) GetUserDailyHours(ctx context.Context, userID string, date time.Time) (float64, error) {
-	// Calculate the start and end of the day in the same timezone as the date
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+// MarkNoShow marks a confirmed booking as 'no_show'.
+// The freed slot is offered to the waitlist in the same transaction.
+func (r *Repository) MarkNoShow(ctx context.Context, id int, change StatusChange) error {
+	query := `
+		UPDATE bookings
+		SET status = 'no_show', updated_at = NOW()
+		WHERE id = $1 AND status = $2
+		RETURNING desk_id, start_time, end_time
+	`
+
+	if err := r.releaseBooking(ctx, id, StatusNoShow, change, query); err != nil {
+		if errors.Is(err, ErrBookingNotFound) || errors.Is(err, ErrInvalidStatusTransition) {
+			return err
+		}
+		return fmt.Errorf("failed to mark booking as no-show: %w", err)
+	}
+
+	return nil
+}
+
+// MarkNoShows marks up to limit confirmed bookings as 'no_show' when nobody checked in before
+// start_time plus the grace period, records each transition and promotes the waitlist into each
+// freed slot, all in one transaction. Rows are claimed with FOR UPDATE SKIP LOCKED so several replicas can sweep
+// concurrently without processing the same booking twice.
+func (r *Repository) MarkNoShows(ctx context.Context, gracePeriodMinutes, limit int, change StatusChange) ([]*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
 	query := `
-		SELECT COALESCE(
-			SUM(
-				EXTRACT(EPOCH FROM (
-					LEAST(end_time, $3) - GREATEST(start_time, $2)
-				)) / 3600.0
-			),
-			0
+		UPDATE bookings
+		SET status = 'no_show', updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM bookings
+			WHERE status = 'confirmed'
+			  AND checked_in_at IS NULL
+			  AND start_time + make_interval(mins => $1) < NOW()
+			ORDER BY start_time
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
 		)
-		FROM bookings
-		WHERE user_id = $1
-		  AND status IN ('confirmed', 'completed')
-		  AND start_time < $3
-		  AND end_time > $2
+		RETURNING ` + bookingColumns + `
+	`
+
+	rows, err := tx.Query(ctx, query, gracePeriodMinutes, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark no-shows: %w", err)
+	}
+	marked, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range marked {
+		if err := recordTransition(ctx, tx, booking.ID, StatusConfirmed, StatusNoShow, change); err != nil {
+			return nil, err
+		}
+		if err := promoteWaitlist(ctx, tx, booking.DeskID, booking.StartTime, booking.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit no-shows: %w", err)
+	}
+
+	return marked, nil
+}
+
+// CheckInBooking records the check-in time of a confirmed booking that is not checked in yet.
+// The conditional update keeps check-in and the no-show sweeper from both winning a race.
+func (r *Repository) CheckInBooking(ctx context.Context, id int, at time.Time) (*Booking, error) {
+	query := `
+		UPDATE bookings
+		SET checked_in_at = $1, updated_at = NOW()
+		WHERE id = $2
+		  AND status = 'confirmed'
+		  AND checked_in_at IS NULL
+		RETURNING ` + bookingColumns + `
 	`
 
-	var totalHours float64
-	err := r.db.QueryRow(ctx, query, userID, startOfDay, endOfDay).Scan(&totalHours)
+	booking, err := scanBooking(r.db.QueryRow(ctx, query, at, id))
 	if err != nil {
-		return 0, fmt.Errorf("failed to calculate daily hours: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotActive
+		}
+		return nil, fmt.Errorf("failed to check in booking: %w", err)
 	}
 
-	return totalHours, nil
+	return booking, nil
 }
 
-// GetBookingsByTimeRange retrieves all bookings that overlap with the given time range
-func (r *Repository) GetBookingsByTimeRange(ctx context.Context, deskID int, startTime, endTime time.Time) ([]*Booking, error) {
+// CheckOutBooking completes a checked-in booking at actualEnd and records the actual duration.
+// time_range ends at actual_end_time, so the rest of the booked window is freed and offered to
+// the waitlist in the same transaction.
+func (r *Repository) CheckOutBooking(ctx context.Context, id int, actualEnd time.Time, durationMinutes int, change StatusChange) (*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	query := `
-		SELECT id, desk_id, user_id, start_time, end_time, status,
-		       checked_in_at, actual_end_time, cancelled_at, created_at, updated_at
-		FROM bookings
-		WHERE desk_id = $1
-		  AND status NOT IN ('cancelled', 'no_show')
-		  AND time_range && tstzrange($2, $3)
-		ORDER BY start_time
+		UPDATE bookings
+		SET status = 'completed', actual_end_time = $1, actual_duration_minutes = $2, updated_at = NOW()
+		WHERE id = $3
+		  AND status = 'confirmed'
+		  AND checked_in_at IS NOT NULL
+		RETURNING ` + bookingColumns + `
 	`
 
-	rows, err := r.db.Query(ctx, query, deskID, startTime, endTime)
+	booking, err := scanBooking(tx.QueryRow(ctx, query, actualEnd, durationMinutes, id))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query bookings by time range: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotActive
+		}
+		return nil, fmt.Errorf("failed to check out booking: %w", err)
 	}
-	defer rows.Close()
 
-	var bookings []*Booking
-	for rows.Next() {
-		var booking Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.DeskID,
-			&booking.UserID,
-			&booking.StartTime,
-			&booking.EndTime,
-			&booking.Status,
-			&booking.CheckedInAt,
-			&booking.ActualEndTime,
-			&booking.CancelledAt,
-			&booking.CreatedAt,
-			&booking.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan booking: %w", err)
+	if err := recordTransition(ctx, tx, id, StatusConfirmed, StatusCompleted, change); err != nil {
+		return nil, err
+	}
+
+	if actualEnd.Before(booking.EndTime) {
+		if err := promoteWaitlist(ctx, tx, booking.DeskID, actualEnd, booking.EndTime); err != nil {
+			return nil, err
 		}
-		bookings = append(bookings, &booking)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit check-out: %w", err)
 	}
 
-	return bookings, nil
+	return booking, nil
+}
+
+// releaseBooking moves a booking to a status that takes it out of the no_overlapping_bookings
+// constraint. The row is locked and the transition validated, then query (taking the booking ID
+// and the current status, returning desk_id, start_time, end_time) is run, the transition is
+// recorded and the waitlist is promoted into the freed slot, all in one transaction.
+// Re-applying the current status is a no-op.
+func (r *Repository) releaseBooking(ctx context.Context, id int, to BookingStatus, change StatusChange, query string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	from, err := lockBookingStatus(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+	if err := checkTransition(from, to); err != nil {
+		return err
+	}
+
+	var deskID int
+	var startTime, endTime time.Time
+	if err := tx.QueryRow(ctx, query, id, from).Scan(&deskID, &startTime, &endTime); err != nil {
+		return err
+	}
+
+	if err := recordTransition(ctx, tx, id, from, to, change); err != nil {
+		return err
+	}
+
+	if err := promoteWaitlist(ctx, tx, deskID, startTime, endTime); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// MoveBooking moves a confirmed booking to another desk and/or window in one transaction. The
+// target desk must exist and be available; an overlap returns ErrBookingConflict and leaves the
+// booking untouched. The move is recorded in audit_logs and the freed slot is offered to the waitlist.
+func (r *Repository) MoveBooking(ctx context.Context, id int, move BookingMove) (*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var previous Booking
+	err = tx.QueryRow(ctx, `
+		SELECT desk_id, start_time, end_time, status
+		FROM bookings
+		WHERE id = $1
+		FOR UPDATE
+	`, id).Scan(&previous.DeskID, &previous.StartTime, &previous.EndTime, &previous.Status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to lock booking: %w", err)
+	}
+	if previous.Status != StatusConfirmed {
+		return nil, ErrBookingNotActive
+	}
+
+	var deskStatus string
+	err = tx.QueryRow(ctx, `SELECT status FROM desks WHERE id = $1`, move.DeskID).Scan(&deskStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeskNotFound
+		}
+		return nil, fmt.Errorf("failed to get desk: %w", err)
+	}
+	if deskStatus != deskStatusAvailable {
+		return nil, ErrDeskNotAvailable
+	}
+
+	query := `
+		UPDATE bookings
+		SET desk_id = $2, start_time = $3, end_time = $4, updated_at = NOW()
+		WHERE id = $1 AND status = 'confirmed'
+		RETURNING ` + bookingColumns + `
+	`
+
+	booking, err := scanBooking(tx.QueryRow(ctx, query, id, move.DeskID, move.StartTime, move.EndTime))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+			return nil, ErrBookingConflict
+		}
+		return nil, fmt.Errorf("failed to move booking: %w", err)
+	}
+
+	if err := recordMove(ctx, tx, id, &previous, move); err != nil {
+		return nil, err
+	}
+
+	if err := promoteWaitlist(ctx, tx, previous.DeskID, previous.StartTime, previous.EndTime); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit booking move: %w", err)
+	}
+
+	return booking, nil
+}
+
+// recordMove writes an audit_logs entry listing the fields a move changed
+func recordMove(ctx context.Context, tx pgx.Tx, bookingID int, previous *Booking, move BookingMove) error {
+	changed := map[string]interface{}{}
+	if previous.DeskID != move.DeskID {
+		changed["desk_id"] = map[string]int{"from": previous.DeskID, "to": move.DeskID}
+	}
+	if !previous.StartTime.Equal(move.StartTime) {
+		changed["start_time"] = map[string]time.Time{"from": previous.StartTime, "to": move.StartTime}
+	}
+	if !previous.EndTime.Equal(move.EndTime) {
+		changed["end_time"] = map[string]time.Time{"from": previous.EndTime, "to": move.EndTime}
+	}
+
+	changes, err := json.Marshal(changed)
+	if err != nil {
+		return fmt.Errorf("failed to encode move: %w", err)
+	}
+
+	meta := map[string]interface{}{}
+	if move.Reason != "" {
+		meta["reason"] = move.Reason
+	}
+	if move.Forced {
+		meta["forced"] = true
+	}
+	var metadata []byte
+	if len(meta) > 0 {
+		metadata, err = json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("failed to encode move metadata: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO audit_logs (user_id, entity_type, entity_id, action, changes, metadata)
+		VALUES ($1, 'booking', $2, 'move', $3, $4)
+	`
+	if _, err := tx.Exec(ctx, query, move.ActorID, bookingID, changes, metadata); err != nil {
+		return fmt.Errorf("failed to record booking move: %w", err)
+	}
+
+	return nil
+}
+
+// lockBookingStatus locks a booking row for the rest of the transaction and returns its status
+func lockBookingStatus(ctx context.Context, tx pgx.Tx, id int) (BookingStatus, error) {
+	var status BookingStatus
+	err := tx.QueryRow(ctx, `SELECT status FROM bookings WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrBookingNotFound
+		}
+		return "", fmt.Errorf("failed to lock booking: %w", err)
+	}
+	return status, nil
+}
+
+// recordTransition writes an audit_logs entry for a booking status transition
+func recordTransition(ctx context.Context, tx pgx.Tx, bookingID int, from, to BookingStatus, change StatusChange) error {
+	changes, err := json.Marshal(map[string]interface{}{
+		"status": map[string]BookingStatus{"from": from, "to": to},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode transition: %w", err)
+	}
+
+	var metadata []byte
+	if change.Reason != "" {
+		metadata, err = json.Marshal(map[string]string{"reason": change.Reason})
+		if err != nil {
+			return fmt.Errorf("failed to encode transition metadata: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO audit_logs (user_id, entity_type, entity_id, action, changes, metadata)
+		VALUES ($1, 'booking', $2, 'status_transition', $3, $4)
+	`
+	if _, err := tx.Exec(ctx, query, change.ActorID, bookingID, changes, metadata); err != nil {
+		return fmt.Errorf("failed to record status transition: %w", err)
+	}
+
+	return nil
+}
+
+// promoteWaitlist offers a freed desk slot to the waitlist in first-in-first-out order.
+// Waiting entries for the desk, or for any desk in its wing, that overlap the freed range are
+// tried in turn; each one whose full range now fits on the desk gets a confirmed booking, is
+// marked fulfilled and receives a notification. Members who already hold an overlapping
+// booking are skipped, as are entries that overlap a closure of the wing, fall outside its
+// opening hours, start outside the member's booking window or would take them over the daily
+// hour limit or a quota; nothing is promoted onto a desk that is not available. Must be called
+// inside the transaction that freed the slot.
+func promoteWaitlist(ctx context.Context, tx pgx.Tx, deskID int, startTime, endTime time.Time) error {
+	var wing, status string
+	err := tx.QueryRow(ctx, `SELECT wing, status FROM desks WHERE id = $1`, deskID).Scan(&wing, &status)
+	if err != nil {
+		return fmt.Errorf("failed to get desk: %w", err)
+	}
+	if status != deskStatusAvailable {
+		return nil
+	}
+
+	candidatesQuery := `
+		SELECT w.id, w.user_id, w.start_time, w.end_time
+		FROM waitlist_entries w
+		WHERE w.status = 'waiting'
+		  AND w.start_time > NOW()
+		  AND w.start_time < $3
+		  AND w.end_time > $2
+		  AND (w.desk_id = $1 OR w.wing = $4::wing_type)
+		  AND NOT EXISTS (
+			SELECT 1 FROM bookings b
+			WHERE b.user_id = w.user_id
+			  AND b.status IN ('held', 'confirmed')
+			  AND b.time_range && tstzrange(w.start_time, w.end_time)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM closures c
+			WHERE (c.wing IS NULL OR c.wing = $4::wing_type)
+			  AND c.time_range && tstzrange(w.start_time, w.end_time)
+		  )
+		ORDER BY w.created_at, w.id
+		FOR UPDATE OF w SKIP LOCKED
+	`
+
+	type candidate struct {
+		id        int
+		userID    string
+		startTime time.Time
+		endTime   time.Time
+	}
+
+	rows, err := tx.Query(ctx, candidatesQuery, deskID, startTime, endTime, wing)
+	if err != nil {
+		return fmt.Errorf("failed to query waitlist: %w", err)
+	}
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.userID, &c.startTime, &c.endTime); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan waitlist entry: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating waitlist: %w", err)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	settings, err := getSettings(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	bookingQuery := `
+		INSERT INTO bookings (desk_id, user_id, start_time, end_time)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	fulfilQuery := `
+		UPDATE waitlist_entries
+		SET status = 'fulfilled', booking_id = $1, fulfilled_at = NOW(), updated_at = NOW()
+		WHERE id = $2
+	`
+	notifyQuery := `
+		INSERT INTO notifications (user_id, type, message)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, c := range candidates {
+		if !withinOpeningHours(c.startTime, c.endTime, &wing, settings) {
+			continue
+		}
+		withinLimits, err := withinBookingLimits(ctx, tx, settings, c.userID, c.startTime, c.endTime)
+		if err != nil {
+			return err
+		}
+		if !withinLimits {
+			continue
+		}
+
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		var bookingID int
+		err = savepoint.QueryRow(ctx, bookingQuery, deskID, c.userID, c.startTime, c.endTime).Scan(&bookingID)
+		if err != nil {
+			_ = savepoint.Rollback(ctx)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+				// The entry's range still overlaps another booking on this desk
+				continue
+			}
+			return fmt.Errorf("failed to create waitlist booking: %w", err)
+		}
+
+		if _, err := savepoint.Exec(ctx, fulfilQuery, bookingID, c.id); err != nil {
+			_ = savepoint.Rollback(ctx)
+			return fmt.Errorf("failed to fulfil waitlist entry: %w", err)
+		}
+
+		message := fmt.Sprintf("A desk became available: booking #%d on desk %d from %s to %s is confirmed",
+			bookingID, deskID, c.startTime.UTC().Format(time.RFC3339), c.endTime.UTC().Format(time.RFC3339))
+		if _, err := savepoint.Exec(ctx, notifyQuery, c.userID, notificationWaitlistPromoted, message); err != nil {
+			_ = savepoint.Rollback(ctx)
+			return fmt.Errorf("failed to create waitlist notification: %w", err)
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// withinBookingLimits reports whether the range starts within the booking window of the user's
+// role and booking it keeps them within the daily hour limit and their role's quotas, counting the
+// bookings visible to the transaction
+func withinBookingLimits(ctx context.Context, tx pgx.Tx, settings *Settings, userID string, startTime, endTime time.Time) (bool, error) {
+	day := startOfDay(startTime, settings.location())
+	usage, err := userUsage(ctx, tx, userID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return false, err
+	}
+	if usage.Hours+endTime.Sub(startTime).Hours() > float64(settings.DailyHourLimit) {
+		return false, nil
+	}
+	if !settings.roleDependent() {
+		return true, nil
+	}
+
+	role, err := userRole(ctx, tx, userID)
+	if err != nil {
+		return false, err
+	}
+	if checkWindow(settings.BookingWindows[role], settings.location(), time.Now(), startTime) != nil {
+		return false, nil
+	}
+	loadUsage := func(ctx context.Context, userID string, startTime, endTime time.Time) (*QuotaUsage, error) {
+		return userUsage(ctx, tx, userID, startTime, endTime)
+	}
+	err = newQuotaTracker(settings, userID, role, loadUsage).add(ctx, startTime, endTime, nil)
+	if errors.Is(err, ErrQuotaExceeded) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// IsDeskAvailable checks if a desk is available for the specified time range.
+// It applies the same rule as the no_overlapping_bookings constraint and the maintenance trigger:
+// the range plus the desk's cleaning buffer must not overlap the blocked range of another active
+// booking or a maintenance block.
+func (r *Repository) IsDeskAvailable(ctx context.Context, check *DeskAvailabilityCheck) (bool, error) {
+	// Use the blocked_range column and GIST index for efficient overlap detection
+	// Only check against active bookings (not cancelled or no_show)
+	query := `
+		SELECT NOT EXISTS (
+			SELECT 1 FROM desk_maintenance_blocks
+			WHERE desk_id = $1
+			  AND time_range && tstzrange($2, $3 + make_interval(mins => desk_cleaning_buffer($1)))
+		) AND NOT EXISTS (
+			SELECT 1 FROM bookings
+			WHERE desk_id = $1
+			  AND status NOT IN ('cancelled', 'no_show')
+			  AND blocked_range && tstzrange($2, $3 + make_interval(mins => desk_cleaning_buffer($1)))
+	`
+	args := []interface{}{check.DeskID, check.StartTime, check.EndTime}
+	argNum := 4
+
+	// Exclude a specific booking ID (useful for update operations)
+	if check.ExcludeBookingID != nil {
+		query += fmt.Sprintf(" AND id != $%d", argNum)
+		args = append(args, *check.ExcludeBookingID)
+	}
+
+	query += ")"
+
+	var available bool
+	err := r.db.QueryRow(ctx, query, args...).Scan(&available)
+	if err != nil {
+		return false, fmt.Errorf("failed to check desk availability: %w", err)
+	}
+
+	return available, nil
+}
+
+// GetUserDailyHours calculates total booked hours for a user on a specific day
+// Only counts confirmed and completed bookings (excludes cancelled and no_show);
+// bookings checked out early only count up to their actual end time
+func (r *Repository) GetUserDailyHours(ctx context.Context, userID string, date time.Time) (float64, error) {
+	// Calculate the start and end of the day in the same timezone as the date; AddDate keeps
+	// days that the clocks change 23 or 25 hours long
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	usage, err := userUsage(ctx, r.db, userID, startOfDay, endOfDay)
+	if err != nil {
+		return 0, err
+	}
+
+	return usage.Hours, nil
+}
+
+// GetBookingsByTimeRange retrieves all bookings that overlap with the given time range
+func (r *Repository) GetBookingsByTimeRange(ctx context.Context, deskID int, startTime, endTime time.Time) ([]*Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE desk_id = $1
+		  AND status NOT IN ('cancelled', 'no_show')
+		  AND time_range && tstzrange($2, $3)
+		ORDER BY start_time
+	`
+
+	rows, err := r.db.Query(ctx, query, deskID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bookings by time range: %w", err)
+	}
+	defer rows.Close()
+
+	return scanBookings(rows)
+}
+
+// GetDeskBookingsByTimeRange is the wing-wide counterpart of GetBookingsByTimeRange: it retrieves
+// every desk in the wing (all wings when wing is nil), whatever its status, together with its active
+// bookings that block the desk during the time range, cleaning buffers included, its maintenance
+// blocks and the closures of its wing in the time range, in a single query.
+func (r *Repository) GetDeskBookingsByTimeRange(ctx context.Context, wing *string, startTime, endTime time.Time) ([]*DeskBookings, error) {
+	query := `
+		SELECT d.id, d.desk_number, d.wing, d.features, d.status, desk_cleaning_buffer(d.id),
+		       COALESCE(jsonb_agg(to_jsonb(b) ORDER BY b.start_time) FILTER (WHERE b.id IS NOT NULL), '[]'),
+		       m.blocks, cl.closures
+		FROM desks d
+		LEFT JOIN LATERAL (
+			SELECT ` + bookingColumns + `
+			FROM bookings
+			WHERE desk_id = d.id
+			  AND status NOT IN ('cancelled', 'no_show')
+			  AND blocked_range && tstzrange($2, $3 + make_interval(mins => desk_cleaning_buffer(d.id)))
+		) b ON TRUE
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(jsonb_agg(to_jsonb(mb) ORDER BY mb.start_time), '[]') AS blocks
+			FROM (
+				SELECT ` + maintenanceColumns + `
+				FROM desk_maintenance_blocks
+				WHERE desk_id = d.id
+				  AND time_range && tstzrange($2, $3)
+			) mb
+		) m
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(jsonb_agg(to_jsonb(c) ORDER BY c.start_time), '[]') AS closures
+			FROM (
+				SELECT ` + closureColumns + `
+				FROM closures
+				WHERE (wing IS NULL OR wing = d.wing)
+				  AND time_range && tstzrange($2, $3)
+			) c
+		) cl
+		WHERE ($1::wing_type IS NULL OR d.wing = $1::wing_type)
+		GROUP BY d.id, m.blocks, cl.closures
+		ORDER BY d.desk_number
+	`
+
+	rows, err := r.db.Query(ctx, query, wing, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query desk bookings by time range: %w", err)
+	}
+	defer rows.Close()
+
+	var desks []*DeskBookings
+	for rows.Next() {
+		var desk DeskBookings
+		var bookings, maintenance, closures []byte
+		err := rows.Scan(
+			&desk.Desk.ID,
+			&desk.Desk.DeskNumber,
+			&desk.Desk.Wing,
+			&desk.Desk.Features,
+			&desk.Desk.Status,
+			&desk.Desk.CleaningBufferMinutes,
+			&bookings,
+			&maintenance,
+			&closures,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan desk bookings: %w", err)
+		}
+		// The JSON tags of Booking, MaintenanceBlock and Closure match the column names, so the
+		// aggregated rows decode directly
+		if err := json.Unmarshal(bookings, &desk.Bookings); err != nil {
+			return nil, fmt.Errorf("failed to decode desk bookings: %w", err)
+		}
+		if err := json.Unmarshal(maintenance, &desk.Maintenance); err != nil {
+			return nil, fmt.Errorf("failed to decode desk maintenance: %w", err)
+		}
+		if err := json.Unmarshal(closures, &desk.Closures); err != nil {
+			return nil, fmt.Errorf("failed to decode desk closures: %w", err)
+		}
+		desks = append(desks, &desk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating desk bookings: %w", err)
+	}
+
+	return desks, nil
+}
+
+// GetDesk retrieves a desk by its ID regardless of its status
+func (r *Repository) GetDesk(ctx context.Context, id int) (*Desk, error) {
+	query := `
+		SELECT id, desk_number, wing, features, status, desk_cleaning_buffer(id)
+		FROM desks
+		WHERE id = $1
+	`
+
+	var desk Desk
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&desk.ID,
+		&desk.DeskNumber,
+		&desk.Wing,
+		&desk.Features,
+		&desk.Status,
+		&desk.CleaningBufferMinutes,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeskNotFound
+		}
+		return nil, fmt.Errorf("failed to get desk: %w", err)
+	}
+
+	return &desk, nil
+}
+
+// GetDeskOccupancy retrieves every available desk matching the filter together with the blocked
+// ranges of its active bookings and its maintenance blocks that could keep a booking in the
+// filter's window off the desk, and the closures of its wing in the window, in a single query. The lookups use the GIST indexes, so the cost
+// does not grow with the number of desks queried.
+func (r *Repository) GetDeskOccupancy(ctx context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error) {
+	query := `
+		SELECT d.id, d.desk_number, d.wing, d.features, d.status, desk_cleaning_buffer(d.id),
+		       COALESCE(array_agg(lower(o.busy) ORDER BY lower(o.busy))
+		                FILTER (WHERE o.busy IS NOT NULL), '{}'),
+		       COALESCE(array_agg(upper(o.busy) ORDER BY lower(o.busy))
+		                FILTER (WHERE o.busy IS NOT NULL), '{}'),
+		       c.starts, c.ends
+		FROM desks d
+		LEFT JOIN LATERAL (
+			SELECT b.blocked_range AS busy
+			FROM bookings b
+			WHERE b.desk_id = d.id
+			  AND b.status NOT IN ('cancelled', 'no_show')
+			  AND b.blocked_range && tstzrange($1, $2 + make_interval(mins => desk_cleaning_buffer(d.id)))
+			UNION ALL
+			SELECT m.time_range
+			FROM desk_maintenance_blocks m
+			WHERE m.desk_id = d.id
+			  AND m.time_range && tstzrange($1, $2 + make_interval(mins => desk_cleaning_buffer(d.id)))
+		) o ON TRUE
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(array_agg(start_time ORDER BY start_time), '{}') AS starts,
+			       COALESCE(array_agg(end_time ORDER BY start_time), '{}') AS ends
+			FROM closures
+			WHERE (wing IS NULL OR wing = d.wing)
+			  AND time_range && tstzrange($1, $2)
+		) c
+		WHERE d.status = 'available'
+		  AND ($3::wing_type IS NULL OR d.wing = $3::wing_type)
+		  AND d.features @> $4::text[]
+		GROUP BY d.id, c.starts, c.ends
+		ORDER BY d.desk_number
+	`
+
+	features := filter.Features
+	if features == nil {
+		features = []string{}
+	}
+
+	rows, err := r.db.Query(ctx, query, filter.StartTime, filter.EndTime, filter.Wing, features)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query desk occupancy: %w", err)
+	}
+	defer rows.Close()
+
+	var occupancy []*DeskOccupancy
+	for rows.Next() {
+		var desk DeskOccupancy
+		var starts, ends, closedStarts, closedEnds []time.Time
+		err := rows.Scan(
+			&desk.Desk.ID,
+			&desk.Desk.DeskNumber,
+			&desk.Desk.Wing,
+			&desk.Desk.Features,
+			&desk.Desk.Status,
+			&desk.Desk.CleaningBufferMinutes,
+			&starts,
+			&ends,
+			&closedStarts,
+			&closedEnds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan desk occupancy: %w", err)
+		}
+		for i := range starts {
+			desk.Busy = append(desk.Busy, Interval{StartTime: starts[i], EndTime: ends[i]})
+		}
+		for i := range closedStarts {
+			desk.Closed = append(desk.Closed, Interval{StartTime: closedStarts[i], EndTime: closedEnds[i]})
+		}
+		occupancy = append(occupancy, &desk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating desk occupancy: %w", err)
+	}
+
+	return occupancy, nil
+}
+
+// CreateSeries inserts a booking series and one booking per occurrence in a single transaction.
+// Every occurrence goes through the no_overlapping_bookings constraint inside its own savepoint so
+// that all conflicts are collected. Unless input.Partial is set, any conflict rolls back the whole
+// series and ErrBookingConflict is returned alongside the conflicting occurrences.
+func (r *Repository) CreateSeries(ctx context.Context, input *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	seriesQuery := `
+		INSERT INTO booking_series (user_id, desk_id, rrule, start_time, end_time, exdates)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, desk_id, rrule, start_time, end_time, exdates, created_at, updated_at
+	`
+
+	exdates := input.ExDates
+	if exdates == nil {
+		exdates = []time.Time{}
+	}
+
+	var series Series
+	err = tx.QueryRow(ctx, seriesQuery,
+		input.UserID,
+		input.DeskID,
+		input.RRule,
+		input.StartTime,
+		input.EndTime,
+		exdates,
+	).Scan(
+		&series.ID,
+		&series.UserID,
+		&series.DeskID,
+		&series.RRule,
+		&series.StartTime,
+		&series.EndTime,
+		&series.ExDates,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create booking series: %w", err)
+	}
+
+	bookingQuery := `
+		INSERT INTO bookings (desk_id, user_id, series_id, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + bookingColumns + `
+	`
+
+	result := &SeriesInsertResult{Series: &series}
+	for _, occurrence := range occurrences {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		booking, err := scanBooking(savepoint.QueryRow(ctx, bookingQuery,
+			input.DeskID,
+			input.UserID,
+			series.ID,
+			occurrence.StartTime,
+			occurrence.EndTime,
+		))
+		if err != nil {
+			_ = savepoint.Rollback(ctx)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+				result.Conflicts = append(result.Conflicts, occurrence)
+				continue
+			}
+			return nil, fmt.Errorf("failed to create series booking: %w", err)
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		result.Bookings = append(result.Bookings, booking)
+	}
+
+	if len(result.Conflicts) > 0 && (!input.Partial || len(result.Bookings) == 0) {
+		return &SeriesInsertResult{Conflicts: result.Conflicts}, ErrBookingConflict
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit booking series: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateGroup books every assignment of a group in one transaction, all or nothing. Each booking
+// is tried in its own savepoint so that every conflicting desk and unknown attendee is reported;
+// if any fail, the whole group is rolled back and ErrBookingConflict is returned with them.
+func (r *Repository) CreateGroup(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	groupQuery := `
+		INSERT INTO booking_groups (organizer_id, start_time, end_time)
+		VALUES ($1, $2, $3)
+		RETURNING id, organizer_id, start_time, end_time, created_at
+	`
+
+	var group BookingGroup
+	err = tx.QueryRow(ctx, groupQuery, input.OrganizerID, input.StartTime, input.EndTime).Scan(
+		&group.ID,
+		&group.OrganizerID,
+		&group.StartTime,
+		&group.EndTime,
+		&group.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create booking group: %w", err)
+	}
+
+	bookingQuery := `
+		INSERT INTO bookings (desk_id, user_id, group_id, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + bookingColumns + `
+	`
+
+	result := &GroupInsertResult{Group: &group}
+	for _, assignment := range assignments {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		booking, err := scanBooking(savepoint.QueryRow(ctx, bookingQuery,
+			assignment.DeskID,
+			assignment.UserID,
+			group.ID,
+			input.StartTime,
+			input.EndTime,
+		))
+		if err != nil {
+			_ = savepoint.Rollback(ctx)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				if pgErr.Code == pgExclusionViolation {
+					result.Conflicts = append(result.Conflicts, assignment)
+					continue
+				}
+				if pgErr.Code == pgForeignKeyViolation && pgErr.ConstraintName == "bookings_user_id_fkey" {
+					result.MissingUsers = append(result.MissingUsers, assignment)
+					continue
+				}
+			}
+			return nil, fmt.Errorf("failed to create group booking: %w", err)
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		result.Bookings = append(result.Bookings, booking)
+	}
+
+	if len(result.Conflicts) > 0 || len(result.MissingUsers) > 0 {
+		return &GroupInsertResult{Conflicts: result.Conflicts, MissingUsers: result.MissingUsers}, ErrBookingConflict
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit booking group: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetSeriesBookings retrieves the confirmed bookings of a series starting at or after from
+func (r *Repository) GetSeriesBookings(ctx context.Context, seriesID int, from time.Time) ([]*Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE series_id = $1
+		  AND status = 'confirmed'
+		  AND start_time >= $2
+		ORDER BY start_time
+	`
+
+	rows, err := r.db.Query(ctx, query, seriesID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series bookings: %w", err)
+	}
+	defer rows.Close()
+
+	return scanBookings(rows)
+}
+
+// RescheduleBookings moves several bookings to new time windows in a single transaction.
+// Only confirmed bookings that have not started yet are changed; if any of them is no longer
+// in that state ErrBookingNotActive is returned. Each update runs in its own savepoint so all
+// exclusion conflicts are collected before the transaction is rolled back with ErrBookingConflict.
+func (r *Repository) RescheduleBookings(ctx context.Context, changes []BookingReschedule) (*RescheduleResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE bookings
+		SET start_time = $1, end_time = $2, updated_at = NOW()
+		WHERE id = $3
+		  AND status = 'confirmed'
+		  AND start_time > NOW()
+		RETURNING ` + bookingColumns + `
+	`
+
+	result := &RescheduleResult{}
+	for _, change := range changes {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		booking, err := scanBooking(savepoint.QueryRow(ctx, query, change.StartTime, change.EndTime, change.BookingID))
+		if err != nil {
+			_ = savepoint.Rollback(ctx)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrBookingNotActive
+			}
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+				result.Conflicts = append(result.Conflicts, Occurrence{StartTime: change.StartTime, EndTime: change.EndTime})
+				continue
+			}
+			return nil, fmt.Errorf("failed to reschedule booking: %w", err)
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		result.Bookings = append(result.Bookings, booking)
+	}
+
+	if len(result.Conflicts) > 0 {
+		return &RescheduleResult{Conflicts: result.Conflicts}, ErrBookingConflict
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit reschedule: %w", err)
+	}
+
+	return result, nil
+}
+
+// CancelSeriesBookings cancels the confirmed bookings of a series starting at or after from.
+// Bookings that have already started are never touched. Each transition is recorded and each
+// freed slot is offered to the waitlist in the same transaction.
+func (r *Repository) CancelSeriesBookings(ctx context.Context, seriesID int, from time.Time, change StatusChange) ([]*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE bookings
+		SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+		WHERE series_id = $1
+		  AND status = 'confirmed'
+		  AND start_time >= $2
+		  AND start_time > NOW()
+		RETURNING ` + bookingColumns + `
+	`
+
+	rows, err := tx.Query(ctx, query, seriesID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel series bookings: %w", err)
+	}
+	cancelled, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range cancelled {
+		if err := recordTransition(ctx, tx, booking.ID, StatusConfirmed, StatusCancelled, change); err != nil {
+			return nil, err
+		}
+		if err := promoteWaitlist(ctx, tx, booking.DeskID, booking.StartTime, booking.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit series cancellation: %w", err)
+	}
+
+	return cancelled, nil
+}
+
+// bulkCancelConditions selects the active bookings on the chosen desks that overlap the window.
+// $1 and $2 bound the window, $3 lists desk IDs and $4 is the wing; either may be NULL.
+const bulkCancelConditions = `
+		status IN ('held', 'confirmed')
+		AND time_range && tstzrange($1, $2)
+		AND ($3::int[] IS NULL OR desk_id = ANY($3::int[]))
+		AND ($4::wing_type IS NULL OR desk_id IN (SELECT id FROM desks WHERE wing = $4::wing_type))
+`
+
+// GetBulkCancelBookings previews the bookings BulkCancelBookings would cancel for the same input
+func (r *Repository) GetBulkCancelBookings(ctx context.Context, input *BulkCancelInput) ([]*Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE ` + bulkCancelConditions + `
+		ORDER BY start_time, id
+	`
+
+	rows, err := r.db.Query(ctx, query, input.StartTime, input.EndTime, bulkCancelDeskIDs(input), input.Wing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bookings to cancel: %w", err)
+	}
+	defer rows.Close()
+
+	return scanBookings(rows)
+}
+
+// BulkCancelBookings cancels every active booking on the chosen desks that overlaps the window
+// in one transaction. Each cancellation gets its own audit_logs entry carrying the reason, and
+// each owner is notified. Freed slots are not offered to the waitlist: the desks are closed.
+func (r *Repository) BulkCancelBookings(ctx context.Context, input *BulkCancelInput, change StatusChange) ([]*Booking, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the rows first so each transition is recorded from the status it actually left
+	lockQuery := `
+		SELECT id, status
+		FROM bookings
+		WHERE ` + bulkCancelConditions + `
+		ORDER BY id
+		FOR UPDATE
+	`
+	rows, err := tx.Query(ctx, lockQuery, input.StartTime, input.EndTime, bulkCancelDeskIDs(input), input.Wing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock bookings to cancel: %w", err)
+	}
+	previous := map[int]BookingStatus{}
+	var ids []int
+	for rows.Next() {
+		var id int
+		var status BookingStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan booking to cancel: %w", err)
+		}
+		previous[id] = status
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bookings to cancel: %w", err)
+	}
+
+	query := `
+		UPDATE bookings
+		SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+		WHERE id = ANY($1)
+		RETURNING ` + bookingColumns + `
+	`
+	rows, err = tx.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel bookings: %w", err)
+	}
+	cancelled, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range cancelled {
+		if err := recordTransition(ctx, tx, booking.ID, previous[booking.ID], StatusCancelled, change); err != nil {
+			return nil, err
+		}
+		message := fmt.Sprintf("Your booking #%d on desk %d from %s to %s was cancelled: %s",
+			booking.ID, booking.DeskID, booking.StartTime.UTC().Format(time.RFC3339),
+			booking.EndTime.UTC().Format(time.RFC3339), change.Reason)
+		if err := notify(ctx, tx, booking.UserID, notificationBookingCancelled, message); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit bulk cancellation: %w", err)
+	}
+
+	return cancelled, nil
+}
+
+// bulkCancelDeskIDs returns the desk IDs to filter on, or nil to match any desk
+func bulkCancelDeskIDs(input *BulkCancelInput) []int {
+	if len(input.DeskIDs) == 0 {
+		return nil
+	}
+	return input.DeskIDs
+}
+
+// CreateMaintenanceBlock blocks a desk for a time range in one transaction and applies the
+// policy to the held and confirmed bookings the block overlaps, cleaning buffers included.
+// With MaintenanceReject any overlap rolls the block back; with MaintenanceCancel the bookings
+// are cancelled; with MaintenanceMove each one is moved to the first free desk in the same wing
+// that has at least the same features, and if any cannot be moved nothing changes. Rolled back
+// blocks return ErrBookingConflict alongside the bookings that caused it. Owners of cancelled
+// and moved bookings are notified; freed slots are not offered to the waitlist.
+func (r *Repository) CreateMaintenanceBlock(ctx context.Context, input *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Locking the desk keeps bookings off it until the block is in place; the maintenance
+	// trigger takes a conflicting lock on the desk before checking for blocks.
+	var wing string
+	var features []string
+	err = tx.QueryRow(ctx, `SELECT wing, features FROM desks WHERE id = $1 FOR UPDATE`, input.DeskID).Scan(&wing, &features)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeskNotFound
+		}
+		return nil, fmt.Errorf("failed to lock desk: %w", err)
+	}
+
+	var createdBy *string
+	if input.CreatedBy != "" {
+		createdBy = &input.CreatedBy
+	}
+	insertQuery := `
+		INSERT INTO desk_maintenance_blocks (desk_id, start_time, end_time, reason, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + maintenanceColumns + `
+	`
+	block, err := scanMaintenanceBlock(tx.QueryRow(ctx, insertQuery,
+		input.DeskID, input.StartTime, input.EndTime, input.Reason, createdBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create maintenance block: %w", err)
+	}
+
+	overlapQuery := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE desk_id = $1
+		  AND status IN ('held', 'confirmed')
+		  AND blocked_range && tstzrange($2, $3)
+		ORDER BY start_time, id
+		FOR UPDATE
+	`
+	rows, err := tx.Query(ctx, overlapQuery, input.DeskID, input.StartTime, input.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overlapping bookings: %w", err)
+	}
+	overlapping, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MaintenanceInsertResult{Block: block}
+	if len(overlapping) == 0 {
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to commit maintenance block: %w", err)
+		}
+		return result, nil
+	}
+
+	change := StatusChange{ActorID: createdBy, Reason: input.Reason}
+	switch input.Policy {
+	case MaintenanceCancel:
+		for _, booking := range overlapping {
+			cancelled, err := scanBooking(tx.QueryRow(ctx, `
+				UPDATE bookings
+				SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+				WHERE id = $1
+				RETURNING `+bookingColumns, booking.ID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to cancel booking: %w", err)
+			}
+			if err := recordTransition(ctx, tx, booking.ID, booking.Status, StatusCancelled, change); err != nil {
+				return nil, err
+			}
+			message := fmt.Sprintf("Your booking #%d on desk %d from %s to %s was cancelled for maintenance: %s",
+				booking.ID, booking.DeskID, booking.StartTime.UTC().Format(time.RFC3339),
+				booking.EndTime.UTC().Format(time.RFC3339), input.Reason)
+			if err := notify(ctx, tx, booking.UserID, notificationBookingCancelled, message); err != nil {
+				return nil, err
+			}
+			result.Cancelled = append(result.Cancelled, cancelled)
+		}
+	case MaintenanceMove:
+		settings, err := getSettings(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		for _, booking := range overlapping {
+			moved, err := moveToEquivalentDesk(ctx, tx, settings, booking, wing, features)
+			if err != nil {
+				return nil, err
+			}
+			if moved == nil {
+				result.Conflicts = append(result.Conflicts, booking)
+				continue
+			}
+			move := BookingMove{
+				DeskID:    moved.DeskID,
+				StartTime: moved.StartTime,
+				EndTime:   moved.EndTime,
+				ActorID:   createdBy,
+				Reason:    input.Reason,
+				Forced:    true,
+			}
+			if err := recordMove(ctx, tx, booking.ID, booking, move); err != nil {
+				return nil, err
+			}
+			message := fmt.Sprintf("Your booking #%d from %s to %s was moved from desk %d to desk %d for maintenance: %s",
+				booking.ID, booking.StartTime.UTC().Format(time.RFC3339), booking.EndTime.UTC().Format(time.RFC3339),
+				booking.DeskID, moved.DeskID, input.Reason)
+			if err := notify(ctx, tx, booking.UserID, notificationBookingMoved, message); err != nil {
+				return nil, err
+			}
+			result.Moved = append(result.Moved, moved)
+		}
+	default:
+		result.Conflicts = overlapping
+	}
+
+	if len(result.Conflicts) > 0 {
+		return &MaintenanceInsertResult{Conflicts: result.Conflicts}, ErrBookingConflict
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit maintenance block: %w", err)
+	}
+
+	return result, nil
+}
+
+// moveToEquivalentDesk moves a booking off a blocked desk onto the first desk, by desk number,
+// in the same wing that is available and has at least the given features. Each candidate is
+// tried in a savepoint so that a conflict only skips that desk. It returns nil when no desk is
+// free, or when the booking overlaps a closure of the wing or falls outside its opening hours.
+func moveToEquivalentDesk(ctx context.Context, tx pgx.Tx, settings *Settings, booking *Booking, wing string, features []string) (*Booking, error) {
+	if !withinOpeningHours(booking.StartTime, booking.EndTime, &wing, settings) {
+		return nil, nil
+	}
+
+	candidatesQuery := `
+		SELECT id
+		FROM desks
+		WHERE wing = $1::wing_type
+		  AND status = 'available'
+		  AND features @> $2::text[]
+		  AND id <> $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM closures c
+			WHERE (c.wing IS NULL OR c.wing = $1::wing_type)
+			  AND c.time_range && tstzrange($4, $5)
+		  )
+		ORDER BY desk_number
+	`
+	if features == nil {
+		features = []string{}
+	}
+	rows, err := tx.Query(ctx, candidatesQuery, wing, features, booking.DeskID, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query replacement desks: %w", err)
+	}
+	var candidates []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan replacement desk: %w", err)
+		}
+		candidates = append(candidates, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating replacement desks: %w", err)
+	}
+
+	query := `
+		UPDATE bookings
+		SET desk_id = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + bookingColumns + `
+	`
+	for _, deskID := range candidates {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		moved, err := scanBooking(savepoint.QueryRow(ctx, query, booking.ID, deskID))
+		if err != nil {
+			_ = savepoint.Rollback(ctx)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+				continue
+			}
+			return nil, fmt.Errorf("failed to move booking: %w", err)
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		return moved, nil
+	}
+
+	return nil, nil
+}
+
+// GetMaintenanceBlocks lists maintenance blocks in chronological order, optionally limited to a
+// desk and to blocks overlapping a time range
+func (r *Repository) GetMaintenanceBlocks(ctx context.Context, filter *MaintenanceFilter) ([]*MaintenanceBlock, error) {
+	query := `
+		SELECT ` + maintenanceColumns + `
+		FROM desk_maintenance_blocks
+		WHERE ($1::int IS NULL OR desk_id = $1)
+		  AND time_range && tstzrange($2, $3)
+		ORDER BY start_time, id
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.DeskID, filter.StartTime, filter.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query maintenance blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []*MaintenanceBlock{}
+	for rows.Next() {
+		block, err := scanMaintenanceBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating maintenance blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// DeleteMaintenanceBlock removes a maintenance block, returning the desk to service for its range
+func (r *Repository) DeleteMaintenanceBlock(ctx context.Context, id int) (*MaintenanceBlock, error) {
+	query := `
+		DELETE FROM desk_maintenance_blocks
+		WHERE id = $1
+		RETURNING ` + maintenanceColumns + `
+	`
+
+	block, err := scanMaintenanceBlock(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMaintenanceNotFound
+		}
+		return nil, fmt.Errorf("failed to delete maintenance block: %w", err)
+	}
+
+	return block, nil
+}
+
+// CreateClosure closes the office, or one wing of it, for a time range
+func (r *Repository) CreateClosure(ctx context.Context, input *CreateClosureInput) (*Closure, error) {
+	query := `
+		INSERT INTO closures (wing, start_time, end_time, reason, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + closureColumns + `
+	`
+
+	var createdBy *string
+	if input.CreatedBy != "" {
+		createdBy = &input.CreatedBy
+	}
+
+	closure, err := scanClosure(r.db.QueryRow(ctx, query, input.Wing, input.StartTime, input.EndTime, input.Reason, createdBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create closure: %w", err)
+	}
+
+	return closure, nil
+}
+
+// GetClosures lists closures in chronological order, optionally limited to those that apply to a
+// wing or to a desk's wing and to those overlapping a time range
+func (r *Repository) GetClosures(ctx context.Context, filter *ClosureFilter) ([]*Closure, error) {
+	query := `
+		SELECT ` + closureColumns + `
+		FROM closures
+		WHERE ($1::wing_type IS NULL OR wing IS NULL OR wing = $1::wing_type)
+		  AND ($2::int IS NULL OR wing IS NULL OR wing = (SELECT wing FROM desks WHERE id = $2))
+		  AND time_range && tstzrange($3, $4)
+		ORDER BY start_time, id
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.Wing, filter.DeskID, filter.StartTime, filter.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query closures: %w", err)
+	}
+	defer rows.Close()
+
+	closures := []*Closure{}
+	for rows.Next() {
+		closure, err := scanClosure(rows)
+		if err != nil {
+			return nil, err
+		}
+		closures = append(closures, closure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating closures: %w", err)
+	}
+
+	return closures, nil
+}
+
+// DeleteClosure removes a closure, reopening its range for bookings
+func (r *Repository) DeleteClosure(ctx context.Context, id int) (*Closure, error) {
+	query := `
+		DELETE FROM closures
+		WHERE id = $1
+		RETURNING ` + closureColumns + `
+	`
+
+	closure, err := scanClosure(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrClosureNotFound
+		}
+		return nil, fmt.Errorf("failed to delete closure: %w", err)
+	}
+
+	return closure, nil
+}
+
+// SetOpeningHours sets a weekday's hours for the whole office or one wing, replacing any entry
+// already set for that weekday
+func (r *Repository) SetOpeningHours(ctx context.Context, input *SetOpeningHoursInput) (*OpeningHours, error) {
+	query := `
+		INSERT INTO opening_hours (wing, weekday, opens_at, closes_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ON CONSTRAINT opening_hours_wing_weekday_key DO UPDATE
+		SET opens_at = EXCLUDED.opens_at, closes_at = EXCLUDED.closes_at, updated_at = NOW()
+		RETURNING ` + openingHoursColumns + `
+	`
+
+	hours, err := scanOpeningHours(r.db.QueryRow(ctx, query,
+		input.Wing,
+		int(input.Weekday),
+		timeOfDayParam(input.Opens),
+		timeOfDayParam(input.Closes),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set opening hours: %w", err)
+	}
+
+	return hours, nil
+}
+
+// DeleteOpeningHours removes an opening schedule entry; its weekday falls back to the office-wide
+// entry or the default opening hours
+func (r *Repository) DeleteOpeningHours(ctx context.Context, id int) (*OpeningHours, error) {
+	query := `
+		DELETE FROM opening_hours
+		WHERE id = $1
+		RETURNING ` + openingHoursColumns + `
+	`
+
+	hours, err := scanOpeningHours(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOpeningHoursNotFound
+		}
+		return nil, fmt.Errorf("failed to delete opening hours: %w", err)
+	}
+
+	return hours, nil
+}
+
+// getSchedule retrieves the weekly opening schedule, office-wide entries before wing overrides
+func getSchedule(ctx context.Context, q querier) ([]*OpeningHours, error) {
+	query := `
+		SELECT ` + openingHoursColumns + `
+		FROM opening_hours
+		ORDER BY weekday, wing NULLS FIRST
+	`
+
+	rows, err := q.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query opening hours: %w", err)
+	}
+	defer rows.Close()
+
+	schedule := []*OpeningHours{}
+	for rows.Next() {
+		hours, err := scanOpeningHours(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedule = append(schedule, hours)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating opening hours: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// SetQuota creates or replaces a role's quota for a period
+func (r *Repository) SetQuota(ctx context.Context, input *SetQuotaInput) (*Quota, error) {
+	query := `
+		INSERT INTO booking_quotas (role, period, max_hours, max_bookings)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ON CONSTRAINT booking_quotas_role_period_key DO UPDATE
+		SET max_hours = EXCLUDED.max_hours, max_bookings = EXCLUDED.max_bookings, updated_at = NOW()
+		RETURNING ` + quotaColumns + `
+	`
+
+	quota, err := scanQuota(r.db.QueryRow(ctx, query,
+		input.Role,
+		input.Period,
+		input.MaxHours,
+		input.MaxBookings,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+// DeleteQuota removes a booking quota, leaving its role uncapped for the period
+func (r *Repository) DeleteQuota(ctx context.Context, id int) (*Quota, error) {
+	query := `
+		DELETE FROM booking_quotas
+		WHERE id = $1
+		RETURNING ` + quotaColumns + `
+	`
+
+	quota, err := scanQuota(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrQuotaNotFound
+		}
+		return nil, fmt.Errorf("failed to delete quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+// getQuotas retrieves the booking quotas of every role, ordered by role and period
+func getQuotas(ctx context.Context, q querier) ([]*Quota, error) {
+	query := `
+		SELECT ` + quotaColumns + `
+		FROM booking_quotas
+		ORDER BY role, period
+	`
+
+	rows, err := q.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quotas: %w", err)
+	}
+	defer rows.Close()
+
+	quotas := []*Quota{}
+	for rows.Next() {
+		quota, err := scanQuota(rows)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, quota)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quotas: %w", err)
+	}
+
+	return quotas, nil
+}
+
+// GetUserRole returns the role of a user
+func (r *Repository) GetUserRole(ctx context.Context, userID string) (string, error) {
+	return userRole(ctx, r.db, userID)
+}
+
+// userRole looks up the role of a user through q
+func userRole(ctx context.Context, q querier, userID string) (string, error) {
+	var role string
+	err := q.QueryRow(ctx, `SELECT role FROM users WHERE id = $1`, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetUserQuotaUsage returns the hours a user has booked within a range and how many of their
+// bookings start in it. Held, confirmed and completed bookings count, as for the daily limit.
+func (r *Repository) GetUserQuotaUsage(ctx context.Context, userID string, startTime, endTime time.Time) (*QuotaUsage, error) {
+	return userUsage(ctx, r.db, userID, startTime, endTime)
+}
+
+// userUsage sums the hours of a user's held, confirmed and completed bookings within a range and
+// counts those that start in it; bookings checked out early only count up to their actual end time
+func userUsage(ctx context.Context, q querier, userID string, startTime, endTime time.Time) (*QuotaUsage, error) {
+	query := `
+		SELECT
+			COALESCE(
+				SUM(
+					EXTRACT(EPOCH FROM (
+						LEAST(COALESCE(actual_end_time, end_time), $3) - GREATEST(start_time, $2)
+					)) / 3600.0
+				),
+				0
+			),
+			COUNT(*) FILTER (WHERE start_time >= $2)
+		FROM bookings
+		WHERE user_id = $1
+		  AND status IN ('held', 'confirmed', 'completed')
+		  AND start_time < $3
+		  AND COALESCE(actual_end_time, end_time) > $2
+	`
+
+	var usage QuotaUsage
+	err := q.QueryRow(ctx, query, userID, startTime, endTime).Scan(&usage.Hours, &usage.Bookings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate booked hours: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// CreateWaitlistEntry adds a member to the waitlist for a desk or a wing
+func (r *Repository) CreateWaitlistEntry(ctx context.Context, input *JoinWaitlistInput) (*WaitlistEntry, error) {
+	query := `
+		INSERT INTO waitlist_entries (user_id, desk_id, wing, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + waitlistColumns + `
+	`
+
+	entry, err := scanWaitlistEntry(r.db.QueryRow(ctx, query,
+		input.UserID,
+		input.DeskID,
+		input.Wing,
+		input.StartTime,
+		input.EndTime,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetWaitlistEntryByID retrieves a waitlist entry by its ID
+func (r *Repository) GetWaitlistEntryByID(ctx context.Context, id int) (*WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE id = $1
+	`
+
+	entry, err := scanWaitlistEntry(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWaitlistEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetUserWaitlistEntries retrieves a member's waitlist entries, newest first
+func (r *Repository) GetUserWaitlistEntries(ctx context.Context, userID string) ([]*WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query waitlist entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*WaitlistEntry
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating waitlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CancelWaitlistEntry removes a waiting entry from the waitlist
+func (r *Repository) CancelWaitlistEntry(ctx context.Context, id int) (*WaitlistEntry, error) {
+	query := `
+		UPDATE waitlist_entries
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE id = $1 AND status = 'waiting'
+		RETURNING ` + waitlistColumns + `
+	`
+
+	entry, err := scanWaitlistEntry(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWaitlistEntryClosed
+		}
+		return nil, fmt.Errorf("failed to cancel waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// CreateTransfer records a pending offer of a booking from its owner to a colleague and notifies
+// the colleague. A booking can only have one pending offer at a time.
+func (r *Repository) CreateTransfer(ctx context.Context, fromUserID string, input *OfferTransferInput) (*BookingTransfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		INSERT INTO booking_transfers (booking_id, from_user_id, to_user_id, swap_booking_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + transferColumns + `
+	`
+
+	transfer, err := scanTransfer(tx.QueryRow(ctx, query, input.BookingID, fromUserID, input.ToUserID, input.SwapBookingID))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgUniqueViolation:
+				return nil, ErrTransferPending
+			case pgForeignKeyViolation:
+				return nil, ErrTransferRecipientNotFound
+			}
+		}
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	message := fmt.Sprintf("You have been offered booking #%d", transfer.BookingID)
+	if transfer.SwapBookingID != nil {
+		message = fmt.Sprintf("You have been offered booking #%d in exchange for your booking #%d",
+			transfer.BookingID, *transfer.SwapBookingID)
+	}
+	if err := notify(ctx, tx, transfer.ToUserID, notificationTransferOffered, message); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// GetTransferByID retrieves a booking transfer by its ID
+func (r *Repository) GetTransferByID(ctx context.Context, id int) (*BookingTransfer, error) {
+	query := `
+		SELECT ` + transferColumns + `
+		FROM booking_transfers
+		WHERE id = $1
+	`
+
+	transfer, err := scanTransfer(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// GetUserTransfers retrieves the transfers a member offered or received, newest first
+func (r *Repository) GetUserTransfers(ctx context.Context, userID string) ([]*BookingTransfer, error) {
+	query := `
+		SELECT ` + transferColumns + `
+		FROM booking_transfers
+		WHERE from_user_id = $1 OR to_user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*BookingTransfer
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// AcceptTransfer hands the offered booking to the recipient and, for swaps, the recipient's booking
+// to the offerer. Both owners change in a single statement so the bookings are never held by the
+// same user in between. The bookings must still be confirmed and owned by the original parties.
+// Other pending offers involving either booking are cancelled, each owner change is recorded in
+// audit_logs and the offerer is notified.
+func (r *Repository) AcceptTransfer(ctx context.Context, id int) (*TransferResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	transfer, err := scanTransfer(tx.QueryRow(ctx, `
+		SELECT `+transferColumns+`
+		FROM booking_transfers
+		WHERE id = $1
+		FOR UPDATE
+	`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to lock transfer: %w", err)
+	}
+	if transfer.Status != TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	// Each booking and the user expected to own it before the exchange
+	owners := map[int]string{transfer.BookingID: transfer.FromUserID}
+	bookingIDs := []int{transfer.BookingID}
+	if transfer.SwapBookingID != nil {
+		owners[*transfer.SwapBookingID] = transfer.ToUserID
+		bookingIDs = append(bookingIDs, *transfer.SwapBookingID)
+	}
+
+	// Lock in id order so concurrent swaps of the same pair cannot deadlock
+	rows, err := tx.Query(ctx, `
+		SELECT id, user_id, status
+		FROM bookings
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`, bookingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock bookings: %w", err)
+	}
+	locked := 0
+	for rows.Next() {
+		var bookingID int
+		var userID string
+		var status BookingStatus
+		if err := rows.Scan(&bookingID, &userID, &status); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+		if status != StatusConfirmed {
+			rows.Close()
+			return nil, ErrBookingNotActive
+		}
+		if userID != owners[bookingID] {
+			rows.Close()
+			return nil, ErrTransferClosed
+		}
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+	if locked != len(bookingIDs) {
+		return nil, ErrBookingNotFound
+	}
+
+	swapQuery := `
+		UPDATE bookings
+		SET user_id = CASE WHEN id = $1 THEN $3::uuid ELSE $2::uuid END, updated_at = NOW()
+		WHERE id = ANY($4)
+		RETURNING ` + bookingColumns + `
+	`
+	rows, err = tx.Query(ctx, swapQuery, transfer.BookingID, transfer.FromUserID, transfer.ToUserID, bookingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer bookings: %w", err)
+	}
+	bookings, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := scanTransfer(tx.QueryRow(ctx, `
+		UPDATE booking_transfers
+		SET status = 'accepted', responded_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+transferColumns, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept transfer: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE booking_transfers
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE status = 'pending'
+		  AND id <> $1
+		  AND (booking_id = ANY($2) OR swap_booking_id = ANY($2))
+	`, id, bookingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel stale transfers: %w", err)
+	}
+
+	for _, booking := range bookings {
+		if err := recordOwnerChange(ctx, tx, booking.ID, owners[booking.ID], booking.UserID, accepted); err != nil {
+			return nil, err
+		}
+	}
+
+	message := fmt.Sprintf("Your offer of booking #%d was accepted", accepted.BookingID)
+	if err := notify(ctx, tx, accepted.FromUserID, notificationTransferAccepted, message); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer: %w", err)
+	}
+
+	return &TransferResult{Transfer: accepted, Bookings: sortBookings(bookings)}, nil
+}
+
+// CloseTransfer declines or withdraws a pending transfer and notifies the other party
+func (r *Repository) CloseTransfer(ctx context.Context, id int, status TransferStatus) (*BookingTransfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE booking_transfers
+		SET status = $2, responded_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + transferColumns + `
+	`
+
+	transfer, err := scanTransfer(tx.QueryRow(ctx, query, id, status))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferClosed
+		}
+		return nil, fmt.Errorf("failed to close transfer: %w", err)
+	}
+
+	if status == TransferDeclined {
+		message := fmt.Sprintf("Your offer of booking #%d was declined", transfer.BookingID)
+		err = notify(ctx, tx, transfer.FromUserID, notificationTransferDeclined, message)
+	} else {
+		message := fmt.Sprintf("The offer of booking #%d was withdrawn", transfer.BookingID)
+		err = notify(ctx, tx, transfer.ToUserID, notificationTransferCancelled, message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// recordOwnerChange writes an audit_logs entry for a booking that changed hands through a transfer
+func recordOwnerChange(ctx context.Context, tx pgx.Tx, bookingID int, from, to string, transfer *BookingTransfer) error {
+	changes, err := json.Marshal(map[string]interface{}{
+		"user_id": map[string]string{"from": from, "to": to},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode owner change: %w", err)
+	}
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"transfer_id": transfer.ID,
+		"swap":        transfer.SwapBookingID != nil,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode owner change metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_logs (user_id, entity_type, entity_id, action, changes, metadata)
+		VALUES ($1, 'booking', $2, 'transfer', $3, $4)
+	`
+	if _, err := tx.Exec(ctx, query, transfer.ToUserID, bookingID, changes, metadata); err != nil {
+		return fmt.Errorf("failed to record owner change: %w", err)
+	}
+
+	return nil
+}
+
+// notify writes an in-app notification inside the caller's transaction
+func notify(ctx context.Context, tx pgx.Tx, userID, notificationType, message string) error {
+	query := `
+		INSERT INTO notifications (user_id, type, message)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := tx.Exec(ctx, query, userID, notificationType, message); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// scanBooking scans a single row selected with bookingColumns
+func scanBooking(row pgx.Row) (*Booking, error) {
+	var booking Booking
+	err := row.Scan(
+		&booking.ID,
+		&booking.DeskID,
+		&booking.UserID,
+		&booking.SeriesID,
+		&booking.GroupID,
+		&booking.StartTime,
+		&booking.EndTime,
+		&booking.Status,
+		&booking.CheckedInAt,
+		&booking.ActualEndTime,
+		&booking.ActualDurationMinutes,
+		&booking.BufferMinutes,
+		&booking.HoldExpiresAt,
+		&booking.CancelledAt,
+		&booking.CreatedAt,
+		&booking.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// scanBookings scans all rows selected with bookingColumns
+func scanBookings(rows pgx.Rows) ([]*Booking, error) {
+	var bookings []*Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// scanMaintenanceBlock scans a single row selected with maintenanceColumns
+func scanMaintenanceBlock(row pgx.Row) (*MaintenanceBlock, error) {
+	var block MaintenanceBlock
+	err := row.Scan(
+		&block.ID,
+		&block.DeskID,
+		&block.StartTime,
+		&block.EndTime,
+		&block.Reason,
+		&block.CreatedBy,
+		&block.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// scanClosure scans a single row selected with closureColumns
+func scanClosure(row pgx.Row) (*Closure, error) {
+	var closure Closure
+	err := row.Scan(
+		&closure.ID,
+		&closure.Wing,
+		&closure.StartTime,
+		&closure.EndTime,
+		&closure.Reason,
+		&closure.CreatedBy,
+		&closure.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &closure, nil
+}
+
+// scanOpeningHours scans a single row selected with openingHoursColumns
+func scanOpeningHours(row pgx.Row) (*OpeningHours, error) {
+	var hours OpeningHours
+	var weekday int
+	var opens, closes pgtype.Time
+	err := row.Scan(
+		&hours.ID,
+		&hours.Wing,
+		&weekday,
+		&opens,
+		&closes,
+		&hours.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	hours.Weekday = time.Weekday(weekday)
+	hours.Opens = timeOfDay(opens)
+	hours.Closes = timeOfDay(closes)
+	return &hours, nil
+}
+
+// scanQuota scans a single row selected with quotaColumns
+func scanQuota(row pgx.Row) (*Quota, error) {
+	var quota Quota
+	err := row.Scan(
+		&quota.ID,
+		&quota.Role,
+		&quota.Period,
+		&quota.MaxHours,
+		&quota.MaxBookings,
+		&quota.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// timeOfDay converts a TIME column to a TimeOfDay, nil when NULL
+func timeOfDay(t pgtype.Time) *TimeOfDay {
+	if !t.Valid {
+		return nil
+	}
+	offset := TimeOfDay(time.Duration(t.Microseconds) * time.Microsecond)
+	return &offset
+}
+
+// timeOfDayParam converts a TimeOfDay to a TIME parameter, NULL when nil
+func timeOfDayParam(t *TimeOfDay) pgtype.Time {
+	if t == nil {
+		return pgtype.Time{}
+	}
+	return pgtype.Time{Microseconds: time.Duration(*t).Microseconds(), Valid: true}
+}
+
+// scanWaitlistEntry scans a single row selected with waitlistColumns
+func scanWaitlistEntry(row pgx.Row) (*WaitlistEntry, error) {
+	var entry WaitlistEntry
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.DeskID,
+		&entry.Wing,
+		&entry.StartTime,
+		&entry.EndTime,
+		&entry.Status,
+		&entry.BookingID,
+		&entry.FulfilledAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// scanTransfer scans a single row selected with transferColumns
+func scanTransfer(row pgx.Row) (*BookingTransfer, error) {
+	var transfer BookingTransfer
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.BookingID,
+		&transfer.FromUserID,
+		&transfer.ToUserID,
+		&transfer.SwapBookingID,
+		&transfer.Status,
+		&transfer.RespondedAt,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// GetSettings retrieves the global booking policies from the settings table, with the weekly
+// opening schedule
+func (r *Repository) GetSettings(ctx context.Context) (*Settings, error) {
+	return getSettings(ctx, r.db)
+}
+
+// getSettings loads the settings through q, so that a transaction sees the policies it enforces
+func getSettings(ctx context.Context, q querier) (*Settings, error) {
+	query := `
+		SELECT opening_start, opening_end, daily_hour_limit, check_in_grace_period_minutes,
+		       cleaning_buffer_minutes, timezone,
+		       member_max_advance_days, member_min_lead_minutes, admin_max_advance_days, admin_min_lead_minutes
+		FROM settings
+		WHERE id = 1
+	`
+
+	var openingStart, openingEnd pgtype.Time
+	var timezone string
+	var member, admin BookingWindow
+	var settings Settings
+	err := q.QueryRow(ctx, query).Scan(
+		&openingStart,
+		&openingEnd,
+		&settings.DailyHourLimit,
+		&settings.CheckInGracePeriodMinutes,
+		&settings.CleaningBufferMinutes,
+		&timezone,
+		&member.MaxAdvanceDays,
+		&member.MinLeadMinutes,
+		&admin.MaxAdvanceDays,
+		&admin.MinLeadMinutes,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSettingsNotFound
+		}
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	settings.OpeningStart = time.Duration(openingStart.Microseconds) * time.Microsecond
+	settings.OpeningEnd = time.Duration(openingEnd.Microseconds) * time.Microsecond
+	settings.BookingWindows = map[string]BookingWindow{roleMember: member, roleAdmin: admin}
+
+	settings.Location, err = time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid site timezone %q: %w", timezone, err)
+	}
+
+	settings.Schedule, err = getSchedule(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.Quotas, err = getQuotas(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
 }
@@ -2,6 +2,7 @@ package bookings
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -55,7 +56,7 @@ func setupTestUser(t *testing.T) string {
 	var userID string
 	err := testDB.QueryRow(ctx,
 		"INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING id",
-		"test-"+time.Now().Format("150405.000")+"@example.com", "hash", "member",
+		fmt.Sprintf("test-%d@example.com", time.Now().UnixNano()), "hash", "member",
 	).Scan(&userID)
 	if err != nil {
 		t.Fatalf("failed to create test user: %v", err)
@@ -76,6 +77,9 @@ func cleanupTestDesk(_ *testing.T, deskID int) {
 
 func cleanupTestUser(_ *testing.T, userID string) {
 	ctx := context.Background()
+	_, _ = testDB.Exec(ctx, "DELETE FROM audit_logs WHERE user_id = $1", userID)
+	_, _ = testDB.Exec(ctx, "DELETE FROM notifications WHERE user_id = $1", userID)
+	_, _ = testDB.Exec(ctx, "DELETE FROM waitlist_entries WHERE user_id = $1", userID)
 	_, _ = testDB.Exec(ctx, "DELETE FROM bookings WHERE user_id = $1", userID)
 	_, _ = testDB.Exec(ctx, "DELETE FROM sessions WHERE user_id = $1", userID)
 	_, _ = testDB.Exec(ctx, "DELETE FROM users WHERE id = $1", userID)
@@ -225,15 +229,15 @@ func TestGetUserBookings(t *testing.T) {
 		defer cleanupTestBooking(t, booking.ID)
 	}
 
-	bookings, err := repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err := repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID: &userID,
 	})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 3 {
-		t.Errorf("expected 3 bookings, got %d", len(bookings))
+	if len(page.Bookings) != 3 {
+		t.Errorf("expected 3 bookings, got %d", len(page.Bookings))
 	}
 }
 
@@ -261,7 +265,7 @@ func TestGetUserBookings_WithFilters(t *testing.T) {
 
 	// Filter by status
 	status := StatusConfirmed
-	bookings, err := repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err := repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID: &userID,
 		Status: &status,
 	})
@@ -269,13 +273,13 @@ func TestGetUserBookings_WithFilters(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 1 {
-		t.Errorf("expected 1 booking, got %d", len(bookings))
+	if len(page.Bookings) != 1 {
+		t.Errorf("expected 1 booking, got %d", len(page.Bookings))
 	}
 
 	// Filter by non-matching status
 	cancelledStatus := StatusCancelled
-	bookings, err = repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err = repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID: &userID,
 		Status: &cancelledStatus,
 	})
@@ -283,8 +287,8 @@ func TestGetUserBookings_WithFilters(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 0 {
-		t.Errorf("expected 0 bookings, got %d", len(bookings))
+	if len(page.Bookings) != 0 {
+		t.Errorf("expected 0 bookings, got %d", len(page.Bookings))
 	}
 }
 
@@ -313,7 +317,7 @@ func TestGetUserBookings_WithLimitOffset(t *testing.T) {
 	}
 
 	// Test limit
-	bookings, err := repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err := repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID: &userID,
 		Limit:  2,
 	})
@@ -321,12 +325,12 @@ func TestGetUserBookings_WithLimitOffset(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 2 {
-		t.Errorf("expected 2 bookings with limit, got %d", len(bookings))
+	if len(page.Bookings) != 2 {
+		t.Errorf("expected 2 bookings with limit, got %d", len(page.Bookings))
 	}
 
 	// Test offset
-	bookings, err = repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err = repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID: &userID,
 		Limit:  10,
 		Offset: 3,
@@ -335,8 +339,8 @@ func TestGetUserBookings_WithLimitOffset(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 2 {
-		t.Errorf("expected 2 bookings with offset, got %d", len(bookings))
+	if len(page.Bookings) != 2 {
+		t.Errorf("expected 2 bookings with offset, got %d", len(page.Bookings))
 	}
 }
 
@@ -421,7 +425,7 @@ func TestDeleteBooking(t *testing.T) {
 	}
 	defer cleanupTestBooking(t, created.ID)
 
-	err = repo.DeleteBooking(context.Background(), created.ID)
+	err = repo.DeleteBooking(context.Background(), created.ID, StatusChange{})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -443,7 +447,7 @@ func TestDeleteBooking(t *testing.T) {
 func TestDeleteBooking_NotFound(t *testing.T) {
 	repo := NewRepository(testDB)
 
-	err := repo.DeleteBooking(context.Background(), 999999)
+	err := repo.DeleteBooking(context.Background(), 999999, StatusChange{})
 	if err != ErrBookingNotFound {
 		t.Errorf("expected ErrBookingNotFound, got %v", err)
 	}
@@ -471,18 +475,149 @@ func TestDeleteBooking_AlreadyCancelled(t *testing.T) {
 	defer cleanupTestBooking(t, created.ID)
 
 	// Cancel once
-	err = repo.DeleteBooking(context.Background(), created.ID)
+	err = repo.DeleteBooking(context.Background(), created.ID, StatusChange{})
 	if err != nil {
 		t.Fatalf("expected no error on first cancel, got %v", err)
 	}
 
 	// Cancel again - should succeed (idempotent)
-	err = repo.DeleteBooking(context.Background(), created.ID)
+	err = repo.DeleteBooking(context.Background(), created.ID, StatusChange{})
 	if err != nil {
 		t.Errorf("expected no error on second cancel, got %v", err)
 	}
 }
 
+func TestUpdateBooking_InvalidTransition(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, created.ID)
+
+	if err := repo.DeleteBooking(ctx, created.ID, StatusChange{ActorID: &userID, Reason: "plans changed"}); err != nil {
+		t.Fatalf("failed to cancel booking: %v", err)
+	}
+
+	// A cancelled booking can never be confirmed again
+	confirmed := StatusConfirmed
+	_, err = repo.UpdateBooking(ctx, created.ID, &UpdateBookingInput{Status: &confirmed})
+	if !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	var actorID, reason string
+	err = testDB.QueryRow(ctx, `
+		SELECT user_id, metadata->>'reason' FROM audit_logs
+		WHERE entity_type = 'booking' AND entity_id = $1 AND action = 'status_transition'
+	`, created.ID).Scan(&actorID, &reason)
+	if err != nil {
+		t.Fatalf("failed to read transition: %v", err)
+	}
+	if actorID != userID || reason != "plans changed" {
+		t.Errorf("expected transition by %s for plans changed, got %s / %s", userID, actorID, reason)
+	}
+}
+
+func TestBulkCancelBookings(t *testing.T) {
+	closedDesk := setupTestDesk(t)
+	openDesk := setupTestDesk(t)
+	userID := setupTestUser(t)
+	adminID := setupTestUser(t)
+	defer cleanupTestDesk(t, closedDesk)
+	defer cleanupTestDesk(t, openDesk)
+	defer cleanupTestUser(t, userID)
+	defer cleanupTestUser(t, adminID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	create := func(deskID int, start time.Time) *Booking {
+		booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+			DeskID:    deskID,
+			UserID:    userID,
+			StartTime: start,
+			EndTime:   start.Add(2 * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("failed to create booking: %v", err)
+		}
+		return booking
+	}
+	inWindow := create(closedDesk, startTime)
+	afterWindow := create(closedDesk, startTime.Add(8*time.Hour))
+	otherDesk := create(openDesk, startTime)
+
+	input := &BulkCancelInput{
+		DeskIDs:   []int{closedDesk},
+		StartTime: startTime.Add(-time.Hour),
+		EndTime:   startTime.Add(4 * time.Hour),
+		Reason:    "power outage",
+	}
+
+	preview, err := repo.GetBulkCancelBookings(ctx, input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(preview) != 1 || preview[0].ID != inWindow.ID {
+		t.Fatalf("expected only booking %d in the preview, got %d bookings", inWindow.ID, len(preview))
+	}
+
+	cancelled, err := repo.BulkCancelBookings(ctx, input, StatusChange{ActorID: &adminID, Reason: input.Reason})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(cancelled) != 1 || cancelled[0].ID != inWindow.ID || cancelled[0].Status != StatusCancelled {
+		t.Fatalf("expected booking %d to be cancelled, got %d bookings", inWindow.ID, len(cancelled))
+	}
+
+	for _, id := range []int{afterWindow.ID, otherDesk.ID} {
+		booking, err := repo.GetBookingByID(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to get booking: %v", err)
+		}
+		if booking.Status != StatusConfirmed {
+			t.Errorf("expected booking %d to stay confirmed, got %s", id, booking.Status)
+		}
+	}
+
+	var reason string
+	err = testDB.QueryRow(ctx, `
+		SELECT metadata->>'reason' FROM audit_logs
+		WHERE entity_type = 'booking' AND entity_id = $1 AND action = 'status_transition'
+	`, inWindow.ID).Scan(&reason)
+	if err != nil {
+		t.Fatalf("failed to get audit entry: %v", err)
+	}
+	if reason != "power outage" {
+		t.Errorf("expected reason to be recorded, got %q", reason)
+	}
+
+	var notifications int
+	err = testDB.QueryRow(ctx, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2",
+		userID, notificationBookingCancelled).Scan(&notifications)
+	if err != nil {
+		t.Fatalf("failed to count notifications: %v", err)
+	}
+	if notifications != 1 {
+		t.Errorf("expected 1 notification, got %d", notifications)
+	}
+}
+
 // ============================================================================
 // IsDeskAvailable Tests
 // ============================================================================
@@ -607,7 +742,7 @@ func TestIsDeskAvailable_CancelledBookingIgnored(t *testing.T) {
 	}
 	defer cleanupTestBooking(t, booking.ID)
 
-	err = repo.DeleteBooking(context.Background(), booking.ID)
+	err = repo.DeleteBooking(context.Background(), booking.ID, StatusChange{})
 	if err != nil {
 		t.Fatalf("failed to cancel booking: %v", err)
 	}
@@ -627,6 +762,69 @@ func TestIsDeskAvailable_CancelledBookingIgnored(t *testing.T) {
 	}
 }
 
+func TestCleaningBuffer_ConstraintAndAvailabilityAgree(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	if _, err := testDB.Exec(ctx, "UPDATE desks SET cleaning_buffer_minutes = 15 WHERE id = $1", deskID); err != nil {
+		t.Fatalf("failed to set desk buffer: %v", err)
+	}
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+	if booking.BufferMinutes != 15 {
+		t.Errorf("expected the desk buffer to be recorded, got %d", booking.BufferMinutes)
+	}
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		available bool
+	}{
+		{"right after", start.Add(2 * time.Hour), start.Add(3 * time.Hour), false},
+		{"after the buffer", start.Add(2*time.Hour + 15*time.Minute), start.Add(3 * time.Hour), true},
+		{"right before", start.Add(-time.Hour), start, false},
+		{"buffer before", start.Add(-time.Hour), start.Add(-15 * time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			available, err := repo.IsDeskAvailable(ctx, &DeskAvailabilityCheck{DeskID: deskID, StartTime: tt.start, EndTime: tt.end})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if available != tt.available {
+				t.Errorf("expected available %v, got %v", tt.available, available)
+			}
+
+			created, err := repo.CreateBooking(ctx, &CreateBookingInput{DeskID: deskID, UserID: userID, StartTime: tt.start, EndTime: tt.end})
+			if tt.available {
+				if err != nil {
+					t.Fatalf("expected the constraint to accept the booking, got %v", err)
+				}
+				cleanupTestBooking(t, created.ID)
+			} else if err != ErrBookingConflict {
+				t.Errorf("expected ErrBookingConflict, got %v", err)
+			}
+		})
+	}
+}
+
 // ============================================================================
 // GetUserDailyHours Tests
 // ============================================================================
@@ -683,6 +881,40 @@ func TestGetUserDailyHours(t *testing.T) {
 	}
 }
 
+func TestGetUserDailyHours_DSTChange(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed to load Europe/London: %v", err)
+	}
+
+	// The clocks go forward on 28 March 2027, so the local day ends at 23:00 UTC
+	start := time.Date(2027, 3, 28, 22, 30, 0, 0, time.UTC)
+	booking, err := repo.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+
+	hours, err := repo.GetUserDailyHours(context.Background(), userID, time.Date(2027, 3, 28, 0, 0, 0, 0, london))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if hours != 0.5 {
+		t.Errorf("expected 0.5 hours before local midnight, got %v", hours)
+	}
+}
+
 func TestGetUserDailyHours_ExcludesCancelled(t *testing.T) {
 	deskID := setupTestDesk(t)
 	userID := setupTestUser(t)
@@ -706,7 +938,7 @@ func TestGetUserDailyHours_ExcludesCancelled(t *testing.T) {
 	}
 	defer cleanupTestBooking(t, booking.ID)
 
-	err = repo.DeleteBooking(context.Background(), booking.ID)
+	err = repo.DeleteBooking(context.Background(), booking.ID, StatusChange{})
 	if err != nil {
 		t.Fatalf("failed to cancel booking: %v", err)
 	}
@@ -761,6 +993,86 @@ func TestGetBookingsByTimeRange_Empty(t *testing.T) {
 	}
 }
 
+func TestGetUserBookings_Cursor(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	var ids []int
+	for i := 0; i < 5; i++ {
+		startTime := time.Now().Add(time.Duration(i+1) * 24 * time.Hour).Truncate(time.Second)
+		booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+			DeskID:    deskID,
+			UserID:    userID,
+			StartTime: startTime,
+			EndTime:   startTime.Add(2 * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("failed to create booking %d: %v", i, err)
+		}
+		ids = append(ids, booking.ID)
+	}
+
+	pageIDs := func(page *BookingPage) []int {
+		var got []int
+		for _, booking := range page.Bookings {
+			got = append(got, booking.ID)
+		}
+		return got
+	}
+
+	for _, sort := range []SortDirection{SortAsc, SortDesc} {
+		t.Run(string(sort), func(t *testing.T) {
+			expected := append([]int(nil), ids...)
+			if sort == SortDesc {
+				for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+					expected[i], expected[j] = expected[j], expected[i]
+				}
+			}
+
+			// Walk forwards in pages of two
+			filter := &BookingFilter{UserID: &userID, Sort: sort, Limit: 2}
+			var walked []int
+			var last *BookingPage
+			for {
+				page, err := repo.GetUserBookings(ctx, filter)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				walked = append(walked, pageIDs(page)...)
+				last = page
+				if page.Next == nil {
+					break
+				}
+				filter.Cursor = page.Next
+			}
+			if fmt.Sprint(walked) != fmt.Sprint(expected) {
+				t.Fatalf("expected %v walking forwards, got %v", expected, walked)
+			}
+
+			// Step back from the last page
+			if last.Prev == nil {
+				t.Fatal("expected a prev cursor on the last page")
+			}
+			filter.Cursor = last.Prev
+			page, err := repo.GetUserBookings(ctx, filter)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if fmt.Sprint(pageIDs(page)) != fmt.Sprint(expected[2:4]) {
+				t.Errorf("expected %v walking back, got %v", expected[2:4], pageIDs(page))
+			}
+			if page.Next == nil || page.Prev == nil {
+				t.Error("expected both cursors on a middle page")
+			}
+		})
+	}
+}
+
 func TestGetUserBookings_WithDateRange(t *testing.T) {
 	deskID := setupTestDesk(t)
 	userID := setupTestUser(t)
@@ -786,7 +1098,7 @@ func TestGetUserBookings_WithDateRange(t *testing.T) {
 	// Query with date range that includes the booking
 	startDate := startTime.Add(-time.Hour)
 	endDate := startTime.Add(3 * time.Hour)
-	bookings, err := repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err := repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID:    &userID,
 		StartDate: &startDate,
 		EndDate:   &endDate,
@@ -795,14 +1107,14 @@ func TestGetUserBookings_WithDateRange(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 1 {
-		t.Errorf("expected 1 booking, got %d", len(bookings))
+	if len(page.Bookings) != 1 {
+		t.Errorf("expected 1 booking, got %d", len(page.Bookings))
 	}
 
 	// Query with date range that excludes the booking
 	excludeStart := startTime.Add(-48 * time.Hour)
 	excludeEnd := startTime.Add(-24 * time.Hour)
-	bookings, err = repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err = repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID:    &userID,
 		StartDate: &excludeStart,
 		EndDate:   &excludeEnd,
@@ -811,8 +1123,8 @@ func TestGetUserBookings_WithDateRange(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 0 {
-		t.Errorf("expected 0 bookings, got %d", len(bookings))
+	if len(page.Bookings) != 0 {
+		t.Errorf("expected 0 bookings, got %d", len(page.Bookings))
 	}
 }
 
@@ -893,7 +1205,7 @@ func TestGetUserBookings_WithDeskFilter(t *testing.T) {
 	defer cleanupTestBooking(t, booking2.ID)
 
 	// Filter by desk 1
-	bookings, err := repo.GetUserBookings(context.Background(), &BookingFilter{
+	page, err := repo.GetUserBookings(context.Background(), &BookingFilter{
 		UserID: &userID,
 		DeskID: &deskID1,
 	})
@@ -901,8 +1213,8 @@ func TestGetUserBookings_WithDeskFilter(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(bookings) != 1 {
-		t.Errorf("expected 1 booking for desk 1, got %d", len(bookings))
+	if len(page.Bookings) != 1 {
+		t.Errorf("expected 1 booking for desk 1, got %d", len(page.Bookings))
 	}
 }
 
@@ -951,3 +1263,1642 @@ func TestGetBookingsByTimeRange(t *testing.T) {
 		t.Errorf("expected 2 bookings, got %d", len(bookings))
 	}
 }
+
+// ============================================================================
+// GetDesk Tests
+// ============================================================================
+
+func TestGetDesk(t *testing.T) {
+	deskID := setupTestDesk(t)
+	defer cleanupTestDesk(t, deskID)
+
+	repo := NewRepository(testDB)
+
+	desk, err := repo.GetDesk(context.Background(), deskID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if desk.ID != deskID || desk.Wing != WingEast || desk.Status != "available" {
+		t.Errorf("unexpected desk: %+v", desk)
+	}
+}
+
+func TestGetDesk_NotFound(t *testing.T) {
+	repo := NewRepository(testDB)
+
+	_, err := repo.GetDesk(context.Background(), 999999)
+	if err != ErrDeskNotFound {
+		t.Errorf("expected ErrDeskNotFound, got %v", err)
+	}
+}
+
+// ============================================================================
+// GetDeskOccupancy Tests
+// ============================================================================
+
+func TestGetDeskOccupancy(t *testing.T) {
+	freeDeskID := setupTestDesk(t)
+	busyDeskID := setupTestDesk(t)
+	otherDeskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, freeDeskID)
+	defer cleanupTestDesk(t, busyDeskID)
+	defer cleanupTestDesk(t, otherDeskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	// A feature unique to this run keeps desks from other tests out of the result
+	feature := fmt.Sprintf("feature-%d", time.Now().UnixNano())
+	_, err := testDB.Exec(ctx, "UPDATE desks SET features = ARRAY[$1, 'standing'] WHERE id = ANY($2)",
+		feature, []int{freeDeskID, busyDeskID})
+	if err != nil {
+		t.Fatalf("failed to set desk features: %v", err)
+	}
+
+	windowStart := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	windowEnd := windowStart.Add(8 * time.Hour)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    busyDeskID,
+		UserID:    userID,
+		StartTime: windowStart.Add(2 * time.Hour),
+		EndTime:   windowStart.Add(4 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+
+	// Cancelled bookings do not occupy the desk
+	cancelled, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    busyDeskID,
+		UserID:    userID,
+		StartTime: windowStart.Add(5 * time.Hour),
+		EndTime:   windowStart.Add(6 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, cancelled.ID)
+	if err := repo.DeleteBooking(ctx, cancelled.ID, StatusChange{}); err != nil {
+		t.Fatalf("failed to cancel booking: %v", err)
+	}
+
+	wing := WingEast
+	occupancy, err := repo.GetDeskOccupancy(ctx, &AvailabilityFilter{
+		StartTime: windowStart,
+		EndTime:   windowEnd,
+		Wing:      &wing,
+		Features:  []string{feature, "standing"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(occupancy) != 2 {
+		t.Fatalf("expected 2 desks, got %d", len(occupancy))
+	}
+	for _, desk := range occupancy {
+		switch desk.Desk.ID {
+		case freeDeskID:
+			if len(desk.Busy) != 0 {
+				t.Errorf("expected free desk to have no busy intervals, got %v", desk.Busy)
+			}
+		case busyDeskID:
+			if len(desk.Busy) != 1 || !desk.Busy[0].StartTime.Equal(booking.StartTime) {
+				t.Errorf("expected one busy interval at %v, got %v", booking.StartTime, desk.Busy)
+			}
+		default:
+			t.Errorf("unexpected desk %d", desk.Desk.ID)
+		}
+	}
+}
+
+// ============================================================================
+// GetDeskBookingsByTimeRange Tests
+// ============================================================================
+
+func TestGetDeskBookingsByTimeRange(t *testing.T) {
+	busyDeskID := setupTestDesk(t)
+	freeDeskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, busyDeskID)
+	defer cleanupTestDesk(t, freeDeskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	day := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    busyDeskID,
+		UserID:    userID,
+		StartTime: day.Add(9 * time.Hour),
+		EndTime:   day.Add(11 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+
+	wing := WingEast
+	desks, err := repo.GetDeskBookingsByTimeRange(ctx, &wing, day, day.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	found := 0
+	for _, desk := range desks {
+		switch desk.Desk.ID {
+		case busyDeskID:
+			found++
+			if len(desk.Bookings) != 1 {
+				t.Fatalf("expected 1 booking, got %d", len(desk.Bookings))
+			}
+			got := desk.Bookings[0]
+			if got.ID != booking.ID || got.UserID != userID || got.Status != StatusConfirmed || !got.StartTime.Equal(booking.StartTime) {
+				t.Errorf("expected booking %+v, got %+v", booking, got)
+			}
+		case freeDeskID:
+			found++
+			if len(desk.Bookings) != 0 {
+				t.Errorf("expected no bookings, got %d", len(desk.Bookings))
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf("expected both test desks in the result, found %d", found)
+	}
+}
+
+// ============================================================================
+// MoveBooking Tests
+// ============================================================================
+
+func TestMoveBooking(t *testing.T) {
+	fromDesk := setupTestDesk(t)
+	toDesk := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, fromDesk)
+	defer cleanupTestDesk(t, toDesk)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    fromDesk,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, created.ID)
+
+	moved, err := repo.MoveBooking(ctx, created.ID, BookingMove{
+		DeskID:    toDesk,
+		StartTime: startTime.Add(time.Hour),
+		EndTime:   startTime.Add(3 * time.Hour),
+		ActorID:   &userID,
+		Reason:    "quieter spot",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if moved.ID != created.ID || moved.DeskID != toDesk {
+		t.Errorf("expected booking %d on desk %d, got booking %d on desk %d", created.ID, toDesk, moved.ID, moved.DeskID)
+	}
+	if !moved.StartTime.Equal(startTime.Add(time.Hour)) {
+		t.Errorf("expected start %v, got %v", startTime.Add(time.Hour), moved.StartTime)
+	}
+
+	var fromDeskID, toDeskID int
+	var reason string
+	err = testDB.QueryRow(ctx, `
+		SELECT (changes->'desk_id'->>'from')::int, (changes->'desk_id'->>'to')::int, metadata->>'reason'
+		FROM audit_logs
+		WHERE entity_type = 'booking' AND entity_id = $1 AND action = 'move'
+	`, created.ID).Scan(&fromDeskID, &toDeskID, &reason)
+	if err != nil {
+		t.Fatalf("failed to read move: %v", err)
+	}
+	if fromDeskID != fromDesk || toDeskID != toDesk || reason != "quieter spot" {
+		t.Errorf("expected move %d -> %d for quieter spot, got %d -> %d for %s", fromDesk, toDesk, fromDeskID, toDeskID, reason)
+	}
+}
+
+func TestMoveBooking_ConflictLeavesBookingUntouched(t *testing.T) {
+	fromDesk := setupTestDesk(t)
+	toDesk := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, fromDesk)
+	defer cleanupTestDesk(t, toDesk)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    fromDesk,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, created.ID)
+
+	blocker, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    toDesk,
+		UserID:    userID,
+		StartTime: startTime.Add(time.Hour),
+		EndTime:   startTime.Add(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create blocking booking: %v", err)
+	}
+	defer cleanupTestBooking(t, blocker.ID)
+
+	_, err = repo.MoveBooking(ctx, created.ID, BookingMove{
+		DeskID:    toDesk,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+		ActorID:   &userID,
+	})
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Fatalf("expected ErrBookingConflict, got %v", err)
+	}
+
+	booking, err := repo.GetBookingByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("failed to get booking: %v", err)
+	}
+	if booking.DeskID != fromDesk || !booking.StartTime.Equal(startTime) {
+		t.Errorf("expected booking to stay on desk %d at %v, got desk %d at %v", fromDesk, startTime, booking.DeskID, booking.StartTime)
+	}
+
+	var moves int
+	err = testDB.QueryRow(ctx, `
+		SELECT COUNT(*) FROM audit_logs
+		WHERE entity_type = 'booking' AND entity_id = $1 AND action = 'move'
+	`, created.ID).Scan(&moves)
+	if err != nil {
+		t.Fatalf("failed to count moves: %v", err)
+	}
+	if moves != 0 {
+		t.Errorf("expected no move recorded, got %d", moves)
+	}
+}
+
+func TestMoveBooking_DeskInMaintenance(t *testing.T) {
+	fromDesk := setupTestDesk(t)
+	toDesk := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, fromDesk)
+	defer cleanupTestDesk(t, toDesk)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	if _, err := testDB.Exec(ctx, "UPDATE desks SET status = 'maintenance' WHERE id = $1", toDesk); err != nil {
+		t.Fatalf("failed to put desk in maintenance: %v", err)
+	}
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    fromDesk,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, created.ID)
+
+	_, err = repo.MoveBooking(ctx, created.ID, BookingMove{
+		DeskID:    toDesk,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if !errors.Is(err, ErrDeskNotAvailable) {
+		t.Errorf("expected ErrDeskNotAvailable, got %v", err)
+	}
+}
+
+// ============================================================================
+// CreateGroup Tests
+// ============================================================================
+
+func TestCreateGroup(t *testing.T) {
+	deskA := setupTestDesk(t)
+	deskB := setupTestDesk(t)
+	alice := setupTestUser(t)
+	bob := setupTestUser(t)
+	defer cleanupTestDesk(t, deskA)
+	defer cleanupTestDesk(t, deskB)
+	defer cleanupTestUser(t, alice)
+	defer cleanupTestUser(t, bob)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	result, err := repo.CreateGroup(ctx, &CreateGroupInput{
+		OrganizerID: alice,
+		StartTime:   startTime,
+		EndTime:     startTime.Add(8 * time.Hour),
+	}, []GroupAssignment{{UserID: alice, DeskID: deskA}, {UserID: bob, DeskID: deskB}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Bookings) != 2 {
+		t.Fatalf("expected 2 bookings, got %d", len(result.Bookings))
+	}
+	for _, booking := range result.Bookings {
+		if booking.GroupID == nil || *booking.GroupID != result.Group.ID {
+			t.Errorf("expected booking %d in group %d, got %v", booking.ID, result.Group.ID, booking.GroupID)
+		}
+	}
+
+	// Attendees cancel individually without touching the rest of the group
+	if err := repo.DeleteBooking(ctx, result.Bookings[0].ID, StatusChange{ActorID: &alice}); err != nil {
+		t.Fatalf("failed to cancel booking: %v", err)
+	}
+	other, err := repo.GetBookingByID(ctx, result.Bookings[1].ID)
+	if err != nil {
+		t.Fatalf("failed to get booking: %v", err)
+	}
+	if other.Status != StatusConfirmed {
+		t.Errorf("expected the other attendee's booking to stay confirmed, got %s", other.Status)
+	}
+}
+
+func TestCreateGroup_AllOrNothing(t *testing.T) {
+	deskA := setupTestDesk(t)
+	deskB := setupTestDesk(t)
+	deskC := setupTestDesk(t)
+	alice := setupTestUser(t)
+	bob := setupTestUser(t)
+	defer cleanupTestDesk(t, deskA)
+	defer cleanupTestDesk(t, deskB)
+	defer cleanupTestDesk(t, deskC)
+	defer cleanupTestUser(t, alice)
+	defer cleanupTestUser(t, bob)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	blocker, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID: deskB, UserID: bob, StartTime: startTime.Add(time.Hour), EndTime: startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, blocker.ID)
+
+	unknownUser := "00000000-0000-0000-0000-000000000000"
+	result, err := repo.CreateGroup(ctx, &CreateGroupInput{
+		OrganizerID: alice,
+		StartTime:   startTime,
+		EndTime:     startTime.Add(8 * time.Hour),
+	}, []GroupAssignment{{UserID: alice, DeskID: deskA}, {UserID: bob, DeskID: deskB}, {UserID: unknownUser, DeskID: deskC}})
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Fatalf("expected ErrBookingConflict, got %v", err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].DeskID != deskB {
+		t.Errorf("expected desk %d to conflict, got %+v", deskB, result.Conflicts)
+	}
+	if len(result.MissingUsers) != 1 || result.MissingUsers[0].UserID != unknownUser {
+		t.Errorf("expected %s to be missing, got %+v", unknownUser, result.MissingUsers)
+	}
+
+	var groups, bookings int
+	err = testDB.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM booking_groups WHERE organizer_id = $1),
+		       (SELECT COUNT(*) FROM bookings WHERE user_id = $1)
+	`, alice).Scan(&groups, &bookings)
+	if err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if groups != 0 || bookings != 0 {
+		t.Errorf("expected the group to be rolled back, got %d groups and %d bookings", groups, bookings)
+	}
+}
+
+// ============================================================================
+// Transfer Tests
+// ============================================================================
+
+func TestAcceptTransfer_Swap(t *testing.T) {
+	deskA := setupTestDesk(t)
+	deskB := setupTestDesk(t)
+	alice := setupTestUser(t)
+	bob := setupTestUser(t)
+	carol := setupTestUser(t)
+	defer cleanupTestDesk(t, deskA)
+	defer cleanupTestDesk(t, deskB)
+	defer cleanupTestUser(t, alice)
+	defer cleanupTestUser(t, bob)
+	defer cleanupTestUser(t, carol)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	aliceBooking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID: deskA, UserID: alice, StartTime: startTime, EndTime: startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	bobBooking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID: deskB, UserID: bob, StartTime: startTime, EndTime: startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	// A plain offer of Bob's booking to Carol goes stale once Bob swaps it away
+	stale, err := repo.CreateTransfer(ctx, bob, &OfferTransferInput{BookingID: bobBooking.ID, ToUserID: carol})
+	if err != nil {
+		t.Fatalf("failed to create transfer: %v", err)
+	}
+	swap, err := repo.CreateTransfer(ctx, alice, &OfferTransferInput{
+		BookingID: aliceBooking.ID, ToUserID: bob, SwapBookingID: &bobBooking.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create swap: %v", err)
+	}
+
+	result, err := repo.AcceptTransfer(ctx, swap.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Transfer.Status != TransferAccepted {
+		t.Errorf("expected status accepted, got %s", result.Transfer.Status)
+	}
+	if len(result.Bookings) != 2 {
+		t.Fatalf("expected 2 bookings, got %d", len(result.Bookings))
+	}
+	for _, booking := range result.Bookings {
+		want := bob
+		if booking.ID == bobBooking.ID {
+			want = alice
+		}
+		if booking.UserID != want {
+			t.Errorf("expected booking %d to belong to %s, got %s", booking.ID, want, booking.UserID)
+		}
+	}
+
+	staleAfter, err := repo.GetTransferByID(ctx, stale.ID)
+	if err != nil {
+		t.Fatalf("failed to get transfer: %v", err)
+	}
+	if staleAfter.Status != TransferCancelled {
+		t.Errorf("expected the stale offer to be cancelled, got %s", staleAfter.Status)
+	}
+
+	var changes int
+	err = testDB.QueryRow(ctx, `
+		SELECT COUNT(*) FROM audit_logs
+		WHERE entity_type = 'booking' AND entity_id = ANY($1) AND action = 'transfer'
+	`, []int{aliceBooking.ID, bobBooking.ID}).Scan(&changes)
+	if err != nil {
+		t.Fatalf("failed to count owner changes: %v", err)
+	}
+	if changes != 2 {
+		t.Errorf("expected 2 owner changes recorded, got %d", changes)
+	}
+
+	if _, err := repo.AcceptTransfer(ctx, swap.ID); !errors.Is(err, ErrTransferClosed) {
+		t.Errorf("expected ErrTransferClosed on second accept, got %v", err)
+	}
+}
+
+func TestCreateTransfer_OnePendingPerBooking(t *testing.T) {
+	deskID := setupTestDesk(t)
+	alice := setupTestUser(t)
+	bob := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, alice)
+	defer cleanupTestUser(t, bob)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID: deskID, UserID: alice, StartTime: startTime, EndTime: startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	first, err := repo.CreateTransfer(ctx, alice, &OfferTransferInput{BookingID: booking.ID, ToUserID: bob})
+	if err != nil {
+		t.Fatalf("failed to create transfer: %v", err)
+	}
+
+	if _, err := repo.CreateTransfer(ctx, alice, &OfferTransferInput{BookingID: booking.ID, ToUserID: bob}); !errors.Is(err, ErrTransferPending) {
+		t.Errorf("expected ErrTransferPending, got %v", err)
+	}
+
+	declined, err := repo.CloseTransfer(ctx, first.ID, TransferDeclined)
+	if err != nil {
+		t.Fatalf("failed to decline transfer: %v", err)
+	}
+	if declined.Status != TransferDeclined || declined.RespondedAt == nil {
+		t.Errorf("expected a declined transfer with a response time, got %+v", declined)
+	}
+
+	// Once declined the booking can be offered again
+	if _, err := repo.CreateTransfer(ctx, alice, &OfferTransferInput{BookingID: booking.ID, ToUserID: bob}); err != nil {
+		t.Errorf("expected a new offer to be accepted, got %v", err)
+	}
+}
+
+// ============================================================================
+// GetSettings Tests
+// ============================================================================
+
+func TestGetSettings(t *testing.T) {
+	repo := NewRepository(testDB)
+
+	settings, err := repo.GetSettings(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if settings.OpeningEnd <= settings.OpeningStart {
+		t.Errorf("expected opening_end after opening_start, got %v-%v", settings.OpeningStart, settings.OpeningEnd)
+	}
+	if settings.DailyHourLimit <= 0 {
+		t.Errorf("expected positive daily_hour_limit, got %d", settings.DailyHourLimit)
+	}
+	if _, ok := settings.BookingWindows[roleMember]; !ok {
+		t.Errorf("expected a member booking window, got %+v", settings.BookingWindows)
+	}
+	if settings.BookingWindows[roleAdmin].restricts() {
+		t.Errorf("expected admins to be exempt by default, got %+v", settings.BookingWindows[roleAdmin])
+	}
+}
+
+// ============================================================================
+// Waitlist Tests
+// ============================================================================
+
+func TestDeleteBooking_PromotesWaitlist(t *testing.T) {
+	deskID := setupTestDesk(t)
+	ownerID := setupTestUser(t)
+	waiterID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, ownerID)
+	defer cleanupTestUser(t, waiterID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	// Promotions only land within opening hours, 08:00 to 22:00 UTC by default
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(2 * time.Hour)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    ownerID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	entry, err := repo.CreateWaitlistEntry(ctx, &JoinWaitlistInput{
+		UserID:    waiterID,
+		DeskID:    &deskID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	if err := repo.DeleteBooking(ctx, created.ID, StatusChange{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	promoted, err := repo.GetWaitlistEntryByID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("failed to get waitlist entry: %v", err)
+	}
+	if promoted.Status != WaitlistFulfilled || promoted.BookingID == nil {
+		t.Fatalf("expected entry to be fulfilled with a booking, got %+v", promoted)
+	}
+
+	booking, err := repo.GetBookingByID(ctx, *promoted.BookingID)
+	if err != nil {
+		t.Fatalf("failed to get promoted booking: %v", err)
+	}
+	if booking.UserID != waiterID || booking.Status != StatusConfirmed {
+		t.Errorf("expected confirmed booking for waiter, got %+v", booking)
+	}
+
+	var notifications int
+	err = testDB.QueryRow(ctx, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2",
+		waiterID, notificationWaitlistPromoted).Scan(&notifications)
+	if err != nil {
+		t.Fatalf("failed to count notifications: %v", err)
+	}
+	if notifications != 1 {
+		t.Errorf("expected 1 notification, got %d", notifications)
+	}
+}
+
+func TestUpdateBooking_PromotesWaitlistForFreedTime(t *testing.T) {
+	deskID := setupTestDesk(t)
+	ownerID := setupTestUser(t)
+	waiterID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, ownerID)
+	defer cleanupTestUser(t, waiterID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(3 * time.Hour)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    ownerID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	// The waiter wants the last hour, which the owner gives up by ending early
+	entry, err := repo.CreateWaitlistEntry(ctx, &JoinWaitlistInput{
+		UserID:    waiterID,
+		DeskID:    &deskID,
+		StartTime: endTime.Add(-time.Hour),
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	newEnd := startTime.Add(time.Hour)
+	if _, err := repo.UpdateBooking(ctx, created.ID, &UpdateBookingInput{EndTime: &newEnd}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	promoted, err := repo.GetWaitlistEntryByID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("failed to get waitlist entry: %v", err)
+	}
+	if promoted.Status != WaitlistFulfilled || promoted.BookingID == nil {
+		t.Fatalf("expected entry to be fulfilled with a booking, got %+v", promoted)
+	}
+
+	booking, err := repo.GetBookingByID(ctx, *promoted.BookingID)
+	if err != nil {
+		t.Fatalf("failed to get promoted booking: %v", err)
+	}
+	if booking.UserID != waiterID || booking.DeskID != deskID || !booking.StartTime.Equal(endTime.Add(-time.Hour)) {
+		t.Errorf("expected the freed hour to be booked for the waiter, got %+v", booking)
+	}
+}
+
+func TestDeleteBooking_NoPromotionDuringClosure(t *testing.T) {
+	deskID := setupTestDesk(t)
+	ownerID := setupTestUser(t)
+	waiterID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, ownerID)
+	defer cleanupTestUser(t, waiterID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(2 * time.Hour)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    ownerID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	entry, err := repo.CreateWaitlistEntry(ctx, &JoinWaitlistInput{
+		UserID:    waiterID,
+		DeskID:    &deskID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	// The test desk's wing closes over the booking before it is cancelled
+	east := WingEast
+	closure, err := repo.CreateClosure(ctx, &CreateClosureInput{
+		Wing:      &east,
+		StartTime: startTime.Add(-time.Hour),
+		EndTime:   endTime.Add(time.Hour),
+		Reason:    "power outage",
+	})
+	if err != nil {
+		t.Fatalf("failed to create closure: %v", err)
+	}
+	defer cleanupTestClosure(t, closure.ID)
+
+	if err := repo.DeleteBooking(ctx, created.ID, StatusChange{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	waiting, err := repo.GetWaitlistEntryByID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("failed to get waitlist entry: %v", err)
+	}
+	if waiting.Status != WaitlistWaiting || waiting.BookingID != nil {
+		t.Errorf("expected entry to keep waiting, got %+v", waiting)
+	}
+
+	var bookings int
+	err = testDB.QueryRow(ctx, "SELECT COUNT(*) FROM bookings WHERE user_id = $1", waiterID).Scan(&bookings)
+	if err != nil {
+		t.Fatalf("failed to count bookings: %v", err)
+	}
+	if bookings != 0 {
+		t.Errorf("expected no booking for the waiter, got %d", bookings)
+	}
+}
+
+func TestDeleteBooking_NoPromotionOverDailyLimit(t *testing.T) {
+	deskID := setupTestDesk(t)
+	otherDeskID := setupTestDesk(t)
+	ownerID := setupTestUser(t)
+	waiterID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestDesk(t, otherDeskID)
+	defer cleanupTestUser(t, ownerID)
+	defer cleanupTestUser(t, waiterID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(2 * time.Hour)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    ownerID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	// With the default 10 hour limit the waiter has room for only one more hour that day
+	_, err = repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    otherDeskID,
+		UserID:    waiterID,
+		StartTime: endTime,
+		EndTime:   endTime.Add(9 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	entry, err := repo.CreateWaitlistEntry(ctx, &JoinWaitlistInput{
+		UserID:    waiterID,
+		DeskID:    &deskID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	if err := repo.DeleteBooking(ctx, created.ID, StatusChange{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	waiting, err := repo.GetWaitlistEntryByID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("failed to get waitlist entry: %v", err)
+	}
+	if waiting.Status != WaitlistWaiting || waiting.BookingID != nil {
+		t.Errorf("expected entry to keep waiting, got %+v", waiting)
+	}
+}
+
+func TestDeleteBooking_NoPromotionOutsideBookingWindow(t *testing.T) {
+	deskID := setupTestDesk(t)
+	ownerID := setupTestUser(t)
+	waiterID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, ownerID)
+	defer cleanupTestUser(t, waiterID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(2 * time.Hour)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    ownerID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	entry, err := repo.CreateWaitlistEntry(ctx, &JoinWaitlistInput{
+		UserID:    waiterID,
+		DeskID:    &deskID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	// Members now need two days' notice, so tomorrow's slot starts too soon
+	if _, err := testDB.Exec(ctx, "UPDATE settings SET member_min_lead_minutes = 2880"); err != nil {
+		t.Fatalf("failed to set booking window: %v", err)
+	}
+	defer func() { _, _ = testDB.Exec(ctx, "UPDATE settings SET member_min_lead_minutes = 0") }()
+
+	if err := repo.DeleteBooking(ctx, created.ID, StatusChange{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	waiting, err := repo.GetWaitlistEntryByID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("failed to get waitlist entry: %v", err)
+	}
+	if waiting.Status != WaitlistWaiting || waiting.BookingID != nil {
+		t.Errorf("expected entry to keep waiting, got %+v", waiting)
+	}
+}
+
+// ============================================================================
+// No-Show Tests
+// ============================================================================
+
+func TestMarkNoShows(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	missed, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(30 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	attended, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime.Add(30 * time.Minute),
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	if _, err := repo.CheckInBooking(ctx, attended.ID, startTime.Add(30*time.Minute)); err != nil {
+		t.Fatalf("failed to check in: %v", err)
+	}
+
+	marked, err := repo.MarkNoShows(ctx, 15, 100, StatusChange{Reason: "test"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	found := false
+	for _, booking := range marked {
+		if booking.ID == attended.ID {
+			t.Error("expected checked-in booking not to be marked")
+		}
+		if booking.ID == missed.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected missed booking to be marked as no-show")
+	}
+
+	// A no-show can no longer be checked in
+	if _, err := repo.CheckInBooking(ctx, missed.ID, time.Now()); err != ErrBookingNotActive {
+		t.Errorf("expected ErrBookingNotActive, got %v", err)
+	}
+}
+
+// ============================================================================
+// Hold Tests
+// ============================================================================
+
+func TestHolds_BlockDeskUntilExpired(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	input := &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	}
+
+	held, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+		Hold:      true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create hold: %v", err)
+	}
+	if held.Status != StatusHeld || held.HoldExpiresAt == nil {
+		t.Fatalf("expected a hold with an expiry, got %s", held.Status)
+	}
+
+	// A hold occupies the desk like a confirmed booking
+	if _, err := repo.CreateBooking(ctx, input); err != ErrBookingConflict {
+		t.Fatalf("expected ErrBookingConflict, got %v", err)
+	}
+
+	if _, err := testDB.Exec(ctx, "UPDATE bookings SET hold_expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", held.ID); err != nil {
+		t.Fatalf("failed to expire hold: %v", err)
+	}
+	if _, err := repo.ConfirmHold(ctx, held.ID, StatusChange{Reason: "test"}); err != ErrHoldExpired {
+		t.Errorf("expected ErrHoldExpired, got %v", err)
+	}
+
+	expired, err := repo.ExpireHolds(ctx, 100, StatusChange{Reason: "test"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	found := false
+	for _, booking := range expired {
+		if booking.ID == held.ID {
+			found = booking.Status == StatusCancelled
+		}
+	}
+	if !found {
+		t.Error("expected expired hold to be cancelled")
+	}
+
+	// The released slot can be booked again
+	booking, err := repo.CreateBooking(ctx, input)
+	if err != nil {
+		t.Fatalf("expected released slot to be bookable, got %v", err)
+	}
+	if booking.Status != StatusConfirmed || booking.HoldExpiresAt != nil {
+		t.Errorf("expected a confirmed booking without expiry, got %s", booking.Status)
+	}
+}
+
+func TestConfirmHold(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	held, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(time.Hour),
+		Hold:      true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create hold: %v", err)
+	}
+
+	confirmed, err := repo.ConfirmHold(ctx, held.ID, StatusChange{ActorID: &userID, Reason: "test"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if confirmed.Status != StatusConfirmed || confirmed.HoldExpiresAt != nil {
+		t.Errorf("expected confirmed booking without expiry, got %s", confirmed.Status)
+	}
+
+	// Confirming twice is not a valid transition
+	if _, err := repo.ConfirmHold(ctx, held.ID, StatusChange{Reason: "test"}); !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+	}
+}
+
+// ============================================================================
+// CheckOut Tests
+// ============================================================================
+
+func TestCheckOutBooking_FreesRemainingTime(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	endTime := startTime.Add(4 * time.Hour)
+
+	created, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	if _, err := repo.CheckInBooking(ctx, created.ID, startTime); err != nil {
+		t.Fatalf("failed to check in: %v", err)
+	}
+
+	actualEnd := startTime.Add(time.Hour)
+	completed, err := repo.CheckOutBooking(ctx, created.ID, actualEnd, 60, StatusChange{ActorID: &userID})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if completed.Status != StatusCompleted || completed.ActualDurationMinutes == nil || *completed.ActualDurationMinutes != 60 {
+		t.Errorf("expected completed booking with 60 minutes, got %+v", completed)
+	}
+	if !completed.EndTime.Equal(endTime) {
+		t.Errorf("expected original end time %v, got %v", endTime, completed.EndTime)
+	}
+
+	// The rest of the window can be booked by someone else
+	available, err := repo.IsDeskAvailable(ctx, &DeskAvailabilityCheck{
+		DeskID:    deskID,
+		StartTime: actualEnd,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		t.Fatalf("failed to check availability: %v", err)
+	}
+	if !available {
+		t.Error("expected the remaining interval to be free")
+	}
+}
+
+// ============================================================================
+// Maintenance Tests
+// ============================================================================
+
+func TestCreateMaintenanceBlock_BlocksBookings(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	inserted, err := repo.CreateMaintenanceBlock(ctx, &CreateMaintenanceInput{
+		DeskID:    deskID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(4 * time.Hour),
+		Reason:    "monitor replacement",
+		Policy:    MaintenanceReject,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if inserted.Block.DeskID != deskID || inserted.Block.Reason != "monitor replacement" {
+		t.Errorf("expected the block on the desk, got %+v", inserted.Block)
+	}
+
+	_, err = repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime.Add(time.Hour),
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Errorf("expected ErrBookingConflict while the desk is blocked, got %v", err)
+	}
+
+	available, err := repo.IsDeskAvailable(ctx, &DeskAvailabilityCheck{
+		DeskID:    deskID,
+		StartTime: startTime.Add(3 * time.Hour),
+		EndTime:   startTime.Add(5 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if available {
+		t.Error("expected the desk to be unavailable during maintenance")
+	}
+
+	if _, err := repo.DeleteMaintenanceBlock(ctx, inserted.Block.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime.Add(time.Hour),
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected the desk to be bookable once the block is removed, got %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+}
+
+func TestCreateMaintenanceBlock_RejectRollsBack(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+
+	inserted, err := repo.CreateMaintenanceBlock(ctx, &CreateMaintenanceInput{
+		DeskID:    deskID,
+		StartTime: startTime.Add(time.Hour),
+		EndTime:   startTime.Add(4 * time.Hour),
+		Reason:    "monitor replacement",
+		Policy:    MaintenanceReject,
+	})
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Fatalf("expected ErrBookingConflict, got %v", err)
+	}
+	if len(inserted.Conflicts) != 1 || inserted.Conflicts[0].ID != booking.ID {
+		t.Errorf("expected the booking to be reported, got %+v", inserted.Conflicts)
+	}
+
+	blocks, err := repo.GetMaintenanceBlocks(ctx, &MaintenanceFilter{DeskID: &deskID})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(blocks) != 0 {
+		t.Errorf("expected the block to be rolled back, got %+v", blocks)
+	}
+}
+
+func TestCreateMaintenanceBlock_Cancel(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	inserted, err := repo.CreateMaintenanceBlock(ctx, &CreateMaintenanceInput{
+		DeskID:    deskID,
+		StartTime: startTime.Add(time.Hour),
+		EndTime:   startTime.Add(4 * time.Hour),
+		Reason:    "monitor replacement",
+		Policy:    MaintenanceCancel,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(inserted.Cancelled) != 1 || inserted.Cancelled[0].Status != StatusCancelled {
+		t.Fatalf("expected the booking to be cancelled, got %+v", inserted.Cancelled)
+	}
+
+	var notifications int
+	err = testDB.QueryRow(ctx,
+		"SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2", userID, notificationBookingCancelled,
+	).Scan(&notifications)
+	if err != nil {
+		t.Fatalf("failed to count notifications: %v", err)
+	}
+	if notifications != 1 {
+		t.Errorf("expected the owner to be notified once, got %d", notifications)
+	}
+
+	fetched, err := repo.GetBookingByID(ctx, booking.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fetched.Status != StatusCancelled {
+		t.Errorf("expected the booking to be cancelled, got %s", fetched.Status)
+	}
+}
+
+func TestCreateMaintenanceBlock_Move(t *testing.T) {
+	deskID := setupTestDesk(t)
+	spareDesk := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestDesk(t, spareDesk)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	// Moves only land within opening hours, 08:00 to 22:00 UTC by default
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+
+	inserted, err := repo.CreateMaintenanceBlock(ctx, &CreateMaintenanceInput{
+		DeskID:    deskID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(4 * time.Hour),
+		Reason:    "monitor replacement",
+		Policy:    MaintenanceMove,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(inserted.Moved) != 1 {
+		t.Fatalf("expected the booking to be moved, got %+v", inserted.Moved)
+	}
+
+	moved := inserted.Moved[0]
+	if moved.ID != booking.ID || moved.DeskID == deskID || moved.Status != StatusConfirmed {
+		t.Errorf("expected booking %d confirmed on another desk, got %+v", booking.ID, moved)
+	}
+	if !moved.StartTime.Equal(booking.StartTime) || !moved.EndTime.Equal(booking.EndTime) {
+		t.Errorf("expected the window to be kept, got %v-%v", moved.StartTime, moved.EndTime)
+	}
+}
+
+func TestCreateMaintenanceBlock_MoveRejectsDuringClosure(t *testing.T) {
+	deskID := setupTestDesk(t)
+	spareDesk := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestDesk(t, spareDesk)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC()
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+
+	booking, err := repo.CreateBooking(ctx, &CreateBookingInput{
+		DeskID:    deskID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	defer cleanupTestBooking(t, booking.ID)
+
+	// The spare desk is free but its wing is closed over the booking
+	east := WingEast
+	closure, err := repo.CreateClosure(ctx, &CreateClosureInput{
+		Wing:      &east,
+		StartTime: startTime,
+		EndTime:   startTime.Add(time.Hour),
+		Reason:    "power outage",
+	})
+	if err != nil {
+		t.Fatalf("failed to create closure: %v", err)
+	}
+	defer cleanupTestClosure(t, closure.ID)
+
+	inserted, err := repo.CreateMaintenanceBlock(ctx, &CreateMaintenanceInput{
+		DeskID:    deskID,
+		StartTime: startTime,
+		EndTime:   startTime.Add(4 * time.Hour),
+		Reason:    "monitor replacement",
+		Policy:    MaintenanceMove,
+	})
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Fatalf("expected ErrBookingConflict, got %v", err)
+	}
+	if len(inserted.Conflicts) != 1 || inserted.Conflicts[0].ID != booking.ID {
+		t.Errorf("expected booking %d to be left in the way, got %+v", booking.ID, inserted.Conflicts)
+	}
+
+	unchanged, err := repo.GetBookingByID(ctx, booking.ID)
+	if err != nil {
+		t.Fatalf("failed to get booking: %v", err)
+	}
+	if unchanged.DeskID != deskID {
+		t.Errorf("expected the booking to stay on desk %d, got %d", deskID, unchanged.DeskID)
+	}
+}
+
+func cleanupTestClosure(t *testing.T, id int) {
+	t.Helper()
+	if _, err := testDB.Exec(context.Background(), "DELETE FROM closures WHERE id = $1", id); err != nil {
+		t.Errorf("failed to cleanup closure: %v", err)
+	}
+}
+
+func TestGetClosures_WingScope(t *testing.T) {
+	deskID := setupTestDesk(t)
+	defer cleanupTestDesk(t, deskID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+	startTime := time.Now().AddDate(1, 0, 0).Truncate(time.Hour)
+
+	east, west := WingEast, WingWest
+	var ids []int
+	for _, wing := range []*string{nil, &east, &west} {
+		closure, err := repo.CreateClosure(ctx, &CreateClosureInput{
+			Wing:      wing,
+			StartTime: startTime,
+			EndTime:   startTime.Add(24 * time.Hour),
+			Reason:    "public holiday",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		defer cleanupTestClosure(t, closure.ID)
+		ids = append(ids, closure.ID)
+	}
+
+	// The test desk is in the East wing: the office-wide and East wing closures apply to it
+	from, to := startTime.Add(9*time.Hour), startTime.Add(10*time.Hour)
+	closures, err := repo.GetClosures(ctx, &ClosureFilter{DeskID: &deskID, StartTime: &from, EndTime: &to})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	found := make(map[int]bool)
+	for _, closure := range closures {
+		found[closure.ID] = true
+	}
+	if !found[ids[0]] || !found[ids[1]] || found[ids[2]] {
+		t.Errorf("expected closures %d and %d but not %d, got %+v", ids[0], ids[1], ids[2], closures)
+	}
+
+	// Closures outside the range are not returned
+	from, to = startTime.Add(24*time.Hour), startTime.Add(48*time.Hour)
+	closures, err = repo.GetClosures(ctx, &ClosureFilter{Wing: &west, StartTime: &from, EndTime: &to})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, closure := range closures {
+		if closure.ID == ids[0] || closure.ID == ids[2] {
+			t.Errorf("expected closure %d to end before the range", closure.ID)
+		}
+	}
+
+	deleted, err := repo.DeleteClosure(ctx, ids[2])
+	if err != nil || deleted.ID != ids[2] || *deleted.Wing != WingWest {
+		t.Errorf("expected the West wing closure to be deleted, got %+v, %v", deleted, err)
+	}
+	if _, err := repo.DeleteClosure(ctx, ids[2]); !errors.Is(err, ErrClosureNotFound) {
+		t.Errorf("expected ErrClosureNotFound, got %v", err)
+	}
+}
+
+func TestGetDeskOccupancy_Closures(t *testing.T) {
+	deskID := setupTestDesk(t)
+	defer cleanupTestDesk(t, deskID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	feature := fmt.Sprintf("feature-%d", time.Now().UnixNano())
+	if _, err := testDB.Exec(ctx, "UPDATE desks SET features = ARRAY[$1] WHERE id = $2", feature, deskID); err != nil {
+		t.Fatalf("failed to set desk features: %v", err)
+	}
+
+	windowStart := time.Now().AddDate(1, 0, 0).Truncate(time.Hour)
+	east := WingEast
+	closure, err := repo.CreateClosure(ctx, &CreateClosureInput{
+		Wing:      &east,
+		StartTime: windowStart.Add(2 * time.Hour),
+		EndTime:   windowStart.Add(3 * time.Hour),
+		Reason:    "fire drill",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer cleanupTestClosure(t, closure.ID)
+
+	occupancy, err := repo.GetDeskOccupancy(ctx, &AvailabilityFilter{
+		StartTime: windowStart,
+		EndTime:   windowStart.Add(8 * time.Hour),
+		Features:  []string{feature},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(occupancy) != 1 {
+		t.Fatalf("expected 1 desk, got %d", len(occupancy))
+	}
+
+	var closed *Interval
+	for i, interval := range occupancy[0].Closed {
+		if interval.StartTime.Equal(closure.StartTime) && interval.EndTime.Equal(closure.EndTime) {
+			closed = &occupancy[0].Closed[i]
+		}
+	}
+	if closed == nil {
+		t.Errorf("expected the closure to be reported, got %+v", occupancy[0].Closed)
+	}
+	if len(occupancy[0].Busy) != 0 {
+		t.Errorf("expected closures to be kept apart from busy ranges, got %+v", occupancy[0].Busy)
+	}
+}
+
+func cleanupTestOpeningHours(t *testing.T, id int) {
+	t.Helper()
+	if _, err := testDB.Exec(context.Background(), "DELETE FROM opening_hours WHERE id = $1", id); err != nil {
+		t.Errorf("failed to cleanup opening hours: %v", err)
+	}
+}
+
+func TestSetOpeningHours_ReplacesEntry(t *testing.T) {
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	east := WingEast
+	opens, closes := TimeOfDay(7*time.Hour), TimeOfDay(22*time.Hour)
+	first, err := repo.SetOpeningHours(ctx, &SetOpeningHoursInput{Wing: &east, Weekday: time.Saturday, Opens: &opens, Closes: &closes})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer cleanupTestOpeningHours(t, first.ID)
+
+	// Setting the same wing and weekday again replaces the entry; a nil wing is a separate entry
+	closed, err := repo.SetOpeningHours(ctx, &SetOpeningHoursInput{Wing: &east, Weekday: time.Saturday})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if closed.ID != first.ID || closed.Opens != nil || closed.Closes != nil {
+		t.Errorf("expected entry %d to be closed, got %+v", first.ID, closed)
+	}
+	office, err := repo.SetOpeningHours(ctx, &SetOpeningHoursInput{Weekday: time.Saturday, Opens: &opens, Closes: &closes})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer cleanupTestOpeningHours(t, office.ID)
+	if office.ID == first.ID || office.Wing != nil || time.Duration(*office.Opens) != 7*time.Hour {
+		t.Errorf("expected a separate office-wide entry, got %+v", office)
+	}
+
+	settings, err := repo.GetSettings(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := openingHoursOn(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), &east, settings); ok {
+		t.Error("expected the East wing to be closed on Saturdays")
+	}
+
+	if _, err := repo.DeleteOpeningHours(ctx, first.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := repo.DeleteOpeningHours(ctx, first.ID); !errors.Is(err, ErrOpeningHoursNotFound) {
+		t.Errorf("expected ErrOpeningHoursNotFound, got %v", err)
+	}
+}
+
+func cleanupTestQuota(t *testing.T, id int) {
+	t.Helper()
+	if _, err := testDB.Exec(context.Background(), "DELETE FROM booking_quotas WHERE id = $1", id); err != nil {
+		t.Errorf("failed to cleanup quota: %v", err)
+	}
+}
+
+func TestSetQuota_ReplacesEntry(t *testing.T) {
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	hours, bookings := 20, 5
+	first, err := repo.SetQuota(ctx, &SetQuotaInput{Role: roleMember, Period: QuotaWeek, MaxHours: &hours})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer cleanupTestQuota(t, first.ID)
+
+	// Setting the same role and period again replaces the limits
+	replaced, err := repo.SetQuota(ctx, &SetQuotaInput{Role: roleMember, Period: QuotaWeek, MaxBookings: &bookings})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if replaced.ID != first.ID || replaced.MaxHours != nil || replaced.MaxBookings == nil || *replaced.MaxBookings != 5 {
+		t.Errorf("expected quota %d to cap bookings only, got %+v", first.ID, replaced)
+	}
+
+	settings, err := repo.GetSettings(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(settings.Quotas) != 1 || settings.Quotas[0].Period != QuotaWeek {
+		t.Errorf("expected the weekly quota in the settings, got %+v", settings.Quotas)
+	}
+
+	if _, err := repo.DeleteQuota(ctx, first.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := repo.DeleteQuota(ctx, first.ID); !errors.Is(err, ErrQuotaNotFound) {
+		t.Errorf("expected ErrQuotaNotFound, got %v", err)
+	}
+}
+
+func TestGetUserQuotaUsage(t *testing.T) {
+	deskID := setupTestDesk(t)
+	userID := setupTestUser(t)
+	defer cleanupTestDesk(t, deskID)
+	defer cleanupTestUser(t, userID)
+
+	repo := NewRepository(testDB)
+	ctx := context.Background()
+
+	monday := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	ranges := []Interval{
+		{StartTime: monday.Add(-time.Hour), EndTime: monday.Add(time.Hour)}, // Starts in the previous week
+		{StartTime: monday.Add(9 * time.Hour), EndTime: monday.Add(12 * time.Hour)},
+		{StartTime: monday.AddDate(0, 0, 2).Add(9 * time.Hour), EndTime: monday.AddDate(0, 0, 2).Add(10 * time.Hour)},
+	}
+	for _, r := range ranges {
+		booking, err := repo.CreateBooking(ctx, &CreateBookingInput{DeskID: deskID, UserID: userID, StartTime: r.StartTime, EndTime: r.EndTime})
+		if err != nil {
+			t.Fatalf("failed to create booking: %v", err)
+		}
+		defer cleanupTestBooking(t, booking.ID)
+	}
+
+	usage, err := repo.GetUserQuotaUsage(ctx, userID, monday, monday.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if usage.Hours != 5 || usage.Bookings != 2 {
+		t.Errorf("expected 5 hours over 2 bookings starting in the week, got %+v", usage)
+	}
+
+	role, err := repo.GetUserRole(ctx, userID)
+	if err != nil || role != roleMember {
+		t.Errorf("expected the member role, got %q (%v)", role, err)
+	}
+}
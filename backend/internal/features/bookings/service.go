@@ -0,0 +1,1846 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// roleAdmin and roleMember mirror auth.RoleAdmin and auth.RoleMember
+const (
+	roleAdmin  = "admin"
+	roleMember = "member"
+)
+
+// CheckInOpensBefore is how long before a booking starts check-in becomes available
+const CheckInOpensBefore = 15 * time.Minute
+
+// noShowBatchSize caps how many bookings a single sweep transaction marks as no-show
+const noShowBatchSize = 100
+
+// holdBatchSize caps how many expired holds a single sweep transaction releases
+const holdBatchSize = 100
+
+// Reasons recorded for status transitions made without a user-supplied reason
+const (
+	reasonNoShow        = "not checked in within the grace period"
+	reasonCheckOut      = "checked out"
+	reasonHoldExpired   = "hold expired before it was confirmed"
+	reasonHoldConfirmed = "hold confirmed"
+)
+
+var (
+	// ErrInvalidTimeRange is returned when the end time is not after the start time
+	ErrInvalidTimeRange = errors.New("end time must be after start time")
+	// ErrOutsideOpeningHours is returned when a booking falls outside the opening hours
+	ErrOutsideOpeningHours = errors.New("booking is outside opening hours")
+	// ErrDailyLimitExceeded is returned when a booking would exceed the daily hour limit
+	ErrDailyLimitExceeded = errors.New("booking exceeds the daily hour limit")
+	// ErrNotBookingOwner is returned when a member accesses another user's booking
+	ErrNotBookingOwner = errors.New("booking belongs to another user")
+	// ErrBookingNotActive is returned when modifying a booking that is no longer confirmed
+	ErrBookingNotActive = errors.New("booking is no longer active")
+	// ErrBookingStarted is returned when modifying a series occurrence that has already started
+	ErrBookingStarted = errors.New("booking has already started")
+	// ErrNotSeriesBooking is returned when a series edit targets a booking outside any series
+	ErrNotSeriesBooking = errors.New("booking is not part of a series")
+	// ErrInvalidSeriesScope is returned when a series edit uses an unknown scope
+	ErrInvalidSeriesScope = errors.New("invalid series scope")
+	// ErrCheckInNotOpen is returned when checking in earlier than CheckInOpensBefore the start
+	ErrCheckInNotOpen = errors.New("check-in is not open yet")
+	// ErrCheckInClosed is returned when checking in after the grace period has ended
+	ErrCheckInClosed = errors.New("check-in window has closed")
+	// ErrAlreadyCheckedIn is returned when checking in to a booking twice
+	ErrAlreadyCheckedIn = errors.New("booking is already checked in")
+	// ErrNotCheckedIn is returned when checking out of a booking that was never checked in
+	ErrNotCheckedIn = errors.New("booking is not checked in")
+	// ErrInvalidWaitlistTarget is returned when a waitlist entry does not target exactly one desk or wing
+	ErrInvalidWaitlistTarget = errors.New("waitlist entry must target either a desk or a wing")
+	// ErrAdminOnly is returned when a member attempts an operation reserved for admins
+	ErrAdminOnly = errors.New("operation requires the admin role")
+	// ErrInvalidGroup is returned when a group booking's attendee list is malformed
+	ErrInvalidGroup = errors.New("group booking needs 1 to 50 distinct attendees and either a desk for each or a wing")
+	// ErrInvalidTransferRecipient is returned when a booking is offered to its own owner
+	ErrInvalidTransferRecipient = errors.New("a booking cannot be transferred to its owner")
+	// ErrInvalidSwapBooking is returned when a swap offers a booking the recipient does not hold
+	ErrInvalidSwapBooking = errors.New("swap booking must be an upcoming confirmed booking of the recipient")
+	// ErrNotTransferParty is returned when a member answers a transfer addressed to someone else
+	ErrNotTransferParty = errors.New("transfer belongs to other users")
+	// ErrInvalidBulkCancel is returned when a bulk cancellation selects no desks or gives no reason
+	ErrInvalidBulkCancel = errors.New("bulk cancellation needs desk_ids or a wing, and a reason")
+	// ErrInvalidWing is returned when filtering by a wing that does not exist
+	ErrInvalidWing = errors.New("wing must be East or West")
+	// ErrInvalidMaintenance is returned when a maintenance block gives no reason or an unknown policy
+	ErrInvalidMaintenance = errors.New("maintenance needs a reason and a policy of reject, cancel or move")
+	// ErrClosed is returned when a booking overlaps a closure of the office or the desk's wing
+	ErrClosed = errors.New("the office is closed for part of the requested time")
+	// ErrInvalidClosure is returned when a closure gives no reason
+	ErrInvalidClosure = errors.New("closure needs a reason")
+	// ErrInvalidOpeningHours is returned when a schedule entry has no valid weekday or its hours are incomplete or inverted
+	ErrInvalidOpeningHours = errors.New("opening hours need a weekday from 0 to 6 and an opening time before the closing time")
+	// ErrQuotaExceeded is returned when a booking would exceed a weekly or monthly quota of the owner's role
+	ErrQuotaExceeded = errors.New("booking exceeds a weekly or monthly quota")
+	// ErrLeadTimeTooShort is returned when a booking starts sooner than the owner's role may book ahead
+	ErrLeadTimeTooShort = errors.New("booking starts too soon to be booked")
+	// ErrBeyondAdvanceWindow is returned when a booking starts further ahead than the owner's role may book
+	ErrBeyondAdvanceWindow = errors.New("booking starts too far ahead to be booked")
+	// ErrInvalidQuota is returned when a quota has an unknown role or period, or no positive limit
+	ErrInvalidQuota = errors.New("quota needs a role, a period of week or month and a positive hour or booking limit")
+)
+
+// SeriesError is returned when occurrences of a recurring series cannot be booked.
+// Skipped lists every occurrence that failed and the reason it failed.
+type SeriesError struct {
+	Skipped []SkippedOccurrence
+}
+
+// Error implements the error interface
+func (e *SeriesError) Error() string {
+	return fmt.Sprintf("%d occurrence(s) of the series could not be booked", len(e.Skipped))
+}
+
+// GroupError is returned when a group booking cannot be made as a whole.
+// Failures lists every attendee, and desk where known, that could not be booked and why.
+type GroupError struct {
+	Failures []GroupFailure
+}
+
+// Error implements the error interface
+func (e *GroupError) Error() string {
+	return fmt.Sprintf("%d attendee(s) of the group could not be booked", len(e.Failures))
+}
+
+// MaintenanceError is returned when a maintenance block cannot be placed under its policy.
+// Bookings lists the bookings it overlaps that were neither cancelled nor moved.
+type MaintenanceError struct {
+	Policy   MaintenancePolicy
+	Bookings []*Booking
+}
+
+// Error implements the error interface
+func (e *MaintenanceError) Error() string {
+	return fmt.Sprintf("maintenance overlaps %d booking(s) that could not be %s", len(e.Bookings), maintenanceOutcome(e.Policy))
+}
+
+// maintenanceOutcome describes what a policy does to an overlapping booking
+func maintenanceOutcome(policy MaintenancePolicy) string {
+	switch policy {
+	case MaintenanceCancel:
+		return "cancelled"
+	case MaintenanceMove:
+		return "moved"
+	}
+	return "kept"
+}
+
+// QuotaError is returned when a booking would exceed Quota, a weekly or monthly quota of the
+// owner's role; errors.Is(err, ErrQuotaExceeded) still holds.
+type QuotaError struct {
+	Quota *Quota
+}
+
+// Error implements the error interface
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrQuotaExceeded, e.Quota.Period)
+}
+
+// Unwrap returns ErrQuotaExceeded
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// BookingWindowError is returned when a booking starts outside Window, the booking window of the
+// owner's role. Err is ErrLeadTimeTooShort or ErrBeyondAdvanceWindow, and errors.Is matches it.
+type BookingWindowError struct {
+	Err    error
+	Window BookingWindow
+}
+
+// Error implements the error interface
+func (e *BookingWindowError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns Err
+func (e *BookingWindowError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError is returned when a booking conflicts with an existing reservation.
+// Suggestions lists alternative free slots; errors.Is(err, ErrBookingConflict) still holds.
+type ConflictError struct {
+	Suggestions []Suggestion
+}
+
+// Error implements the error interface
+func (e *ConflictError) Error() string {
+	return ErrBookingConflict.Error()
+}
+
+// Unwrap returns ErrBookingConflict
+func (e *ConflictError) Unwrap() error {
+	return ErrBookingConflict
+}
+
+// RepositoryInterface defines the methods required from the repository
+type RepositoryInterface interface {
+	CreateBooking(ctx context.Context, input *CreateBookingInput) (*Booking, error)
+	GetBookingByID(ctx context.Context, id int) (*Booking, error)
+	GetUserBookings(ctx context.Context, filter *BookingFilter) (*BookingPage, error)
+	UpdateBooking(ctx context.Context, id int, input *UpdateBookingInput) (*Booking, error)
+	DeleteBooking(ctx context.Context, id int, change StatusChange) error
+	GetUserDailyHours(ctx context.Context, userID string, date time.Time) (float64, error)
+	GetSettings(ctx context.Context) (*Settings, error)
+	CreateSeries(ctx context.Context, input *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error)
+	GetSeriesBookings(ctx context.Context, seriesID int, from time.Time) ([]*Booking, error)
+	RescheduleBookings(ctx context.Context, changes []BookingReschedule) (*RescheduleResult, error)
+	CancelSeriesBookings(ctx context.Context, seriesID int, from time.Time, change StatusChange) ([]*Booking, error)
+	GetBulkCancelBookings(ctx context.Context, input *BulkCancelInput) ([]*Booking, error)
+	BulkCancelBookings(ctx context.Context, input *BulkCancelInput, change StatusChange) ([]*Booking, error)
+	CreateWaitlistEntry(ctx context.Context, input *JoinWaitlistInput) (*WaitlistEntry, error)
+	GetWaitlistEntryByID(ctx context.Context, id int) (*WaitlistEntry, error)
+	GetUserWaitlistEntries(ctx context.Context, userID string) ([]*WaitlistEntry, error)
+	CancelWaitlistEntry(ctx context.Context, id int) (*WaitlistEntry, error)
+	CheckInBooking(ctx context.Context, id int, at time.Time) (*Booking, error)
+	MarkNoShows(ctx context.Context, gracePeriodMinutes, limit int, change StatusChange) ([]*Booking, error)
+	ConfirmHold(ctx context.Context, id int, change StatusChange) (*Booking, error)
+	ExpireHolds(ctx context.Context, limit int, change StatusChange) ([]*Booking, error)
+	CheckOutBooking(ctx context.Context, id int, actualEnd time.Time, durationMinutes int, change StatusChange) (*Booking, error)
+	MoveBooking(ctx context.Context, id int, move BookingMove) (*Booking, error)
+	CreateGroup(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error)
+	CreateTransfer(ctx context.Context, fromUserID string, input *OfferTransferInput) (*BookingTransfer, error)
+	GetTransferByID(ctx context.Context, id int) (*BookingTransfer, error)
+	GetUserTransfers(ctx context.Context, userID string) ([]*BookingTransfer, error)
+	AcceptTransfer(ctx context.Context, id int) (*TransferResult, error)
+	CloseTransfer(ctx context.Context, id int, status TransferStatus) (*BookingTransfer, error)
+	GetDesk(ctx context.Context, id int) (*Desk, error)
+	GetDeskBookingsByTimeRange(ctx context.Context, wing *string, startTime, endTime time.Time) ([]*DeskBookings, error)
+	GetDeskOccupancy(ctx context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error)
+	CreateMaintenanceBlock(ctx context.Context, input *CreateMaintenanceInput) (*MaintenanceInsertResult, error)
+	GetMaintenanceBlocks(ctx context.Context, filter *MaintenanceFilter) ([]*MaintenanceBlock, error)
+	DeleteMaintenanceBlock(ctx context.Context, id int) (*MaintenanceBlock, error)
+	CreateClosure(ctx context.Context, input *CreateClosureInput) (*Closure, error)
+	GetClosures(ctx context.Context, filter *ClosureFilter) ([]*Closure, error)
+	DeleteClosure(ctx context.Context, id int) (*Closure, error)
+	SetOpeningHours(ctx context.Context, input *SetOpeningHoursInput) (*OpeningHours, error)
+	DeleteOpeningHours(ctx context.Context, id int) (*OpeningHours, error)
+	SetQuota(ctx context.Context, input *SetQuotaInput) (*Quota, error)
+	DeleteQuota(ctx context.Context, id int) (*Quota, error)
+	GetUserRole(ctx context.Context, userID string) (string, error)
+	GetUserQuotaUsage(ctx context.Context, userID string, startTime, endTime time.Time) (*QuotaUsage, error)
+}
+
+// Actor identifies the authenticated user performing an operation
+type Actor struct {
+	UserID string
+	Role   string
+}
+
+// IsAdmin reports whether the actor has the admin role
+func (a Actor) IsAdmin() bool {
+	return a.Role == roleAdmin
+}
+
+// Service provides booking business logic and enforces the settings policies
+type Service struct {
+	repo RepositoryInterface
+	now  func() time.Time
+}
+
+// NewService creates a new bookings service
+func NewService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// CreateBooking validates the booking against the settings policies and creates it.
+// A conflict is returned as a *ConflictError carrying alternative slots.
+func (s *Service) CreateBooking(ctx context.Context, input *CreateBookingInput) (*Booking, error) {
+	desk, err := s.repo.GetDesk(ctx, input.DeskID)
+	if err != nil {
+		return nil, err
+	}
+	if desk.Status != deskStatusAvailable {
+		return nil, ErrDeskNotAvailable
+	}
+	policy, err := s.loadPolicyCheck(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePolicies(ctx, policy, &desk.Wing, input.StartTime, input.EndTime, nil); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotClosed(ctx, &input.DeskID, nil, input.StartTime, input.EndTime); err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repo.CreateBooking(ctx, input)
+	if errors.Is(err, ErrBookingConflict) {
+		suggestions, suggestErr := s.SuggestSlots(ctx, input.DeskID, input.StartTime, input.EndTime)
+		if suggestErr != nil {
+			return nil, err
+		}
+		return nil, &ConflictError{Suggestions: suggestions}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// CreateRecurringBooking expands the series' RRULE and books every occurrence in one transaction.
+// By default the series is all-or-nothing; with input.Partial only the free occurrences are booked
+// and the rest are reported as skipped. Occurrences that fall in a closure are always skipped and
+// reported, without failing the series.
+func (s *Service) CreateRecurringBooking(ctx context.Context, input *CreateSeriesInput) (*SeriesResult, error) {
+	if !input.EndTime.After(input.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	rule, err := ParseRRule(input.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expand in the site timezone so that occurrences keep their local time across DST changes
+	start := input.StartTime.In(settings.location())
+	input.ExDates = anchorExDates(input.ExDates, input.StartTime, settings.location())
+	occurrences, err := rule.Expand(start, input.EndTime.Sub(input.StartTime), input.ExDates)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, fmt.Errorf("%w: rule produces no occurrences", ErrInvalidRecurrence)
+	}
+
+	desk, err := s.repo.GetDesk(ctx, input.DeskID)
+	if err != nil {
+		return nil, err
+	}
+
+	last := occurrences[len(occurrences)-1]
+	closed, err := s.closedIntervals(ctx, &ClosureFilter{DeskID: &input.DeskID}, occurrences[0].StartTime, last.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.newPolicyCheck(ctx, settings, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate each occurrence against the settings policies before touching the bookings table;
+	// occurrences count towards the quotas of the periods they fall in as they are accepted
+	var bookable []Occurrence
+	var skipped, closures []SkippedOccurrence
+	for _, occurrence := range occurrences {
+		if overlapsAny(Interval{StartTime: occurrence.StartTime, EndTime: occurrence.EndTime}, closed) {
+			closures = append(closures, SkippedOccurrence{
+				StartTime: occurrence.StartTime,
+				EndTime:   occurrence.EndTime,
+				Reason:    ErrCodeClosed,
+			})
+			continue
+		}
+
+		err := s.validatePolicies(ctx, policy, &desk.Wing, occurrence.StartTime, occurrence.EndTime, nil)
+		if err == nil {
+			bookable = append(bookable, occurrence)
+			continue
+		}
+		reason, ok := skipReason(err)
+		if !ok {
+			return nil, err
+		}
+		skipped = append(skipped, SkippedOccurrence{
+			StartTime: occurrence.StartTime,
+			EndTime:   occurrence.EndTime,
+			Reason:    reason,
+		})
+	}
+
+	if len(bookable) == 0 || (len(skipped) > 0 && !input.Partial) {
+		return nil, &SeriesError{Skipped: sortSkipped(append(skipped, closures...))}
+	}
+	skipped = append(skipped, closures...)
+
+	inserted, err := s.repo.CreateSeries(ctx, input, bookable)
+	if inserted != nil {
+		for _, conflict := range inserted.Conflicts {
+			skipped = append(skipped, SkippedOccurrence{
+				StartTime: conflict.StartTime,
+				EndTime:   conflict.EndTime,
+				Reason:    ErrCodeBookingConflict,
+			})
+		}
+	}
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return nil, &SeriesError{Skipped: sortSkipped(skipped)}
+		}
+		return nil, err
+	}
+
+	if skipped == nil {
+		skipped = []SkippedOccurrence{}
+	}
+
+	return &SeriesResult{
+		Series:   inserted.Series,
+		Bookings: inserted.Bookings,
+		Skipped:  sortSkipped(skipped),
+	}, nil
+}
+
+// CreateGroupBooking books one desk per attendee for the same window, all or nothing. Attendees
+// either name their desks, or a wing is given and the closest-together free desks in it are
+// picked. Every attendee is checked against their own booking window, daily hour limit and
+// quotas. When anything fails the returned *GroupError reports each failing attendee and desk and
+// nothing is booked.
+func (s *Service) CreateGroupBooking(ctx context.Context, input *CreateGroupInput) (*GroupResult, error) {
+	if !input.EndTime.After(input.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	if input.Wing != nil && !isValidWing(*input.Wing) {
+		return nil, ErrInvalidWing
+	}
+	if err := validateAttendees(input); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if input.Wing != nil && !withinOpeningHours(input.StartTime, input.EndTime, input.Wing, settings) {
+		return nil, ErrOutsideOpeningHours
+	}
+
+	closures, err := s.repo.GetClosures(ctx, &ClosureFilter{Wing: input.Wing, StartTime: &input.StartTime, EndTime: &input.EndTime})
+	if err != nil {
+		return nil, err
+	}
+	if input.Wing != nil && len(closures) > 0 {
+		return nil, ErrClosed
+	}
+
+	var failures []GroupFailure
+	for _, attendee := range input.Attendees {
+		err := s.checkAttendeePolicies(ctx, settings, attendee.UserID, input.StartTime, input.EndTime)
+		if err == nil {
+			continue
+		}
+		reason, ok := skipReason(err)
+		if !ok {
+			return nil, err
+		}
+		failures = append(failures, GroupFailure{UserID: attendee.UserID, DeskID: attendee.DeskID, Reason: reason})
+	}
+
+	assignments := make([]GroupAssignment, 0, len(input.Attendees))
+	if input.Wing == nil {
+		for _, attendee := range input.Attendees {
+			desk, err := s.repo.GetDesk(ctx, *attendee.DeskID)
+			switch {
+			case errors.Is(err, ErrDeskNotFound):
+				failures = append(failures, GroupFailure{UserID: attendee.UserID, DeskID: attendee.DeskID, Reason: ErrCodeDeskNotFound})
+				continue
+			case err != nil:
+				return nil, err
+			case desk.Status != deskStatusAvailable:
+				failures = append(failures, GroupFailure{UserID: attendee.UserID, DeskID: attendee.DeskID, Reason: ErrCodeDeskNotAvailable})
+				continue
+			case !withinOpeningHours(input.StartTime, input.EndTime, &desk.Wing, settings):
+				failures = append(failures, GroupFailure{UserID: attendee.UserID, DeskID: attendee.DeskID, Reason: ErrCodeOutsideOpeningHours})
+				continue
+			case closesWing(closures, desk.Wing):
+				failures = append(failures, GroupFailure{UserID: attendee.UserID, DeskID: attendee.DeskID, Reason: ErrCodeClosed})
+				continue
+			}
+			assignments = append(assignments, GroupAssignment{UserID: attendee.UserID, DeskID: desk.ID})
+		}
+	} else {
+		occupancy, err := s.repo.GetDeskOccupancy(ctx, &AvailabilityFilter{
+			StartTime: input.StartTime,
+			EndTime:   input.EndTime,
+			Wing:      input.Wing,
+			Features:  input.Features,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		window := Interval{StartTime: input.StartTime, EndTime: input.EndTime}
+		desks := pickAdjacentDesks(occupancy, window, len(input.Attendees))
+		for i, attendee := range input.Attendees {
+			if i >= len(desks) {
+				failures = append(failures, GroupFailure{UserID: attendee.UserID, Reason: ErrCodeNoDeskAvailable})
+				continue
+			}
+			assignments = append(assignments, GroupAssignment{UserID: attendee.UserID, DeskID: desks[i].ID})
+		}
+	}
+
+	if len(failures) > 0 {
+		return nil, &GroupError{Failures: failures}
+	}
+
+	inserted, err := s.repo.CreateGroup(ctx, input, assignments)
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) && inserted != nil {
+			for _, conflict := range inserted.Conflicts {
+				failures = append(failures, GroupFailure{UserID: conflict.UserID, DeskID: &conflict.DeskID, Reason: ErrCodeBookingConflict})
+			}
+			for _, missing := range inserted.MissingUsers {
+				failures = append(failures, GroupFailure{UserID: missing.UserID, DeskID: &missing.DeskID, Reason: ErrCodeUserNotFound})
+			}
+			return nil, &GroupError{Failures: failures}
+		}
+		return nil, err
+	}
+
+	return &GroupResult{Group: inserted.Group, Bookings: inserted.Bookings}, nil
+}
+
+// validateAttendees checks that a group lists each attendee once, within maxGroupSize, and that
+// desks are named for every attendee, without repeats, unless a wing is given.
+func validateAttendees(input *CreateGroupInput) error {
+	if len(input.Attendees) == 0 || len(input.Attendees) > maxGroupSize {
+		return ErrInvalidGroup
+	}
+
+	users := make(map[string]bool, len(input.Attendees))
+	desks := make(map[int]bool, len(input.Attendees))
+	for _, attendee := range input.Attendees {
+		if attendee.UserID == "" || users[attendee.UserID] {
+			return ErrInvalidGroup
+		}
+		users[attendee.UserID] = true
+
+		if input.Wing != nil {
+			if attendee.DeskID != nil {
+				return ErrInvalidGroup
+			}
+			continue
+		}
+		if attendee.DeskID == nil || *attendee.DeskID <= 0 || desks[*attendee.DeskID] {
+			return ErrInvalidGroup
+		}
+		desks[*attendee.DeskID] = true
+	}
+
+	return nil
+}
+
+// GetBooking retrieves a booking the actor is allowed to see
+func (s *Service) GetBooking(ctx context.Context, actor Actor, id int) (*Booking, error) {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.IsAdmin() && booking.UserID != actor.UserID {
+		return nil, ErrNotBookingOwner
+	}
+
+	return booking, nil
+}
+
+// ListBookings retrieves a page of bookings matching the filter.
+// Members are always restricted to their own bookings.
+func (s *Service) ListBookings(ctx context.Context, actor Actor, filter *BookingFilter) (*BookingPage, error) {
+	if !actor.IsAdmin() {
+		filter.UserID = &actor.UserID
+	}
+
+	return s.repo.GetUserBookings(ctx, filter)
+}
+
+// UpdateBooking reschedules a confirmed booking, re-validating the settings policies
+func (s *Service) UpdateBooking(ctx context.Context, actor Actor, id int, input *UpdateBookingInput) (*Booking, error) {
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != StatusConfirmed {
+		return nil, ErrBookingNotActive
+	}
+	if booking.SeriesID != nil && !booking.StartTime.After(s.now()) {
+		return nil, ErrBookingStarted
+	}
+
+	startTime := booking.StartTime
+	if input.StartTime != nil {
+		startTime = *input.StartTime
+	}
+	endTime := booking.EndTime
+	if input.EndTime != nil {
+		endTime = *input.EndTime
+	}
+
+	desk, err := s.repo.GetDesk(ctx, booking.DeskID)
+	if err != nil {
+		return nil, err
+	}
+	if desk.Status != deskStatusAvailable {
+		return nil, ErrDeskNotAvailable
+	}
+	policy, err := s.loadPolicyCheck(ctx, booking.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePolicies(ctx, policy, &desk.Wing, startTime, endTime, booking); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotClosed(ctx, &booking.DeskID, nil, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateBooking(ctx, id, input)
+}
+
+// MoveBooking moves a confirmed booking to another desk and/or time atomically: either the booking
+// ends up at its destination or it is left untouched. The settings policies are re-validated for
+// the booking's owner and bookings that have started cannot be moved, unless an admin forces the move.
+func (s *Service) MoveBooking(ctx context.Context, actor Actor, id int, input *MoveBookingInput) (*Booking, error) {
+	if input.Force && !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != StatusConfirmed {
+		return nil, ErrBookingNotActive
+	}
+
+	move := BookingMove{
+		DeskID:    booking.DeskID,
+		StartTime: booking.StartTime,
+		EndTime:   booking.EndTime,
+		ActorID:   &actor.UserID,
+		Reason:    input.Reason,
+		Forced:    input.Force,
+	}
+	if input.DeskID != nil {
+		move.DeskID = *input.DeskID
+	}
+	if input.StartTime != nil {
+		move.StartTime = *input.StartTime
+	}
+	if input.EndTime != nil {
+		move.EndTime = *input.EndTime
+	}
+	if !move.EndTime.After(move.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	if !input.Force {
+		if !booking.StartTime.After(s.now()) {
+			return nil, ErrBookingStarted
+		}
+		desk, err := s.repo.GetDesk(ctx, move.DeskID)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := s.loadPolicyCheck(ctx, booking.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.validatePolicies(ctx, policy, &desk.Wing, move.StartTime, move.EndTime, booking); err != nil {
+			return nil, err
+		}
+		if err := s.checkNotClosed(ctx, &move.DeskID, nil, move.StartTime, move.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.MoveBooking(ctx, id, move)
+}
+
+// CancelBooking cancels a booking and returns its updated state.
+// Cancelling an already cancelled booking is a no-op; reason is recorded with the transition.
+func (s *Service) CancelBooking(ctx context.Context, actor Actor, id int, reason string) (*Booking, error) {
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status == StatusCancelled {
+		return booking, nil
+	}
+	if err := checkTransition(booking.Status, StatusCancelled); err != nil {
+		return nil, err
+	}
+
+	// Past occurrences of a series are kept as history
+	if booking.SeriesID != nil && !booking.StartTime.After(s.now()) {
+		return nil, ErrBookingStarted
+	}
+
+	if err := s.repo.DeleteBooking(ctx, id, StatusChange{ActorID: &actor.UserID, Reason: reason}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBookingByID(ctx, id)
+}
+
+// BulkCancelBookings lets an admin cancel every held or confirmed booking on the chosen desks that
+// overlaps the window, e.g. when a wing closes. With input.DryRun the bookings are only listed.
+func (s *Service) BulkCancelBookings(ctx context.Context, actor Actor, input *BulkCancelInput) (*BulkCancelResult, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	if (len(input.DeskIDs) == 0 && input.Wing == nil) || strings.TrimSpace(input.Reason) == "" {
+		return nil, ErrInvalidBulkCancel
+	}
+	if input.Wing != nil && !isValidWing(*input.Wing) {
+		return nil, ErrInvalidWing
+	}
+
+	var bookings []*Booking
+	var err error
+	if input.DryRun {
+		bookings, err = s.repo.GetBulkCancelBookings(ctx, input)
+	} else {
+		bookings, err = s.repo.BulkCancelBookings(ctx, input, StatusChange{ActorID: &actor.UserID, Reason: input.Reason})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bookings == nil {
+		bookings = []*Booking{}
+	}
+
+	return &BulkCancelResult{DryRun: input.DryRun, Count: len(bookings), Bookings: sortBookings(bookings)}, nil
+}
+
+// CreateMaintenanceBlock lets an admin take a desk out of service for a time range. Bookings the
+// block overlaps are handled by input.Policy, MaintenanceReject by default; when the policy cannot
+// clear them all, nothing is changed and a *MaintenanceError lists the bookings in the way.
+func (s *Service) CreateMaintenanceBlock(ctx context.Context, actor Actor, input *CreateMaintenanceInput) (*MaintenanceResult, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	if input.Policy == "" {
+		input.Policy = MaintenanceReject
+	}
+	if strings.TrimSpace(input.Reason) == "" || !input.Policy.IsValid() {
+		return nil, ErrInvalidMaintenance
+	}
+	input.CreatedBy = actor.UserID
+
+	inserted, err := s.repo.CreateMaintenanceBlock(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) && inserted != nil {
+			return nil, &MaintenanceError{Policy: input.Policy, Bookings: sortBookings(inserted.Conflicts)}
+		}
+		return nil, err
+	}
+
+	result := &MaintenanceResult{Block: inserted.Block, Cancelled: inserted.Cancelled, Moved: inserted.Moved}
+	if result.Cancelled == nil {
+		result.Cancelled = []*Booking{}
+	}
+	if result.Moved == nil {
+		result.Moved = []*Booking{}
+	}
+	return result, nil
+}
+
+// ListMaintenanceBlocks lists maintenance blocks, optionally for one desk and a time range
+func (s *Service) ListMaintenanceBlocks(ctx context.Context, filter *MaintenanceFilter) ([]*MaintenanceBlock, error) {
+	if filter.StartTime != nil && filter.EndTime != nil && !filter.EndTime.After(*filter.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	return s.repo.GetMaintenanceBlocks(ctx, filter)
+}
+
+// DeleteMaintenanceBlock lets an admin end a maintenance block, returning the desk to service.
+// Bookings cancelled or moved when the block was created are not restored.
+func (s *Service) DeleteMaintenanceBlock(ctx context.Context, actor Actor, id int) (*MaintenanceBlock, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	return s.repo.DeleteMaintenanceBlock(ctx, id)
+}
+
+// CreateClosure lets an admin close the office, or one wing of it, for whole days or part of a day.
+// Whole days run from midnight to midnight in the site timezone. Bookings already in the range are
+// kept; new ones are refused and the range is hidden from availability.
+func (s *Service) CreateClosure(ctx context.Context, actor Actor, input *CreateClosureInput) (*Closure, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	if input.Wing != nil && !isValidWing(*input.Wing) {
+		return nil, ErrInvalidWing
+	}
+	if strings.TrimSpace(input.Reason) == "" {
+		return nil, ErrInvalidClosure
+	}
+
+	if input.Date != nil {
+		settings, err := s.repo.GetSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lastDay := *input.Date
+		if input.EndDate != nil {
+			lastDay = *input.EndDate
+		}
+		input.StartTime = dateIn(*input.Date, settings.location())
+		input.EndTime = dateIn(lastDay, settings.location()).AddDate(0, 0, 1)
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	input.CreatedBy = actor.UserID
+
+	return s.repo.CreateClosure(ctx, input)
+}
+
+// ListClosures lists closures, optionally those that apply to a wing and overlap a time range
+func (s *Service) ListClosures(ctx context.Context, filter *ClosureFilter) ([]*Closure, error) {
+	if filter.Wing != nil && !isValidWing(*filter.Wing) {
+		return nil, ErrInvalidWing
+	}
+	if filter.StartTime != nil && filter.EndTime != nil && !filter.EndTime.After(*filter.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	return s.repo.GetClosures(ctx, filter)
+}
+
+// DeleteClosure lets an admin remove a closure, reopening its range for bookings
+func (s *Service) DeleteClosure(ctx context.Context, actor Actor, id int) (*Closure, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	return s.repo.DeleteClosure(ctx, id)
+}
+
+// SetOpeningHours lets an admin set a weekday's opening hours for the whole office or one wing
+func (s *Service) SetOpeningHours(ctx context.Context, actor Actor, input *SetOpeningHoursInput) (*OpeningHours, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	if input.Wing != nil && !isValidWing(*input.Wing) {
+		return nil, ErrInvalidWing
+	}
+	if input.Weekday < time.Sunday || input.Weekday > time.Saturday {
+		return nil, ErrInvalidOpeningHours
+	}
+	if (input.Opens == nil) != (input.Closes == nil) {
+		return nil, ErrInvalidOpeningHours
+	}
+	if input.Opens != nil && (*input.Opens < 0 || *input.Opens >= *input.Closes || time.Duration(*input.Closes) > 24*time.Hour) {
+		return nil, ErrInvalidOpeningHours
+	}
+
+	return s.repo.SetOpeningHours(ctx, input)
+}
+
+// ListOpeningHours lists the weekly opening schedule, office-wide entries before wing overrides
+func (s *Service) ListOpeningHours(ctx context.Context) ([]*OpeningHours, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Schedule == nil {
+		return []*OpeningHours{}, nil
+	}
+	return settings.Schedule, nil
+}
+
+// DeleteOpeningHours lets an admin remove a schedule entry, returning its weekday to the office-wide
+// entry or the default opening hours
+func (s *Service) DeleteOpeningHours(ctx context.Context, actor Actor, id int) (*OpeningHours, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	return s.repo.DeleteOpeningHours(ctx, id)
+}
+
+// SetQuota lets an admin set a role's weekly or monthly quota, replacing any it already has
+func (s *Service) SetQuota(ctx context.Context, actor Actor, input *SetQuotaInput) (*Quota, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	if !isValidRole(input.Role) || !input.Period.IsValid() {
+		return nil, ErrInvalidQuota
+	}
+	if input.MaxHours == nil && input.MaxBookings == nil {
+		return nil, ErrInvalidQuota
+	}
+	if (input.MaxHours != nil && *input.MaxHours <= 0) || (input.MaxBookings != nil && *input.MaxBookings <= 0) {
+		return nil, ErrInvalidQuota
+	}
+
+	return s.repo.SetQuota(ctx, input)
+}
+
+// ListQuotas lists the quotas of every role
+func (s *Service) ListQuotas(ctx context.Context) ([]*Quota, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Quotas == nil {
+		return []*Quota{}, nil
+	}
+	return settings.Quotas, nil
+}
+
+// DeleteQuota lets an admin remove a quota, leaving the role uncapped for that period
+func (s *Service) DeleteQuota(ctx context.Context, actor Actor, id int) (*Quota, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrAdminOnly
+	}
+	return s.repo.DeleteQuota(ctx, id)
+}
+
+// GetQuotaStatus reports the actor's usage and remaining allowance under each quota of their role
+// for the current week and month
+func (s *Service) GetQuotaStatus(ctx context.Context, actor Actor) ([]*QuotaStatus, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quotas, err := s.userQuotaTracker(ctx, settings, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	statuses := make([]*QuotaStatus, 0, len(quotas.quotas))
+	for _, quota := range quotas.quotas {
+		periodStart, periodEnd := quotaPeriodBounds(quota.Period, now, settings.location())
+		usage, err := quotas.usageIn(ctx, quota.Period, periodStart, periodEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		status := &QuotaStatus{
+			Period:       quota.Period,
+			PeriodStart:  periodStart,
+			PeriodEnd:    periodEnd,
+			MaxHours:     quota.MaxHours,
+			UsedHours:    usage.Hours,
+			MaxBookings:  quota.MaxBookings,
+			UsedBookings: usage.Bookings,
+		}
+		if quota.MaxHours != nil {
+			remaining := max(float64(*quota.MaxHours)-usage.Hours, 0)
+			status.RemainingHours = &remaining
+		}
+		if quota.MaxBookings != nil {
+			remaining := max(*quota.MaxBookings-usage.Bookings, 0)
+			status.RemainingBookings = &remaining
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// CheckIn records that the booking's owner has arrived. Check-in opens CheckInOpensBefore the
+// start and closes when the settings' check-in grace period after the start has passed.
+func (s *Service) CheckIn(ctx context.Context, actor Actor, id int) (*Booking, error) {
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != StatusConfirmed {
+		return nil, ErrBookingNotActive
+	}
+	if booking.CheckedInAt != nil {
+		return nil, ErrAlreadyCheckedIn
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if now.Before(booking.StartTime.Add(-CheckInOpensBefore)) {
+		return nil, ErrCheckInNotOpen
+	}
+	if now.After(booking.StartTime.Add(time.Duration(settings.CheckInGracePeriodMinutes) * time.Minute)) {
+		return nil, ErrCheckInClosed
+	}
+
+	return s.repo.CheckInBooking(ctx, id, now)
+}
+
+// CheckOut completes a checked-in booking now. Leaving before the end frees the rest of the
+// booked window for others while start_time/end_time keep the original window as history.
+func (s *Service) CheckOut(ctx context.Context, actor Actor, id int) (*Booking, error) {
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTransition(booking.Status, StatusCompleted); err != nil {
+		return nil, err
+	}
+	if booking.CheckedInAt == nil {
+		return nil, ErrNotCheckedIn
+	}
+
+	// Clamp to the booked window: checking out late never extends the booking
+	actualEnd := s.now()
+	if actualEnd.After(booking.EndTime) {
+		actualEnd = booking.EndTime
+	}
+	if actualEnd.Before(booking.StartTime) {
+		actualEnd = booking.StartTime
+	}
+
+	usedFrom := booking.StartTime
+	if booking.CheckedInAt.After(usedFrom) {
+		usedFrom = *booking.CheckedInAt
+	}
+	durationMinutes := 0
+	if actualEnd.After(usedFrom) {
+		durationMinutes = int(actualEnd.Sub(usedFrom).Minutes())
+	}
+
+	return s.repo.CheckOutBooking(ctx, id, actualEnd, durationMinutes, StatusChange{ActorID: &actor.UserID, Reason: reasonCheckOut})
+}
+
+// SweepNoShows marks every booking whose check-in grace period has passed without a check-in
+// as no-show, freeing the desk. It returns how many bookings were marked.
+func (s *Service) SweepNoShows(ctx context.Context) (int, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		marked, err := s.repo.MarkNoShows(ctx, settings.CheckInGracePeriodMinutes, noShowBatchSize, StatusChange{Reason: reasonNoShow})
+		if err != nil {
+			return total, err
+		}
+		total += len(marked)
+		if len(marked) < noShowBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// ConfirmHold turns the actor's tentative hold into a confirmed booking.
+// Holds past their expiry return ErrHoldExpired, even if the sweeper has not released them yet.
+func (s *Service) ConfirmHold(ctx context.Context, actor Actor, id int) (*Booking, error) {
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTransition(booking.Status, StatusConfirmed); err != nil {
+		return nil, err
+	}
+	if booking.HoldExpiresAt != nil && !booking.HoldExpiresAt.After(s.now()) {
+		return nil, ErrHoldExpired
+	}
+
+	return s.repo.ConfirmHold(ctx, id, StatusChange{ActorID: &actor.UserID, Reason: reasonHoldConfirmed})
+}
+
+// SweepExpiredHolds releases every hold whose expiry has passed without a confirmation,
+// offering the freed slots to the waitlist. It returns how many holds were released.
+func (s *Service) SweepExpiredHolds(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		expired, err := s.repo.ExpireHolds(ctx, holdBatchSize, StatusChange{Reason: reasonHoldExpired})
+		if err != nil {
+			return total, err
+		}
+		total += len(expired)
+		if len(expired) < holdBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// UpdateSeriesBookings reschedules the selected occurrence of a series together with the later
+// (ScopeFollowing) or all upcoming (ScopeAll) occurrences. Every occurrence is shifted by the same
+// offset as the selected one and takes its new duration. Policies and conflicts are re-checked for
+// every occurrence and the change is all-or-nothing; past, completed and no-show occurrences never change.
+func (s *Service) UpdateSeriesBookings(ctx context.Context, actor Actor, id int, scope SeriesScope, input *UpdateBookingInput) ([]*Booking, error) {
+	if scope == ScopeThis {
+		booking, err := s.UpdateBooking(ctx, actor, id, input)
+		if err != nil {
+			return nil, err
+		}
+		return []*Booking{booking}, nil
+	}
+
+	selected, occurrences, err := s.seriesOccurrences(ctx, actor, id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	newStart := selected.StartTime
+	if input.StartTime != nil {
+		newStart = *input.StartTime
+	}
+	newEnd := selected.EndTime
+	if input.EndTime != nil {
+		newEnd = *input.EndTime
+	}
+	if !newEnd.After(newStart) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	shift := newStart.Sub(selected.StartTime)
+	duration := newEnd.Sub(newStart)
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.newPolicyCheck(ctx, settings, selected.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]BookingReschedule, 0, len(occurrences))
+	wings := make(map[int]*string)
+	var skipped []SkippedOccurrence
+	for _, occurrence := range occurrences {
+		change := BookingReschedule{
+			BookingID: occurrence.ID,
+			StartTime: occurrence.StartTime.Add(shift),
+			EndTime:   occurrence.StartTime.Add(shift).Add(duration),
+		}
+
+		wing, err := s.deskWing(ctx, wings, occurrence.DeskID)
+		if err != nil {
+			return nil, err
+		}
+		err = s.validatePolicies(ctx, policy, wing, change.StartTime, change.EndTime, occurrence)
+		if err == nil {
+			err = s.checkNotClosed(ctx, &occurrence.DeskID, nil, change.StartTime, change.EndTime)
+		}
+		if err != nil {
+			reason, ok := skipReason(err)
+			if !ok {
+				return nil, err
+			}
+			skipped = append(skipped, SkippedOccurrence{StartTime: change.StartTime, EndTime: change.EndTime, Reason: reason})
+			continue
+		}
+		changes = append(changes, change)
+	}
+	if len(skipped) > 0 {
+		return nil, &SeriesError{Skipped: skipped}
+	}
+
+	// Move occurrences in the direction of the shift so none overlaps a sibling that has not moved yet
+	if shift > 0 {
+		for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+			changes[i], changes[j] = changes[j], changes[i]
+		}
+	}
+
+	result, err := s.repo.RescheduleBookings(ctx, changes)
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) && result != nil {
+			for _, conflict := range result.Conflicts {
+				skipped = append(skipped, SkippedOccurrence{
+					StartTime: conflict.StartTime,
+					EndTime:   conflict.EndTime,
+					Reason:    ErrCodeBookingConflict,
+				})
+			}
+			return nil, &SeriesError{Skipped: sortSkipped(skipped)}
+		}
+		return nil, err
+	}
+
+	return sortBookings(result.Bookings), nil
+}
+
+// CancelSeriesBookings cancels the selected occurrence of a series together with the later
+// (ScopeFollowing) or all upcoming (ScopeAll) occurrences. Occurrences that have already
+// started are kept as history. reason is recorded with every transition.
+func (s *Service) CancelSeriesBookings(ctx context.Context, actor Actor, id int, scope SeriesScope, reason string) ([]*Booking, error) {
+	if scope == ScopeThis {
+		booking, err := s.CancelBooking(ctx, actor, id, reason)
+		if err != nil {
+			return nil, err
+		}
+		return []*Booking{booking}, nil
+	}
+
+	selected, occurrences, err := s.seriesOccurrences(ctx, actor, id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	from := selected.StartTime
+	if len(occurrences) > 0 {
+		from = occurrences[0].StartTime
+	}
+
+	cancelled, err := s.repo.CancelSeriesBookings(ctx, *selected.SeriesID, from, StatusChange{ActorID: &actor.UserID, Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+	if cancelled == nil {
+		cancelled = []*Booking{}
+	}
+
+	return sortBookings(cancelled), nil
+}
+
+// seriesOccurrences loads the selected booking and the upcoming confirmed occurrences of its
+// series that fall within the scope, in chronological order
+func (s *Service) seriesOccurrences(ctx context.Context, actor Actor, id int, scope SeriesScope) (*Booking, []*Booking, error) {
+	if scope != ScopeFollowing && scope != ScopeAll {
+		return nil, nil, ErrInvalidSeriesScope
+	}
+
+	selected, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if selected.SeriesID == nil {
+		return nil, nil, ErrNotSeriesBooking
+	}
+	if selected.Status != StatusConfirmed {
+		return nil, nil, ErrBookingNotActive
+	}
+
+	now := s.now()
+	if !selected.StartTime.After(now) {
+		return nil, nil, ErrBookingStarted
+	}
+
+	from := selected.StartTime
+	if scope == ScopeAll {
+		from = now
+	}
+
+	bookings, err := s.repo.GetSeriesBookings(ctx, *selected.SeriesID, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	occurrences := make([]*Booking, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.Status == StatusConfirmed && booking.StartTime.After(now) {
+			occurrences = append(occurrences, booking)
+		}
+	}
+
+	return selected, sortBookings(occurrences), nil
+}
+
+// JoinWaitlist puts a member on the waitlist for a desk, or any desk in a wing, for a time range.
+// The range must satisfy the settings policies so that a promotion can be confirmed as is.
+func (s *Service) JoinWaitlist(ctx context.Context, input *JoinWaitlistInput) (*WaitlistEntry, error) {
+	if (input.DeskID == nil) == (input.Wing == nil) {
+		return nil, ErrInvalidWaitlistTarget
+	}
+	if input.DeskID != nil && *input.DeskID <= 0 {
+		return nil, ErrInvalidWaitlistTarget
+	}
+	if input.Wing != nil && !isValidWing(*input.Wing) {
+		return nil, ErrInvalidWaitlistTarget
+	}
+
+	wing := input.Wing
+	if input.DeskID != nil {
+		desk, err := s.repo.GetDesk(ctx, *input.DeskID)
+		if err != nil {
+			return nil, err
+		}
+		if desk.Status != deskStatusAvailable {
+			return nil, ErrDeskNotAvailable
+		}
+		wing = &desk.Wing
+	}
+	policy, err := s.loadPolicyCheck(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePolicies(ctx, policy, wing, input.StartTime, input.EndTime, nil); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotClosed(ctx, input.DeskID, input.Wing, input.StartTime, input.EndTime); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateWaitlistEntry(ctx, input)
+}
+
+// ListWaitlist retrieves the actor's waitlist entries
+func (s *Service) ListWaitlist(ctx context.Context, actor Actor) ([]*WaitlistEntry, error) {
+	return s.repo.GetUserWaitlistEntries(ctx, actor.UserID)
+}
+
+// LeaveWaitlist removes a waiting entry the actor is allowed to manage
+func (s *Service) LeaveWaitlist(ctx context.Context, actor Actor, id int) (*WaitlistEntry, error) {
+	entry, err := s.repo.GetWaitlistEntryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.IsAdmin() && entry.UserID != actor.UserID {
+		return nil, ErrNotBookingOwner
+	}
+
+	return s.repo.CancelWaitlistEntry(ctx, id)
+}
+
+// OfferTransfer offers one of the actor's upcoming bookings to a colleague. With a swap booking the
+// offer becomes a swap and the colleague's booking comes back to the actor on acceptance.
+func (s *Service) OfferTransfer(ctx context.Context, actor Actor, input *OfferTransferInput) (*BookingTransfer, error) {
+	booking, err := s.repo.GetBookingByID(ctx, input.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != actor.UserID {
+		return nil, ErrNotBookingOwner
+	}
+	if err := s.checkTransferable(booking); err != nil {
+		return nil, err
+	}
+
+	if input.ToUserID == booking.UserID {
+		return nil, ErrInvalidTransferRecipient
+	}
+
+	if input.SwapBookingID != nil {
+		swap, err := s.repo.GetBookingByID(ctx, *input.SwapBookingID)
+		if err != nil {
+			if errors.Is(err, ErrBookingNotFound) {
+				return nil, ErrInvalidSwapBooking
+			}
+			return nil, err
+		}
+		if swap.UserID != input.ToUserID || s.checkTransferable(swap) != nil {
+			return nil, ErrInvalidSwapBooking
+		}
+	}
+
+	return s.repo.CreateTransfer(ctx, actor.UserID, input)
+}
+
+// ListTransfers retrieves the transfers the actor offered or received
+func (s *Service) ListTransfers(ctx context.Context, actor Actor) ([]*BookingTransfer, error) {
+	return s.repo.GetUserTransfers(ctx, actor.UserID)
+}
+
+// AcceptTransfer accepts a transfer addressed to the actor. The daily hour limit and quotas are
+// re-checked for each user receiving a booking, discounting the booking they give up in a swap.
+func (s *Service) AcceptTransfer(ctx context.Context, actor Actor, id int) (*TransferResult, error) {
+	transfer, err := s.repo.GetTransferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != actor.UserID {
+		return nil, ErrNotTransferParty
+	}
+	if transfer.Status != TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, transfer.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTransferable(booking); err != nil {
+		return nil, err
+	}
+
+	var swap *Booking
+	if transfer.SwapBookingID != nil {
+		swap, err = s.repo.GetBookingByID(ctx, *transfer.SwapBookingID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkTransferable(swap); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDailyLimit(ctx, settings, transfer.ToUserID, booking.StartTime, booking.EndTime, swap); err != nil {
+		return nil, err
+	}
+	if err := s.checkQuotas(ctx, settings, transfer.ToUserID, booking.StartTime, booking.EndTime, swap); err != nil {
+		return nil, err
+	}
+	if swap != nil {
+		if err := s.checkDailyLimit(ctx, settings, transfer.FromUserID, swap.StartTime, swap.EndTime, booking); err != nil {
+			return nil, err
+		}
+		if err := s.checkQuotas(ctx, settings, transfer.FromUserID, swap.StartTime, swap.EndTime, booking); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.AcceptTransfer(ctx, id)
+}
+
+// DeclineTransfer turns down a transfer addressed to the actor
+func (s *Service) DeclineTransfer(ctx context.Context, actor Actor, id int) (*BookingTransfer, error) {
+	transfer, err := s.repo.GetTransferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != actor.UserID {
+		return nil, ErrNotTransferParty
+	}
+
+	return s.repo.CloseTransfer(ctx, id, TransferDeclined)
+}
+
+// WithdrawTransfer cancels a transfer the actor offered; admins may withdraw any transfer
+func (s *Service) WithdrawTransfer(ctx context.Context, actor Actor, id int) (*BookingTransfer, error) {
+	transfer, err := s.repo.GetTransferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsAdmin() && transfer.FromUserID != actor.UserID {
+		return nil, ErrNotTransferParty
+	}
+
+	return s.repo.CloseTransfer(ctx, id, TransferCancelled)
+}
+
+// checkTransferable reports whether a booking can still change hands: it must be confirmed and not started
+func (s *Service) checkTransferable(booking *Booking) error {
+	if booking.Status != StatusConfirmed {
+		return ErrBookingNotActive
+	}
+	if !booking.StartTime.After(s.now()) {
+		return ErrBookingStarted
+	}
+	return nil
+}
+
+// SearchAvailability lists every available desk matching the filter that has free time in the
+// filter's window, with the free intervals of each. A free interval lies within its wing's opening
+// hours and can be booked as a whole with the desk's cleaning buffer respected. Fully booked desks
+// are left out.
+func (s *Service) SearchAvailability(ctx context.Context, filter *AvailabilityFilter) ([]*DeskAvailability, error) {
+	if !filter.EndTime.After(filter.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	if filter.Wing != nil && !isValidWing(*filter.Wing) {
+		return nil, ErrInvalidWing
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy, err := s.repo.GetDeskOccupancy(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	window := Interval{StartTime: filter.StartTime, EndTime: filter.EndTime}
+	availability := make([]*DeskAvailability, 0, len(occupancy))
+	for _, desk := range occupancy {
+		free := intersectIntervals(occupancyFreeIntervals(window, desk), openingIntervals(window, &desk.Desk.Wing, settings))
+		if len(free) == 0 {
+			continue
+		}
+		availability = append(availability, &DeskAvailability{
+			Desk:           desk.Desk,
+			FullyAvailable: isWholeWindow(window, free),
+			FreeIntervals:  free,
+		})
+	}
+
+	return availability, nil
+}
+
+// GetTimeline breaks date's day in the site timezone into busy, maintenance and free segments for every
+// desk in the wing (all wings when wing is nil). Free segments are clipped to opening hours and only shown for
+// desks that can be booked.
+func (s *Service) GetTimeline(ctx context.Context, actor Actor, date time.Time, wing *string) ([]*DeskTimeline, error) {
+	if wing != nil && !isValidWing(*wing) {
+		return nil, ErrInvalidWing
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	day := Interval{StartTime: dateIn(date, settings.location())}
+	day.EndTime = day.StartTime.AddDate(0, 0, 1)
+
+	desks, err := s.repo.GetDeskBookingsByTimeRange(ctx, wing, day.StartTime, day.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	timelines := make([]*DeskTimeline, 0, len(desks))
+	for _, desk := range desks {
+		open := openingIntervals(day, &desk.Desk.Wing, settings)
+		timelines = append(timelines, &DeskTimeline{
+			Desk:     desk.Desk,
+			Segments: buildTimeline(day, desk, open, actor),
+		})
+	}
+
+	return timelines, nil
+}
+
+// SuggestSlots offers alternatives to booking a desk for a window: first the nearest free windows
+// of the same length on the same desk within suggestionHorizon, then the same window on the
+// nearest desks of the same wing. Only slots within opening hours that have not started are offered.
+func (s *Service) SuggestSlots(ctx context.Context, deskID int, startTime, endTime time.Time) ([]Suggestion, error) {
+	if !endTime.After(startTime) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	desk, err := s.repo.GetDesk(ctx, deskID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	horizon := Interval{StartTime: startTime.Add(-suggestionHorizon), EndTime: endTime.Add(suggestionHorizon)}
+	if horizon.StartTime.Before(now) {
+		horizon.StartTime = now
+	}
+	if !horizon.EndTime.After(horizon.StartTime) {
+		return []Suggestion{}, nil
+	}
+
+	// One query covers the desk itself and every desk it could be swapped for
+	wing := desk.Wing
+	occupancy, err := s.repo.GetDeskOccupancy(ctx, &AvailabilityFilter{
+		StartTime: horizon.StartTime,
+		EndTime:   horizon.EndTime,
+		Wing:      &wing,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	requested := Interval{StartTime: startTime, EndTime: endTime}
+	suggestions := sameDeskSuggestions(desk, occupancy, requested, horizon, settings, maxSuggestionsPerKind)
+	suggestions = append(suggestions, nearbyDeskSuggestions(desk, occupancy, requested, settings, now, maxSuggestionsPerKind)...)
+	if suggestions == nil {
+		suggestions = []Suggestion{}
+	}
+
+	return suggestions, nil
+}
+
+// policyCheck holds what validating one user's booking ranges needs, loaded once per request.
+// Ranges checked with the same policyCheck share the user's quotas.
+type policyCheck struct {
+	settings *Settings
+	userID   string
+	role     string
+	quotas   *quotaTracker
+}
+
+// loadPolicyCheck loads the settings and prepares checking ranges for the user
+func (s *Service) loadPolicyCheck(ctx context.Context, userID string) (*policyCheck, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.newPolicyCheck(ctx, settings, userID)
+}
+
+// newPolicyCheck prepares checking ranges for the user against settings. The role is only looked
+// up when a quota or a restricted booking window depends on it.
+func (s *Service) newPolicyCheck(ctx context.Context, settings *Settings, userID string) (*policyCheck, error) {
+	var role string
+	if settings.roleDependent() {
+		var err error
+		role, err = s.repo.GetUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &policyCheck{
+		settings: settings,
+		userID:   userID,
+		role:     role,
+		quotas:   newQuotaTracker(settings, userID, role, s.repo.GetUserQuotaUsage),
+	}, nil
+}
+
+// validatePolicies checks a booking range against the user's booking window, the wing's opening
+// hours, the daily hour limit and the user's quotas. When rescheduling, existing is the booking
+// being replaced so its hours are not counted twice.
+func (s *Service) validatePolicies(ctx context.Context, policy *policyCheck, wing *string, startTime, endTime time.Time, existing *Booking) error {
+	if !endTime.After(startTime) {
+		return ErrInvalidTimeRange
+	}
+
+	// A reschedule that keeps its start stays valid as the start draws near
+	if existing == nil || !existing.StartTime.Equal(startTime) {
+		if err := s.checkBookingWindow(policy, startTime); err != nil {
+			return err
+		}
+	}
+
+	// Validate opening hours
+	if !withinOpeningHours(startTime, endTime, wing, policy.settings) {
+		return ErrOutsideOpeningHours
+	}
+
+	if err := s.checkDailyLimit(ctx, policy.settings, policy.userID, startTime, endTime, existing); err != nil {
+		return err
+	}
+
+	return policy.quotas.add(ctx, startTime, endTime, existing)
+}
+
+// checkDailyLimit checks that adding a range to the user's day, in the site timezone, keeps them
+// within the daily hour limit. existing is a booking the range replaces, or that the user gives up
+// in exchange, and is not counted.
+func (s *Service) checkDailyLimit(ctx context.Context, settings *Settings, userID string, startTime, endTime time.Time, existing *Booking) error {
+	day := startOfDay(startTime, settings.location())
+	bookedHours, err := s.repo.GetUserDailyHours(ctx, userID, day)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		bookedHours -= hoursWithinDay(existing.StartTime, existing.EndTime, day)
+	}
+	if bookedHours+endTime.Sub(startTime).Hours() > float64(settings.DailyHourLimit) {
+		return ErrDailyLimitExceeded
+	}
+
+	return nil
+}
+
+// checkBookingWindow checks a booking starting at startTime against the booking window of the
+// user's role
+func (s *Service) checkBookingWindow(policy *policyCheck, startTime time.Time) error {
+	return checkWindow(policy.settings.BookingWindows[policy.role], policy.settings.location(), s.now(), startTime)
+}
+
+// checkWindow checks that a booking starting at startTime is made at now at least the window's
+// minimum lead time ahead, and starts no more than its advance days after today in loc
+func checkWindow(window BookingWindow, loc *time.Location, now, startTime time.Time) error {
+	if !window.restricts() {
+		return nil
+	}
+
+	if startTime.Before(now.Add(time.Duration(window.MinLeadMinutes) * time.Minute)) {
+		return &BookingWindowError{Err: ErrLeadTimeTooShort, Window: window}
+	}
+	if window.MaxAdvanceDays != nil {
+		latest := startOfDay(now, loc).AddDate(0, 0, *window.MaxAdvanceDays+1)
+		if !startTime.Before(latest) {
+			return &BookingWindowError{Err: ErrBeyondAdvanceWindow, Window: window}
+		}
+	}
+
+	return nil
+}
+
+// checkAttendeePolicies checks a group booking's range against one attendee's booking window,
+// daily hour limit and quotas
+func (s *Service) checkAttendeePolicies(ctx context.Context, settings *Settings, userID string, startTime, endTime time.Time) error {
+	policy, err := s.newPolicyCheck(ctx, settings, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBookingWindow(policy, startTime); err != nil {
+		return err
+	}
+	if err := s.checkDailyLimit(ctx, settings, userID, startTime, endTime, nil); err != nil {
+		return err
+	}
+	return policy.quotas.add(ctx, startTime, endTime, nil)
+}
+
+// checkQuotas checks a single range against the user's weekly and monthly quotas. existing is a
+// booking the range replaces, or that the user gives up in exchange, and is not counted.
+func (s *Service) checkQuotas(ctx context.Context, settings *Settings, userID string, startTime, endTime time.Time, existing *Booking) error {
+	quotas, err := s.userQuotaTracker(ctx, settings, userID)
+	if err != nil {
+		return err
+	}
+	return quotas.add(ctx, startTime, endTime, existing)
+}
+
+// isValidRole reports whether role is one of the user_role enum values
+func isValidRole(role string) bool {
+	return role == roleMember || role == roleAdmin
+}
+
+// isValidWing reports whether wing is one of the wing_type enum values
+func isValidWing(wing string) bool {
+	return wing == WingEast || wing == WingWest
+}
+
+// skipReason maps a per-occurrence policy error to the error code reported for that occurrence
+func skipReason(err error) (string, bool) {
+	switch {
+	case errors.Is(err, ErrOutsideOpeningHours):
+		return ErrCodeOutsideOpeningHours, true
+	case errors.Is(err, ErrDailyLimitExceeded):
+		return ErrCodeDailyLimitExceeded, true
+	case errors.Is(err, ErrQuotaExceeded):
+		return ErrCodeQuotaExceeded, true
+	case errors.Is(err, ErrLeadTimeTooShort):
+		return ErrCodeLeadTimeTooShort, true
+	case errors.Is(err, ErrBeyondAdvanceWindow):
+		return ErrCodeBeyondAdvanceWindow, true
+	case errors.Is(err, ErrUserNotFound):
+		return ErrCodeUserNotFound, true
+	case errors.Is(err, ErrBookingConflict):
+		return ErrCodeBookingConflict, true
+	case errors.Is(err, ErrClosed):
+		return ErrCodeClosed, true
+	default:
+		return "", false
+	}
+}
+
+// sortSkipped orders skipped occurrences chronologically
+func sortSkipped(skipped []SkippedOccurrence) []SkippedOccurrence {
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].StartTime.Before(skipped[j].StartTime)
+	})
+	return skipped
+}
+
+// sortBookings orders bookings chronologically
+func sortBookings(bookings []*Booking) []*Booking {
+	sort.Slice(bookings, func(i, j int) bool {
+		return bookings[i].StartTime.Before(bookings[j].StartTime)
+	})
+	return bookings
+}
+
+// deskWing returns the wing of a desk, looking it up once per desk in wings
+func (s *Service) deskWing(ctx context.Context, wings map[int]*string, deskID int) (*string, error) {
+	if wing, ok := wings[deskID]; ok {
+		return wing, nil
+	}
+	desk, err := s.repo.GetDesk(ctx, deskID)
+	if err != nil {
+		return nil, err
+	}
+	wings[deskID] = &desk.Wing
+	return &desk.Wing, nil
+}
+
+// checkNotClosed returns ErrClosed when a closure that applies to the desk, or to the wing when
+// deskID is nil, overlaps the range
+func (s *Service) checkNotClosed(ctx context.Context, deskID *int, wing *string, startTime, endTime time.Time) error {
+	closed, err := s.closedIntervals(ctx, &ClosureFilter{DeskID: deskID, Wing: wing}, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	if len(closed) > 0 {
+		return ErrClosed
+	}
+	return nil
+}
+
+// closedIntervals returns the ranges of the closures matching filter that overlap the range
+func (s *Service) closedIntervals(ctx context.Context, filter *ClosureFilter, startTime, endTime time.Time) ([]Interval, error) {
+	filter.StartTime, filter.EndTime = &startTime, &endTime
+	closures, err := s.repo.GetClosures(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return closureIntervals(closures), nil
+}
+
+// closesWing reports whether any of closures applies to the wing
+func closesWing(closures []*Closure, wing string) bool {
+	for _, closure := range closures {
+		if closure.Wing == nil || *closure.Wing == wing {
+			return true
+		}
+	}
+	return false
+}
+
+// withinOpeningHours reports whether the range fits inside a single day's opening hours for the
+// wing, or for the whole office when wing is nil. Days and opening hours are in the site timezone.
+func withinOpeningHours(startTime, endTime time.Time, wing *string, settings *Settings) bool {
+	opening, ok := openingHoursOn(startOfDay(startTime, settings.location()), wing, settings)
+	return ok && !startTime.Before(opening.StartTime) && !endTime.After(opening.EndTime)
+}
+
+// startOfDay returns midnight in loc of the day containing t
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// dateIn returns midnight in loc of date's calendar date, whatever location date was parsed in
+func dateIn(date time.Time, loc *time.Location) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// atTimeOfDay returns the wall-clock time offset from the midnight starting day, so that 08:00 is
+// still 08:00 on days the clocks change
+func atTimeOfDay(day time.Time, offset time.Duration) time.Time {
+	year, month, date := day.Date()
+	return time.Date(year, month, date, 0, 0, 0, int(offset), day.Location())
+}
+
+// wallClock returns the time of day of t in loc as an offset from midnight
+func wallClock(t time.Time, loc *time.Location) time.Duration {
+	t = t.In(loc)
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// hoursWithinDay returns how many hours of the range fall on the day starting at day; days are 23
+// or 25 hours long when the clocks change
+func hoursWithinDay(startTime, endTime, day time.Time) float64 {
+	dayEnd := day.AddDate(0, 0, 1)
+	if startTime.Before(day) {
+		startTime = day
+	}
+	if endTime.After(dayEnd) {
+		endTime = dayEnd
+	}
+	if !endTime.After(startTime) {
+		return 0
+	}
+	return endTime.Sub(startTime).Hours()
+}
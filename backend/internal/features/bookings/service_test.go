@@ -0,0 +1,4257 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// MockRepository is a mock implementation of RepositoryInterface
+type MockRepository struct {
+	CreateBookingFunc     func(ctx context.Context, input *CreateBookingInput) (*Booking, error)
+	GetBookingByIDFunc    func(ctx context.Context, id int) (*Booking, error)
+	GetUserBookingsFunc   func(ctx context.Context, filter *BookingFilter) (*BookingPage, error)
+	UpdateBookingFunc     func(ctx context.Context, id int, input *UpdateBookingInput) (*Booking, error)
+	DeleteBookingFunc     func(ctx context.Context, id int, change StatusChange) error
+	GetUserDailyHoursFunc func(ctx context.Context, userID string, date time.Time) (float64, error)
+	GetSettingsFunc       func(ctx context.Context) (*Settings, error)
+	CreateSeriesFunc      func(ctx context.Context, input *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error)
+	GetSeriesBookingsFunc func(ctx context.Context, seriesID int, from time.Time) ([]*Booking, error)
+	RescheduleFunc        func(ctx context.Context, changes []BookingReschedule) (*RescheduleResult, error)
+	CancelSeriesFunc      func(ctx context.Context, seriesID int, from time.Time, change StatusChange) ([]*Booking, error)
+	GetBulkCancelFunc     func(ctx context.Context, input *BulkCancelInput) ([]*Booking, error)
+	BulkCancelFunc        func(ctx context.Context, input *BulkCancelInput, change StatusChange) ([]*Booking, error)
+	CreateWaitlistFunc    func(ctx context.Context, input *JoinWaitlistInput) (*WaitlistEntry, error)
+	GetWaitlistEntryFunc  func(ctx context.Context, id int) (*WaitlistEntry, error)
+	GetUserWaitlistFunc   func(ctx context.Context, userID string) ([]*WaitlistEntry, error)
+	CancelWaitlistFunc    func(ctx context.Context, id int) (*WaitlistEntry, error)
+	CheckInBookingFunc    func(ctx context.Context, id int, at time.Time) (*Booking, error)
+	MarkNoShowsFunc       func(ctx context.Context, gracePeriodMinutes, limit int, change StatusChange) ([]*Booking, error)
+	ConfirmHoldFunc       func(ctx context.Context, id int, change StatusChange) (*Booking, error)
+	ExpireHoldsFunc       func(ctx context.Context, limit int, change StatusChange) ([]*Booking, error)
+	CheckOutBookingFunc   func(ctx context.Context, id int, actualEnd time.Time, durationMinutes int, change StatusChange) (*Booking, error)
+	MoveBookingFunc       func(ctx context.Context, id int, move BookingMove) (*Booking, error)
+	CreateGroupFunc       func(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error)
+	CreateTransferFunc    func(ctx context.Context, fromUserID string, input *OfferTransferInput) (*BookingTransfer, error)
+	GetTransferFunc       func(ctx context.Context, id int) (*BookingTransfer, error)
+	GetUserTransfersFunc  func(ctx context.Context, userID string) ([]*BookingTransfer, error)
+	AcceptTransferFunc    func(ctx context.Context, id int) (*TransferResult, error)
+	CloseTransferFunc     func(ctx context.Context, id int, status TransferStatus) (*BookingTransfer, error)
+	GetDeskFunc           func(ctx context.Context, id int) (*Desk, error)
+	GetDeskOccupancyFunc  func(ctx context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error)
+	GetDeskBookingsFunc   func(ctx context.Context, wing *string, startTime, endTime time.Time) ([]*DeskBookings, error)
+	CreateMaintenanceFunc func(ctx context.Context, input *CreateMaintenanceInput) (*MaintenanceInsertResult, error)
+	GetMaintenanceFunc    func(ctx context.Context, filter *MaintenanceFilter) ([]*MaintenanceBlock, error)
+	DeleteMaintenanceFunc func(ctx context.Context, id int) (*MaintenanceBlock, error)
+	CreateClosureFunc     func(ctx context.Context, input *CreateClosureInput) (*Closure, error)
+	GetClosuresFunc       func(ctx context.Context, filter *ClosureFilter) ([]*Closure, error)
+	DeleteClosureFunc     func(ctx context.Context, id int) (*Closure, error)
+	SetOpeningHoursFunc   func(ctx context.Context, input *SetOpeningHoursInput) (*OpeningHours, error)
+	DeleteOpeningFunc     func(ctx context.Context, id int) (*OpeningHours, error)
+	SetQuotaFunc          func(ctx context.Context, input *SetQuotaInput) (*Quota, error)
+	DeleteQuotaFunc       func(ctx context.Context, id int) (*Quota, error)
+	GetUserRoleFunc       func(ctx context.Context, userID string) (string, error)
+	GetQuotaUsageFunc     func(ctx context.Context, userID string, startTime, endTime time.Time) (*QuotaUsage, error)
+}
+
+func (m *MockRepository) CreateBooking(ctx context.Context, input *CreateBookingInput) (*Booking, error) {
+	if m.CreateBookingFunc != nil {
+		return m.CreateBookingFunc(ctx, input)
+	}
+	return &Booking{
+		ID:        1,
+		DeskID:    input.DeskID,
+		UserID:    input.UserID,
+		StartTime: input.StartTime,
+		EndTime:   input.EndTime,
+		Status:    StatusConfirmed,
+	}, nil
+}
+
+func (m *MockRepository) GetBookingByID(ctx context.Context, id int) (*Booking, error) {
+	if m.GetBookingByIDFunc != nil {
+		return m.GetBookingByIDFunc(ctx, id)
+	}
+	return nil, ErrBookingNotFound
+}
+
+func (m *MockRepository) GetUserBookings(ctx context.Context, filter *BookingFilter) (*BookingPage, error) {
+	if m.GetUserBookingsFunc != nil {
+		return m.GetUserBookingsFunc(ctx, filter)
+	}
+	return &BookingPage{}, nil
+}
+
+func (m *MockRepository) UpdateBooking(ctx context.Context, id int, input *UpdateBookingInput) (*Booking, error) {
+	if m.UpdateBookingFunc != nil {
+		return m.UpdateBookingFunc(ctx, id, input)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) DeleteBooking(ctx context.Context, id int, change StatusChange) error {
+	if m.DeleteBookingFunc != nil {
+		return m.DeleteBookingFunc(ctx, id, change)
+	}
+	return nil
+}
+
+func (m *MockRepository) GetUserDailyHours(ctx context.Context, userID string, date time.Time) (float64, error) {
+	if m.GetUserDailyHoursFunc != nil {
+		return m.GetUserDailyHoursFunc(ctx, userID, date)
+	}
+	return 0, nil
+}
+
+func (m *MockRepository) GetSettings(ctx context.Context) (*Settings, error) {
+	if m.GetSettingsFunc != nil {
+		return m.GetSettingsFunc(ctx)
+	}
+	return defaultTestSettings(), nil
+}
+
+func (m *MockRepository) CreateSeries(ctx context.Context, input *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+	if m.CreateSeriesFunc != nil {
+		return m.CreateSeriesFunc(ctx, input, occurrences)
+	}
+	result := &SeriesInsertResult{
+		Series: &Series{ID: 1, UserID: input.UserID, DeskID: input.DeskID, RRule: input.RRule},
+	}
+	for i, occurrence := range occurrences {
+		seriesID := 1
+		result.Bookings = append(result.Bookings, &Booking{
+			ID:        i + 1,
+			DeskID:    input.DeskID,
+			UserID:    input.UserID,
+			SeriesID:  &seriesID,
+			StartTime: occurrence.StartTime,
+			EndTime:   occurrence.EndTime,
+			Status:    StatusConfirmed,
+		})
+	}
+	return result, nil
+}
+
+func (m *MockRepository) GetSeriesBookings(ctx context.Context, seriesID int, from time.Time) ([]*Booking, error) {
+	if m.GetSeriesBookingsFunc != nil {
+		return m.GetSeriesBookingsFunc(ctx, seriesID, from)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) RescheduleBookings(ctx context.Context, changes []BookingReschedule) (*RescheduleResult, error) {
+	if m.RescheduleFunc != nil {
+		return m.RescheduleFunc(ctx, changes)
+	}
+	result := &RescheduleResult{}
+	for _, change := range changes {
+		result.Bookings = append(result.Bookings, &Booking{
+			ID:        change.BookingID,
+			StartTime: change.StartTime,
+			EndTime:   change.EndTime,
+			Status:    StatusConfirmed,
+		})
+	}
+	return result, nil
+}
+
+func (m *MockRepository) CancelSeriesBookings(ctx context.Context, seriesID int, from time.Time, change StatusChange) ([]*Booking, error) {
+	if m.CancelSeriesFunc != nil {
+		return m.CancelSeriesFunc(ctx, seriesID, from, change)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) GetBulkCancelBookings(ctx context.Context, input *BulkCancelInput) ([]*Booking, error) {
+	if m.GetBulkCancelFunc != nil {
+		return m.GetBulkCancelFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) BulkCancelBookings(ctx context.Context, input *BulkCancelInput, change StatusChange) ([]*Booking, error) {
+	if m.BulkCancelFunc != nil {
+		return m.BulkCancelFunc(ctx, input, change)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) CreateWaitlistEntry(ctx context.Context, input *JoinWaitlistInput) (*WaitlistEntry, error) {
+	if m.CreateWaitlistFunc != nil {
+		return m.CreateWaitlistFunc(ctx, input)
+	}
+	return &WaitlistEntry{
+		ID:        1,
+		UserID:    input.UserID,
+		DeskID:    input.DeskID,
+		Wing:      input.Wing,
+		StartTime: input.StartTime,
+		EndTime:   input.EndTime,
+		Status:    WaitlistWaiting,
+	}, nil
+}
+
+func (m *MockRepository) GetWaitlistEntryByID(ctx context.Context, id int) (*WaitlistEntry, error) {
+	if m.GetWaitlistEntryFunc != nil {
+		return m.GetWaitlistEntryFunc(ctx, id)
+	}
+	return nil, ErrWaitlistEntryNotFound
+}
+
+func (m *MockRepository) GetUserWaitlistEntries(ctx context.Context, userID string) ([]*WaitlistEntry, error) {
+	if m.GetUserWaitlistFunc != nil {
+		return m.GetUserWaitlistFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) CancelWaitlistEntry(ctx context.Context, id int) (*WaitlistEntry, error) {
+	if m.CancelWaitlistFunc != nil {
+		return m.CancelWaitlistFunc(ctx, id)
+	}
+	return &WaitlistEntry{ID: id, Status: WaitlistCancelled}, nil
+}
+
+func (m *MockRepository) CheckInBooking(ctx context.Context, id int, at time.Time) (*Booking, error) {
+	if m.CheckInBookingFunc != nil {
+		return m.CheckInBookingFunc(ctx, id, at)
+	}
+	booking := testBooking()
+	booking.ID = id
+	booking.CheckedInAt = &at
+	return booking, nil
+}
+
+func (m *MockRepository) MarkNoShows(ctx context.Context, gracePeriodMinutes, limit int, change StatusChange) ([]*Booking, error) {
+	if m.MarkNoShowsFunc != nil {
+		return m.MarkNoShowsFunc(ctx, gracePeriodMinutes, limit, change)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) ConfirmHold(ctx context.Context, id int, change StatusChange) (*Booking, error) {
+	if m.ConfirmHoldFunc != nil {
+		return m.ConfirmHoldFunc(ctx, id, change)
+	}
+	booking := testBooking()
+	booking.ID = id
+	return booking, nil
+}
+
+func (m *MockRepository) ExpireHolds(ctx context.Context, limit int, change StatusChange) ([]*Booking, error) {
+	if m.ExpireHoldsFunc != nil {
+		return m.ExpireHoldsFunc(ctx, limit, change)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) CheckOutBooking(ctx context.Context, id int, actualEnd time.Time, durationMinutes int, change StatusChange) (*Booking, error) {
+	if m.CheckOutBookingFunc != nil {
+		return m.CheckOutBookingFunc(ctx, id, actualEnd, durationMinutes, change)
+	}
+	booking := testBooking()
+	booking.ID = id
+	booking.Status = StatusCompleted
+	booking.ActualEndTime = &actualEnd
+	booking.ActualDurationMinutes = &durationMinutes
+	return booking, nil
+}
+
+func (m *MockRepository) MoveBooking(ctx context.Context, id int, move BookingMove) (*Booking, error) {
+	if m.MoveBookingFunc != nil {
+		return m.MoveBookingFunc(ctx, id, move)
+	}
+	booking := testBooking()
+	booking.ID = id
+	booking.DeskID = move.DeskID
+	booking.StartTime = move.StartTime
+	booking.EndTime = move.EndTime
+	return booking, nil
+}
+
+func (m *MockRepository) CreateGroup(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+	if m.CreateGroupFunc != nil {
+		return m.CreateGroupFunc(ctx, input, assignments)
+	}
+	groupID := 1
+	result := &GroupInsertResult{Group: &BookingGroup{ID: groupID, OrganizerID: input.OrganizerID, StartTime: input.StartTime, EndTime: input.EndTime}}
+	for i, assignment := range assignments {
+		result.Bookings = append(result.Bookings, &Booking{
+			ID:        i + 1,
+			DeskID:    assignment.DeskID,
+			UserID:    assignment.UserID,
+			GroupID:   &groupID,
+			StartTime: input.StartTime,
+			EndTime:   input.EndTime,
+			Status:    StatusConfirmed,
+		})
+	}
+	return result, nil
+}
+
+func (m *MockRepository) CreateTransfer(ctx context.Context, fromUserID string, input *OfferTransferInput) (*BookingTransfer, error) {
+	if m.CreateTransferFunc != nil {
+		return m.CreateTransferFunc(ctx, fromUserID, input)
+	}
+	return &BookingTransfer{
+		ID:            1,
+		BookingID:     input.BookingID,
+		FromUserID:    fromUserID,
+		ToUserID:      input.ToUserID,
+		SwapBookingID: input.SwapBookingID,
+		Status:        TransferPending,
+	}, nil
+}
+
+func (m *MockRepository) GetTransferByID(ctx context.Context, id int) (*BookingTransfer, error) {
+	if m.GetTransferFunc != nil {
+		return m.GetTransferFunc(ctx, id)
+	}
+	return nil, ErrTransferNotFound
+}
+
+func (m *MockRepository) GetUserTransfers(ctx context.Context, userID string) ([]*BookingTransfer, error) {
+	if m.GetUserTransfersFunc != nil {
+		return m.GetUserTransfersFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) AcceptTransfer(ctx context.Context, id int) (*TransferResult, error) {
+	if m.AcceptTransferFunc != nil {
+		return m.AcceptTransferFunc(ctx, id)
+	}
+	return &TransferResult{Transfer: &BookingTransfer{ID: id, Status: TransferAccepted}}, nil
+}
+
+func (m *MockRepository) CloseTransfer(ctx context.Context, id int, status TransferStatus) (*BookingTransfer, error) {
+	if m.CloseTransferFunc != nil {
+		return m.CloseTransferFunc(ctx, id, status)
+	}
+	return &BookingTransfer{ID: id, Status: status}, nil
+}
+
+func (m *MockRepository) GetDesk(ctx context.Context, id int) (*Desk, error) {
+	if m.GetDeskFunc != nil {
+		return m.GetDeskFunc(ctx, id)
+	}
+	return &Desk{ID: id, DeskNumber: "E1", Wing: WingEast, Status: "available"}, nil
+}
+
+func (m *MockRepository) GetDeskBookingsByTimeRange(ctx context.Context, wing *string, startTime, endTime time.Time) ([]*DeskBookings, error) {
+	if m.GetDeskBookingsFunc != nil {
+		return m.GetDeskBookingsFunc(ctx, wing, startTime, endTime)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) GetDeskOccupancy(ctx context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error) {
+	if m.GetDeskOccupancyFunc != nil {
+		return m.GetDeskOccupancyFunc(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) CreateMaintenanceBlock(ctx context.Context, input *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+	if m.CreateMaintenanceFunc != nil {
+		return m.CreateMaintenanceFunc(ctx, input)
+	}
+	return &MaintenanceInsertResult{Block: &MaintenanceBlock{
+		ID:        1,
+		DeskID:    input.DeskID,
+		StartTime: input.StartTime,
+		EndTime:   input.EndTime,
+		Reason:    input.Reason,
+		CreatedBy: &input.CreatedBy,
+	}}, nil
+}
+
+func (m *MockRepository) GetMaintenanceBlocks(ctx context.Context, filter *MaintenanceFilter) ([]*MaintenanceBlock, error) {
+	if m.GetMaintenanceFunc != nil {
+		return m.GetMaintenanceFunc(ctx, filter)
+	}
+	return []*MaintenanceBlock{}, nil
+}
+
+func (m *MockRepository) DeleteMaintenanceBlock(ctx context.Context, id int) (*MaintenanceBlock, error) {
+	if m.DeleteMaintenanceFunc != nil {
+		return m.DeleteMaintenanceFunc(ctx, id)
+	}
+	return nil, ErrMaintenanceNotFound
+}
+
+func (m *MockRepository) CreateClosure(ctx context.Context, input *CreateClosureInput) (*Closure, error) {
+	if m.CreateClosureFunc != nil {
+		return m.CreateClosureFunc(ctx, input)
+	}
+	return &Closure{
+		ID:        1,
+		Wing:      input.Wing,
+		StartTime: input.StartTime,
+		EndTime:   input.EndTime,
+		Reason:    input.Reason,
+		CreatedBy: &input.CreatedBy,
+	}, nil
+}
+
+func (m *MockRepository) GetClosures(ctx context.Context, filter *ClosureFilter) ([]*Closure, error) {
+	if m.GetClosuresFunc != nil {
+		return m.GetClosuresFunc(ctx, filter)
+	}
+	return []*Closure{}, nil
+}
+
+func (m *MockRepository) DeleteClosure(ctx context.Context, id int) (*Closure, error) {
+	if m.DeleteClosureFunc != nil {
+		return m.DeleteClosureFunc(ctx, id)
+	}
+	return nil, ErrClosureNotFound
+}
+
+func (m *MockRepository) SetOpeningHours(ctx context.Context, input *SetOpeningHoursInput) (*OpeningHours, error) {
+	if m.SetOpeningHoursFunc != nil {
+		return m.SetOpeningHoursFunc(ctx, input)
+	}
+	return &OpeningHours{ID: 1, Wing: input.Wing, Weekday: input.Weekday, Opens: input.Opens, Closes: input.Closes}, nil
+}
+
+func (m *MockRepository) DeleteOpeningHours(ctx context.Context, id int) (*OpeningHours, error) {
+	if m.DeleteOpeningFunc != nil {
+		return m.DeleteOpeningFunc(ctx, id)
+	}
+	return nil, ErrOpeningHoursNotFound
+}
+
+func (m *MockRepository) SetQuota(ctx context.Context, input *SetQuotaInput) (*Quota, error) {
+	if m.SetQuotaFunc != nil {
+		return m.SetQuotaFunc(ctx, input)
+	}
+	return &Quota{ID: 1, Role: input.Role, Period: input.Period, MaxHours: input.MaxHours, MaxBookings: input.MaxBookings}, nil
+}
+
+func (m *MockRepository) DeleteQuota(ctx context.Context, id int) (*Quota, error) {
+	if m.DeleteQuotaFunc != nil {
+		return m.DeleteQuotaFunc(ctx, id)
+	}
+	return nil, ErrQuotaNotFound
+}
+
+func (m *MockRepository) GetUserRole(ctx context.Context, userID string) (string, error) {
+	if m.GetUserRoleFunc != nil {
+		return m.GetUserRoleFunc(ctx, userID)
+	}
+	return roleMember, nil
+}
+
+func (m *MockRepository) GetUserQuotaUsage(ctx context.Context, userID string, startTime, endTime time.Time) (*QuotaUsage, error) {
+	if m.GetQuotaUsageFunc != nil {
+		return m.GetQuotaUsageFunc(ctx, userID, startTime, endTime)
+	}
+	return &QuotaUsage{}, nil
+}
+
+// defaultTestSettings mirrors the defaults of the settings migration
+func defaultTestSettings() *Settings {
+	return &Settings{
+		OpeningStart:              8 * time.Hour,
+		OpeningEnd:                22 * time.Hour,
+		DailyHourLimit:            10,
+		CheckInGracePeriodMinutes: 15,
+	}
+}
+
+// testBooking returns a confirmed booking owned by user-123 from 09:00 to 11:00 on testDay
+func testBooking() *Booking {
+	return &Booking{
+		ID:        42,
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+		Status:    StatusConfirmed,
+	}
+}
+
+// testDay returns a fixed UTC day used to build booking times
+func testDay() time.Time {
+	return time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+}
+
+// ============================================================================
+// CreateBooking Tests
+// ============================================================================
+
+func TestService_CreateBooking_Success(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	booking, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(17 * time.Hour),
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if booking.Status != StatusConfirmed {
+		t.Errorf("expected status confirmed, got %s", booking.Status)
+	}
+}
+
+func TestService_CreateBooking_DeskNotAvailable(t *testing.T) {
+	deskID := 1
+	actor := Actor{UserID: "user-123", Role: "member"}
+	startTime := testDay().Add(12 * time.Hour)
+
+	tests := []struct {
+		name string
+		call func(service *Service) error
+	}{
+		{"create", func(service *Service) error {
+			_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+				DeskID:    deskID,
+				UserID:    "user-123",
+				StartTime: testDay().Add(9 * time.Hour),
+				EndTime:   testDay().Add(11 * time.Hour),
+			})
+			return err
+		}},
+		{"update", func(service *Service) error {
+			_, err := service.UpdateBooking(context.Background(), actor, 42, &UpdateBookingInput{EndTime: &startTime})
+			return err
+		}},
+		{"join waitlist", func(service *Service) error {
+			_, err := service.JoinWaitlist(context.Background(), &JoinWaitlistInput{
+				UserID:    "user-123",
+				DeskID:    &deskID,
+				StartTime: testDay().Add(9 * time.Hour),
+				EndTime:   testDay().Add(11 * time.Hour),
+			})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsLoaded := false
+			service := NewService(&MockRepository{
+				GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+					return &Desk{ID: id, DeskNumber: "E1", Wing: WingEast, Status: "maintenance"}, nil
+				},
+				GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+					return testBooking(), nil
+				},
+				GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+					settingsLoaded = true
+					return defaultTestSettings(), nil
+				},
+			})
+
+			if err := tt.call(service); !errors.Is(err, ErrDeskNotAvailable) {
+				t.Fatalf("expected ErrDeskNotAvailable, got %v", err)
+			}
+			if settingsLoaded {
+				t.Error("expected the desk to be refused before policies are validated")
+			}
+		})
+	}
+}
+
+func TestService_CreateBooking_InvalidTimeRange(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(12 * time.Hour),
+		EndTime:   testDay().Add(10 * time.Hour),
+	})
+
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("expected ErrInvalidTimeRange, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_OutsideOpeningHours(t *testing.T) {
+	testCases := []struct {
+		name  string
+		start time.Duration
+		end   time.Duration
+	}{
+		{"starts before opening", 6 * time.Hour, 10 * time.Hour},
+		{"ends after closing", 20 * time.Hour, 23 * time.Hour},
+		{"spans whole day", 6 * time.Hour, 23 * time.Hour},
+		{"spans midnight", 21 * time.Hour, 33 * time.Hour},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				CreateBookingFunc: func(_ context.Context, _ *CreateBookingInput) (*Booking, error) {
+					t.Fatal("CreateBooking should not be called")
+					return nil, nil
+				},
+			}
+			service := NewService(mockRepo)
+
+			_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+				DeskID:    1,
+				UserID:    "user-123",
+				StartTime: testDay().Add(tc.start),
+				EndTime:   testDay().Add(tc.end),
+			})
+
+			if !errors.Is(err, ErrOutsideOpeningHours) {
+				t.Errorf("expected ErrOutsideOpeningHours, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_CreateBooking_ExactOpeningHours(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			settings := defaultTestSettings()
+			settings.DailyHourLimit = 14
+			return settings, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(8 * time.Hour),
+		EndTime:   testDay().Add(22 * time.Hour),
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+// scheduleTestSettings opens the East wing at 07:00 on Tuesdays and closes the office on Sundays
+func scheduleTestSettings() *Settings {
+	east := WingEast
+	opens, closes := TimeOfDay(7*time.Hour), TimeOfDay(22*time.Hour)
+	settings := defaultTestSettings()
+	settings.Schedule = []*OpeningHours{
+		{Weekday: time.Sunday},
+		{Wing: &east, Weekday: time.Tuesday, Opens: &opens, Closes: &closes},
+	}
+	return settings
+}
+
+func TestService_CreateBooking_WingSchedule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		deskID   int
+		start    time.Time
+		expected error
+	}{
+		{"East wing opens early", 1, testDay().Add(7 * time.Hour), nil},
+		{"West wing keeps the default", 2, testDay().Add(7 * time.Hour), ErrOutsideOpeningHours},
+		{"closed on Sundays", 1, testDay().AddDate(0, 0, 5).Add(9 * time.Hour), ErrOutsideOpeningHours},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+					return scheduleTestSettings(), nil
+				},
+				GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+					wing := WingEast
+					if id == 2 {
+						wing = WingWest
+					}
+					return &Desk{ID: id, Wing: wing, Status: "available"}, nil
+				},
+			}
+			service := NewService(mockRepo)
+
+			_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+				DeskID:    tc.deskID,
+				UserID:    "user-123",
+				StartTime: tc.start,
+				EndTime:   tc.start.Add(2 * time.Hour),
+			})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_CreateBooking_DailyLimitExceeded(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, _ time.Time) (float64, error) {
+			return 6, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(13 * time.Hour),
+		EndTime:   testDay().Add(18 * time.Hour),
+	})
+
+	if !errors.Is(err, ErrDailyLimitExceeded) {
+		t.Errorf("expected ErrDailyLimitExceeded, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_DailyLimitReachedExactly(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, _ time.Time) (float64, error) {
+			return 6, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(13 * time.Hour),
+		EndTime:   testDay().Add(17 * time.Hour),
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_Conflict(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(_ context.Context, _ *CreateBookingInput) (*Booking, error) {
+			return nil, ErrBookingConflict
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+	})
+
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Errorf("expected ErrBookingConflict, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_SettingsError(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return nil, ErrSettingsNotFound
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+	})
+
+	if !errors.Is(err, ErrSettingsNotFound) {
+		t.Errorf("expected ErrSettingsNotFound, got %v", err)
+	}
+}
+
+// ============================================================================
+// GetBooking Tests
+// ============================================================================
+
+func TestService_GetBooking_Owner(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	booking, err := service.GetBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if booking.ID != 42 {
+		t.Errorf("expected booking 42, got %d", booking.ID)
+	}
+}
+
+func TestService_GetBooking_OtherMember(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.GetBooking(context.Background(), Actor{UserID: "user-456", Role: "member"}, 42)
+	if !errors.Is(err, ErrNotBookingOwner) {
+		t.Errorf("expected ErrNotBookingOwner, got %v", err)
+	}
+}
+
+func TestService_GetBooking_Admin(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.GetBooking(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, 42)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+// ============================================================================
+// ListBookings Tests
+// ============================================================================
+
+func TestService_ListBookings_MemberRestrictedToOwn(t *testing.T) {
+	var gotFilter *BookingFilter
+	mockRepo := &MockRepository{
+		GetUserBookingsFunc: func(_ context.Context, filter *BookingFilter) (*BookingPage, error) {
+			gotFilter = filter
+			return &BookingPage{}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	otherUser := "user-456"
+	_, err := service.ListBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, &BookingFilter{UserID: &otherUser})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotFilter.UserID == nil || *gotFilter.UserID != "user-123" {
+		t.Errorf("expected filter restricted to user-123, got %v", gotFilter.UserID)
+	}
+}
+
+func TestService_ListBookings_AdminSeesAll(t *testing.T) {
+	var gotFilter *BookingFilter
+	mockRepo := &MockRepository{
+		GetUserBookingsFunc: func(_ context.Context, filter *BookingFilter) (*BookingPage, error) {
+			gotFilter = filter
+			return &BookingPage{}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.ListBookings(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, &BookingFilter{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotFilter.UserID != nil {
+		t.Errorf("expected no user filter for admin, got %v", *gotFilter.UserID)
+	}
+}
+
+// ============================================================================
+// UpdateBooking Tests
+// ============================================================================
+
+func TestService_UpdateBooking_Success(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, _ time.Time) (float64, error) {
+			// Existing 2h booking plus 7h elsewhere on the same day
+			return 9, nil
+		},
+		UpdateBookingFunc: func(_ context.Context, _ int, input *UpdateBookingInput) (*Booking, error) {
+			booking := testBooking()
+			booking.EndTime = *input.EndTime
+			return booking, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// Extending to 3h stays within the 10h limit once the original 2h are discounted
+	newEnd := testDay().Add(12 * time.Hour)
+	booking, err := service.UpdateBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !booking.EndTime.Equal(newEnd) {
+		t.Errorf("expected end time %v, got %v", newEnd, booking.EndTime)
+	}
+}
+
+func TestService_UpdateBooking_DailyLimitExceeded(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, _ time.Time) (float64, error) {
+			return 9, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	newEnd := testDay().Add(13 * time.Hour)
+	_, err := service.UpdateBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if !errors.Is(err, ErrDailyLimitExceeded) {
+		t.Errorf("expected ErrDailyLimitExceeded, got %v", err)
+	}
+}
+
+func TestService_UpdateBooking_NotActive(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			booking.Status = StatusCancelled
+			return booking, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	newEnd := testDay().Add(12 * time.Hour)
+	_, err := service.UpdateBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if !errors.Is(err, ErrBookingNotActive) {
+		t.Errorf("expected ErrBookingNotActive, got %v", err)
+	}
+}
+
+func TestService_UpdateBooking_OtherMember(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	newEnd := testDay().Add(12 * time.Hour)
+	_, err := service.UpdateBooking(context.Background(), Actor{UserID: "user-456", Role: "member"}, 42, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if !errors.Is(err, ErrNotBookingOwner) {
+		t.Errorf("expected ErrNotBookingOwner, got %v", err)
+	}
+}
+
+// ============================================================================
+// MoveBooking Tests
+// ============================================================================
+
+// newMoveTestService returns a service whose clock sits an hour before testBooking starts
+func newMoveTestService(mockRepo *MockRepository) *Service {
+	if mockRepo.GetBookingByIDFunc == nil {
+		mockRepo.GetBookingByIDFunc = func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		}
+	}
+	service := NewService(mockRepo)
+	service.now = func() time.Time { return testBooking().StartTime.Add(-time.Hour) }
+	return service
+}
+
+func TestService_MoveBooking_DeskAndTime(t *testing.T) {
+	var gotMove BookingMove
+	mockRepo := &MockRepository{
+		MoveBookingFunc: func(_ context.Context, _ int, move BookingMove) (*Booking, error) {
+			gotMove = move
+			return testBooking(), nil
+		},
+	}
+	service := newMoveTestService(mockRepo)
+
+	deskID := 7
+	newStart := testDay().Add(10 * time.Hour)
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		DeskID:    &deskID,
+		StartTime: &newStart,
+		Reason:    "closer to the window",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotMove.DeskID != 7 {
+		t.Errorf("expected move to desk 7, got %d", gotMove.DeskID)
+	}
+	if !gotMove.StartTime.Equal(newStart) {
+		t.Errorf("expected start %v, got %v", newStart, gotMove.StartTime)
+	}
+	// The end time is kept when only the start is given
+	if !gotMove.EndTime.Equal(testBooking().EndTime) {
+		t.Errorf("expected end %v, got %v", testBooking().EndTime, gotMove.EndTime)
+	}
+	if gotMove.ActorID == nil || *gotMove.ActorID != "user-123" || gotMove.Forced {
+		t.Errorf("expected an unforced move by user-123, got %+v", gotMove)
+	}
+	if gotMove.Reason != "closer to the window" {
+		t.Errorf("expected reason to be passed through, got %q", gotMove.Reason)
+	}
+}
+
+func TestService_MoveBooking_InvalidTimeRange(t *testing.T) {
+	service := newMoveTestService(&MockRepository{})
+
+	newStart := testDay().Add(12 * time.Hour)
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		StartTime: &newStart,
+	})
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("expected ErrInvalidTimeRange, got %v", err)
+	}
+}
+
+func TestService_MoveBooking_OtherMember(t *testing.T) {
+	service := newMoveTestService(&MockRepository{})
+
+	deskID := 7
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-456", Role: "member"}, 42, &MoveBookingInput{
+		DeskID: &deskID,
+	})
+	if !errors.Is(err, ErrNotBookingOwner) {
+		t.Errorf("expected ErrNotBookingOwner, got %v", err)
+	}
+}
+
+func TestService_MoveBooking_NotActive(t *testing.T) {
+	service := newMoveTestService(&MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			booking.Status = StatusCancelled
+			return booking, nil
+		},
+	})
+
+	deskID := 7
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		DeskID: &deskID,
+	})
+	if !errors.Is(err, ErrBookingNotActive) {
+		t.Errorf("expected ErrBookingNotActive, got %v", err)
+	}
+}
+
+func TestService_MoveBooking_Started(t *testing.T) {
+	service := newMoveTestService(&MockRepository{})
+	service.now = func() time.Time { return testBooking().StartTime.Add(time.Hour) }
+
+	deskID := 7
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		DeskID: &deskID,
+	})
+	if !errors.Is(err, ErrBookingStarted) {
+		t.Errorf("expected ErrBookingStarted, got %v", err)
+	}
+}
+
+func TestService_MoveBooking_OutsideOpeningHours(t *testing.T) {
+	service := newMoveTestService(&MockRepository{})
+
+	newEnd := testDay().Add(23 * time.Hour)
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		EndTime: &newEnd,
+	})
+	if !errors.Is(err, ErrOutsideOpeningHours) {
+		t.Errorf("expected ErrOutsideOpeningHours, got %v", err)
+	}
+}
+
+func TestService_MoveBooking_ForceRequiresAdmin(t *testing.T) {
+	called := false
+	service := newMoveTestService(&MockRepository{
+		MoveBookingFunc: func(_ context.Context, _ int, _ BookingMove) (*Booking, error) {
+			called = true
+			return testBooking(), nil
+		},
+	})
+
+	deskID := 7
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		DeskID: &deskID,
+		Force:  true,
+	})
+	if !errors.Is(err, ErrAdminOnly) {
+		t.Errorf("expected ErrAdminOnly, got %v", err)
+	}
+	if called {
+		t.Error("expected the repository not to be called")
+	}
+}
+
+func TestService_MoveBooking_AdminForceSkipsPolicies(t *testing.T) {
+	var gotMove BookingMove
+	service := newMoveTestService(&MockRepository{
+		MoveBookingFunc: func(_ context.Context, _ int, move BookingMove) (*Booking, error) {
+			gotMove = move
+			return testBooking(), nil
+		},
+	})
+	// The booking is already underway
+	service.now = func() time.Time { return testBooking().StartTime.Add(30 * time.Minute) }
+
+	deskID := 7
+	newEnd := testDay().Add(23 * time.Hour)
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, 42, &MoveBookingInput{
+		DeskID:  &deskID,
+		EndTime: &newEnd,
+		Force:   true,
+		Reason:  "desk damaged",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gotMove.Forced || gotMove.ActorID == nil || *gotMove.ActorID != "admin-1" {
+		t.Errorf("expected a forced move by admin-1, got %+v", gotMove)
+	}
+}
+
+func TestService_MoveBooking_Conflict(t *testing.T) {
+	service := newMoveTestService(&MockRepository{
+		MoveBookingFunc: func(_ context.Context, _ int, _ BookingMove) (*Booking, error) {
+			return nil, ErrBookingConflict
+		},
+	})
+
+	deskID := 7
+	_, err := service.MoveBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, &MoveBookingInput{
+		DeskID: &deskID,
+	})
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Errorf("expected ErrBookingConflict, got %v", err)
+	}
+}
+
+// ============================================================================
+// CreateGroupBooking Tests
+// ============================================================================
+
+// namedGroupInput returns a group of user-1..user-n on desks 1..n from 09:00 to 17:00 on testDay
+func namedGroupInput(n int) *CreateGroupInput {
+	input := &CreateGroupInput{
+		OrganizerID: "user-1",
+		StartTime:   testDay().Add(9 * time.Hour),
+		EndTime:     testDay().Add(17 * time.Hour),
+	}
+	for i := 1; i <= n; i++ {
+		deskID := i
+		input.Attendees = append(input.Attendees, GroupAttendee{UserID: fmt.Sprintf("user-%d", i), DeskID: &deskID})
+	}
+	return input
+}
+
+func TestService_CreateGroupBooking_NamedDesks(t *testing.T) {
+	var gotAssignments []GroupAssignment
+	service := NewService(&MockRepository{
+		CreateGroupFunc: func(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+			gotAssignments = assignments
+			return (&MockRepository{}).CreateGroup(ctx, input, assignments)
+		},
+	})
+
+	result, err := service.CreateGroupBooking(context.Background(), namedGroupInput(3))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Bookings) != 3 {
+		t.Fatalf("expected 3 bookings, got %d", len(result.Bookings))
+	}
+	for i, assignment := range gotAssignments {
+		if assignment.DeskID != i+1 || assignment.UserID != fmt.Sprintf("user-%d", i+1) {
+			t.Errorf("expected user-%d on desk %d, got %+v", i+1, i+1, assignment)
+		}
+	}
+	for _, booking := range result.Bookings {
+		if booking.GroupID == nil || *booking.GroupID != result.Group.ID {
+			t.Errorf("expected booking %d to be linked to group %d", booking.ID, result.Group.ID)
+		}
+	}
+}
+
+func TestService_CreateGroupBooking_InvalidAttendees(t *testing.T) {
+	wing := WingEast
+	deskID := 1
+
+	tests := []struct {
+		name  string
+		input *CreateGroupInput
+	}{
+		{"no attendees", namedGroupInput(0)},
+		{"too many attendees", namedGroupInput(maxGroupSize + 1)},
+		{"duplicate user", func() *CreateGroupInput {
+			input := namedGroupInput(2)
+			input.Attendees[1].UserID = "user-1"
+			return input
+		}()},
+		{"duplicate desk", func() *CreateGroupInput {
+			input := namedGroupInput(2)
+			input.Attendees[1].DeskID = &deskID
+			return input
+		}()},
+		{"missing desk", func() *CreateGroupInput {
+			input := namedGroupInput(2)
+			input.Attendees[1].DeskID = nil
+			return input
+		}()},
+		{"desk named alongside a wing", func() *CreateGroupInput {
+			input := namedGroupInput(2)
+			input.Wing = &wing
+			return input
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&MockRepository{})
+			_, err := service.CreateGroupBooking(context.Background(), tt.input)
+			if !errors.Is(err, ErrInvalidGroup) {
+				t.Errorf("expected ErrInvalidGroup, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_CreateGroupBooking_ReportsEveryFailure(t *testing.T) {
+	called := false
+	service := NewService(&MockRepository{
+		GetUserDailyHoursFunc: func(_ context.Context, userID string, _ time.Time) (float64, error) {
+			if userID == "user-2" {
+				return 4, nil
+			}
+			return 0, nil
+		},
+		GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+			switch id {
+			case 3:
+				return nil, ErrDeskNotFound
+			case 4:
+				return &Desk{ID: 4, DeskNumber: "E4", Wing: WingEast, Status: "maintenance"}, nil
+			}
+			return &Desk{ID: id, DeskNumber: fmt.Sprintf("E%d", id), Wing: WingEast, Status: deskStatusAvailable}, nil
+		},
+		CreateGroupFunc: func(_ context.Context, _ *CreateGroupInput, _ []GroupAssignment) (*GroupInsertResult, error) {
+			called = true
+			return nil, nil
+		},
+	})
+
+	_, err := service.CreateGroupBooking(context.Background(), namedGroupInput(4))
+
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected *GroupError, got %v", err)
+	}
+	want := map[string]string{
+		"user-2": ErrCodeDailyLimitExceeded,
+		"user-3": ErrCodeDeskNotFound,
+		"user-4": ErrCodeDeskNotAvailable,
+	}
+	if len(groupErr.Failures) != len(want) {
+		t.Fatalf("expected %d failures, got %+v", len(want), groupErr.Failures)
+	}
+	for _, failure := range groupErr.Failures {
+		if want[failure.UserID] != failure.Reason {
+			t.Errorf("expected %s to fail with %s, got %s", failure.UserID, want[failure.UserID], failure.Reason)
+		}
+	}
+	if called {
+		t.Error("expected nothing to be booked")
+	}
+}
+
+func TestService_CreateGroupBooking_InsertConflicts(t *testing.T) {
+	service := NewService(&MockRepository{
+		CreateGroupFunc: func(_ context.Context, _ *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+			return &GroupInsertResult{
+				Conflicts:    []GroupAssignment{assignments[0]},
+				MissingUsers: []GroupAssignment{assignments[2]},
+			}, ErrBookingConflict
+		},
+	})
+
+	_, err := service.CreateGroupBooking(context.Background(), namedGroupInput(3))
+
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected *GroupError, got %v", err)
+	}
+	if len(groupErr.Failures) != 2 {
+		t.Fatalf("expected 2 failures, got %+v", groupErr.Failures)
+	}
+	if groupErr.Failures[0].Reason != ErrCodeBookingConflict || *groupErr.Failures[0].DeskID != 1 {
+		t.Errorf("expected desk 1 to conflict, got %+v", groupErr.Failures[0])
+	}
+	if groupErr.Failures[1].Reason != ErrCodeUserNotFound || groupErr.Failures[1].UserID != "user-3" {
+		t.Errorf("expected user-3 to be unknown, got %+v", groupErr.Failures[1])
+	}
+}
+
+func TestService_CreateGroupBooking_WingPicksAdjacentDesks(t *testing.T) {
+	start := testDay().Add(9 * time.Hour)
+	end := testDay().Add(17 * time.Hour)
+	busy := []Interval{{StartTime: start, EndTime: start.Add(time.Hour)}}
+
+	var gotFilter *AvailabilityFilter
+	var gotAssignments []GroupAssignment
+	service := NewService(&MockRepository{
+		GetDeskOccupancyFunc: func(_ context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			gotFilter = filter
+			return []*DeskOccupancy{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}},
+				{Desk: Desk{ID: 2, DeskNumber: "E2", Wing: WingEast}, Busy: busy},
+				{Desk: Desk{ID: 3, DeskNumber: "E3", Wing: WingEast}},
+				{Desk: Desk{ID: 4, DeskNumber: "E4", Wing: WingEast}},
+				{Desk: Desk{ID: 5, DeskNumber: "E5", Wing: WingEast}},
+			}, nil
+		},
+		CreateGroupFunc: func(ctx context.Context, input *CreateGroupInput, assignments []GroupAssignment) (*GroupInsertResult, error) {
+			gotAssignments = assignments
+			return (&MockRepository{}).CreateGroup(ctx, input, assignments)
+		},
+	})
+
+	wing := WingEast
+	_, err := service.CreateGroupBooking(context.Background(), &CreateGroupInput{
+		OrganizerID: "user-1",
+		StartTime:   start,
+		EndTime:     end,
+		Wing:        &wing,
+		Features:    []string{"monitor"},
+		Attendees:   []GroupAttendee{{UserID: "user-1"}, {UserID: "user-2"}, {UserID: "user-3"}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotFilter == nil || *gotFilter.Wing != WingEast || len(gotFilter.Features) != 1 {
+		t.Errorf("expected an East wing search with features, got %+v", gotFilter)
+	}
+	if len(gotAssignments) != 3 {
+		t.Fatalf("expected 3 assignments, got %d", len(gotAssignments))
+	}
+	for i, assignment := range gotAssignments {
+		if assignment.DeskID != i+3 {
+			t.Errorf("expected attendee %d on desk %d, got %d", i, i+3, assignment.DeskID)
+		}
+	}
+}
+
+func TestService_CreateGroupBooking_WingTooFewDesks(t *testing.T) {
+	service := NewService(&MockRepository{
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			return []*DeskOccupancy{{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}}}, nil
+		},
+	})
+
+	wing := WingEast
+	_, err := service.CreateGroupBooking(context.Background(), &CreateGroupInput{
+		OrganizerID: "user-1",
+		StartTime:   testDay().Add(9 * time.Hour),
+		EndTime:     testDay().Add(17 * time.Hour),
+		Wing:        &wing,
+		Attendees:   []GroupAttendee{{UserID: "user-1"}, {UserID: "user-2"}},
+	})
+
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected *GroupError, got %v", err)
+	}
+	if len(groupErr.Failures) != 1 || groupErr.Failures[0].UserID != "user-2" || groupErr.Failures[0].Reason != ErrCodeNoDeskAvailable {
+		t.Errorf("expected user-2 to have no desk, got %+v", groupErr.Failures)
+	}
+}
+
+func TestService_CreateGroupBooking_OutsideOpeningHours(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	input := namedGroupInput(2)
+	input.StartTime, input.EndTime = testDay().Add(20*time.Hour), testDay().Add(23*time.Hour)
+	_, err := service.CreateGroupBooking(context.Background(), input)
+
+	// Named desks are checked against their own wing's hours
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected a GroupError, got %v", err)
+	}
+	if len(groupErr.Failures) != 2 || groupErr.Failures[0].Reason != ErrCodeOutsideOpeningHours {
+		t.Errorf("expected both attendees to fail on opening hours, got %+v", groupErr.Failures)
+	}
+
+	input = &CreateGroupInput{
+		OrganizerID: "user-123",
+		StartTime:   testDay().Add(20 * time.Hour),
+		EndTime:     testDay().Add(23 * time.Hour),
+		Attendees:   []GroupAttendee{{UserID: "user-123"}},
+	}
+	wing := WingEast
+	input.Wing = &wing
+	if _, err := service.CreateGroupBooking(context.Background(), input); !errors.Is(err, ErrOutsideOpeningHours) {
+		t.Errorf("expected ErrOutsideOpeningHours for a wing group, got %v", err)
+	}
+}
+
+// ============================================================================
+// Transfer Tests
+// ============================================================================
+
+// swapBooking returns a confirmed booking owned by user-456 from 13:00 to 15:00 on testDay
+func swapBooking() *Booking {
+	booking := testBooking()
+	booking.ID = 43
+	booking.DeskID = 2
+	booking.UserID = "user-456"
+	booking.StartTime = testDay().Add(13 * time.Hour)
+	booking.EndTime = testDay().Add(15 * time.Hour)
+	return booking
+}
+
+// pendingTransfer returns a pending offer of testBooking from user-123 to user-456
+func pendingTransfer() *BookingTransfer {
+	return &BookingTransfer{ID: 7, BookingID: 42, FromUserID: "user-123", ToUserID: "user-456", Status: TransferPending}
+}
+
+// newTransferTestService returns a service that knows testBooking and swapBooking and whose clock
+// sits at the start of testDay
+func newTransferTestService(mockRepo *MockRepository) *Service {
+	if mockRepo.GetBookingByIDFunc == nil {
+		mockRepo.GetBookingByIDFunc = func(_ context.Context, id int) (*Booking, error) {
+			switch id {
+			case 42:
+				return testBooking(), nil
+			case 43:
+				return swapBooking(), nil
+			}
+			return nil, ErrBookingNotFound
+		}
+	}
+	service := NewService(mockRepo)
+	service.now = testDay
+	return service
+}
+
+func TestService_OfferTransfer_Success(t *testing.T) {
+	var gotFrom string
+	service := newTransferTestService(&MockRepository{
+		CreateTransferFunc: func(_ context.Context, fromUserID string, input *OfferTransferInput) (*BookingTransfer, error) {
+			gotFrom = fromUserID
+			return &BookingTransfer{ID: 1, BookingID: input.BookingID, FromUserID: fromUserID, ToUserID: input.ToUserID, Status: TransferPending}, nil
+		},
+	})
+
+	transfer, err := service.OfferTransfer(context.Background(), Actor{UserID: "user-123", Role: "member"}, &OfferTransferInput{
+		BookingID: 42,
+		ToUserID:  "user-456",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotFrom != "user-123" || transfer.ToUserID != "user-456" {
+		t.Errorf("expected offer from user-123 to user-456, got %s to %s", gotFrom, transfer.ToUserID)
+	}
+}
+
+func TestService_OfferTransfer_Validation(t *testing.T) {
+	swapID := 43
+	ownSwapID := 42
+	tests := []struct {
+		name    string
+		actor   Actor
+		input   OfferTransferInput
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:    "other member's booking",
+			actor:   Actor{UserID: "user-456", Role: "member"},
+			input:   OfferTransferInput{BookingID: 42, ToUserID: "user-789"},
+			wantErr: ErrNotBookingOwner,
+		},
+		{
+			name:    "admin cannot offer on behalf of the owner",
+			actor:   Actor{UserID: "admin-1", Role: "admin"},
+			input:   OfferTransferInput{BookingID: 42, ToUserID: "user-456"},
+			wantErr: ErrNotBookingOwner,
+		},
+		{
+			name:    "to the owner",
+			actor:   Actor{UserID: "user-123", Role: "member"},
+			input:   OfferTransferInput{BookingID: 42, ToUserID: "user-123"},
+			wantErr: ErrInvalidTransferRecipient,
+		},
+		{
+			name:    "started booking",
+			actor:   Actor{UserID: "user-123", Role: "member"},
+			input:   OfferTransferInput{BookingID: 42, ToUserID: "user-456"},
+			now:     testDay().Add(10 * time.Hour),
+			wantErr: ErrBookingStarted,
+		},
+		{
+			name:    "swap booking not held by the recipient",
+			actor:   Actor{UserID: "user-123", Role: "member"},
+			input:   OfferTransferInput{BookingID: 42, ToUserID: "user-789", SwapBookingID: &swapID},
+			wantErr: ErrInvalidSwapBooking,
+		},
+		{
+			name:    "swap booking is the offered booking",
+			actor:   Actor{UserID: "user-123", Role: "member"},
+			input:   OfferTransferInput{BookingID: 42, ToUserID: "user-456", SwapBookingID: &ownSwapID},
+			wantErr: ErrInvalidSwapBooking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTransferTestService(&MockRepository{})
+			if !tt.now.IsZero() {
+				service.now = func() time.Time { return tt.now }
+			}
+
+			input := tt.input
+			_, err := service.OfferTransfer(context.Background(), tt.actor, &input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestService_AcceptTransfer_Success(t *testing.T) {
+	accepted := false
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+		AcceptTransferFunc: func(_ context.Context, id int) (*TransferResult, error) {
+			accepted = true
+			return &TransferResult{Transfer: &BookingTransfer{ID: id, Status: TransferAccepted}}, nil
+		},
+	})
+
+	_, err := service.AcceptTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !accepted {
+		t.Error("expected the transfer to be accepted")
+	}
+}
+
+func TestService_AcceptTransfer_NotRecipient(t *testing.T) {
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+	})
+
+	_, err := service.AcceptTransfer(context.Background(), Actor{UserID: "user-123", Role: "member"}, 7)
+	if !errors.Is(err, ErrNotTransferParty) {
+		t.Errorf("expected ErrNotTransferParty, got %v", err)
+	}
+}
+
+func TestService_AcceptTransfer_Closed(t *testing.T) {
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			transfer := pendingTransfer()
+			transfer.Status = TransferDeclined
+			return transfer, nil
+		},
+	})
+
+	_, err := service.AcceptTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7)
+	if !errors.Is(err, ErrTransferClosed) {
+		t.Errorf("expected ErrTransferClosed, got %v", err)
+	}
+}
+
+func TestService_AcceptTransfer_ReceiverOverDailyLimit(t *testing.T) {
+	accepted := false
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+		GetUserDailyHoursFunc: func(_ context.Context, userID string, _ time.Time) (float64, error) {
+			if userID == "user-456" {
+				return 9, nil
+			}
+			return 2, nil
+		},
+		AcceptTransferFunc: func(_ context.Context, _ int) (*TransferResult, error) {
+			accepted = true
+			return &TransferResult{}, nil
+		},
+	})
+
+	_, err := service.AcceptTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7)
+	if !errors.Is(err, ErrDailyLimitExceeded) {
+		t.Errorf("expected ErrDailyLimitExceeded, got %v", err)
+	}
+	if accepted {
+		t.Error("expected the transfer not to be accepted")
+	}
+}
+
+func TestService_AcceptTransfer_SwapDiscountsGivenBooking(t *testing.T) {
+	swapID := 43
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			transfer := pendingTransfer()
+			transfer.SwapBookingID = &swapID
+			return transfer, nil
+		},
+		GetUserDailyHoursFunc: func(_ context.Context, userID string, _ time.Time) (float64, error) {
+			// Both users already hold 10h, including the 2h booking they give up
+			return 10, nil
+		},
+	})
+
+	_, err := service.AcceptTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7)
+	if err != nil {
+		t.Errorf("expected the equal-length swap to stay within the limit, got %v", err)
+	}
+}
+
+func TestService_AcceptTransfer_SwapChecksOfferer(t *testing.T) {
+	swapID := 43
+	service := newTransferTestService(&MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, id int) (*Booking, error) {
+			if id == 43 {
+				// A longer booking comes back to the offerer
+				booking := swapBooking()
+				booking.EndTime = testDay().Add(18 * time.Hour)
+				return booking, nil
+			}
+			return testBooking(), nil
+		},
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			transfer := pendingTransfer()
+			transfer.SwapBookingID = &swapID
+			return transfer, nil
+		},
+		GetUserDailyHoursFunc: func(_ context.Context, userID string, _ time.Time) (float64, error) {
+			if userID == "user-123" {
+				return 8, nil
+			}
+			return 5, nil
+		},
+	})
+
+	_, err := service.AcceptTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7)
+	if !errors.Is(err, ErrDailyLimitExceeded) {
+		t.Errorf("expected ErrDailyLimitExceeded for the offerer, got %v", err)
+	}
+}
+
+func TestService_DeclineTransfer(t *testing.T) {
+	var gotStatus TransferStatus
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+		CloseTransferFunc: func(_ context.Context, id int, status TransferStatus) (*BookingTransfer, error) {
+			gotStatus = status
+			return &BookingTransfer{ID: id, Status: status}, nil
+		},
+	})
+
+	if _, err := service.DeclineTransfer(context.Background(), Actor{UserID: "user-123", Role: "member"}, 7); !errors.Is(err, ErrNotTransferParty) {
+		t.Errorf("expected the offerer not to be able to decline, got %v", err)
+	}
+
+	if _, err := service.DeclineTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotStatus != TransferDeclined {
+		t.Errorf("expected status declined, got %s", gotStatus)
+	}
+}
+
+func TestService_WithdrawTransfer(t *testing.T) {
+	var gotStatus TransferStatus
+	service := newTransferTestService(&MockRepository{
+		GetTransferFunc: func(_ context.Context, _ int) (*BookingTransfer, error) {
+			return pendingTransfer(), nil
+		},
+		CloseTransferFunc: func(_ context.Context, id int, status TransferStatus) (*BookingTransfer, error) {
+			gotStatus = status
+			return &BookingTransfer{ID: id, Status: status}, nil
+		},
+	})
+
+	if _, err := service.WithdrawTransfer(context.Background(), Actor{UserID: "user-456", Role: "member"}, 7); !errors.Is(err, ErrNotTransferParty) {
+		t.Errorf("expected the recipient not to be able to withdraw, got %v", err)
+	}
+
+	if _, err := service.WithdrawTransfer(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, 7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotStatus != TransferCancelled {
+		t.Errorf("expected status cancelled, got %s", gotStatus)
+	}
+}
+
+// ============================================================================
+// CancelBooking Tests
+// ============================================================================
+
+func TestService_CancelBooking_Success(t *testing.T) {
+	cancelled := false
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			if cancelled {
+				booking.Status = StatusCancelled
+			}
+			return booking, nil
+		},
+		DeleteBookingFunc: func(_ context.Context, _ int, _ StatusChange) error {
+			cancelled = true
+			return nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	booking, err := service.CancelBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if booking.Status != StatusCancelled {
+		t.Errorf("expected status cancelled, got %s", booking.Status)
+	}
+}
+
+func TestService_CancelBooking_NotFound(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	_, err := service.CancelBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 999, "")
+	if !errors.Is(err, ErrBookingNotFound) {
+		t.Errorf("expected ErrBookingNotFound, got %v", err)
+	}
+}
+
+func TestService_CancelBooking_RecordsActorAndReason(t *testing.T) {
+	var recorded StatusChange
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+		DeleteBookingFunc: func(_ context.Context, _ int, change StatusChange) error {
+			recorded = change
+			return nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CancelBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, "sick day")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if recorded.ActorID == nil || *recorded.ActorID != "user-123" || recorded.Reason != "sick day" {
+		t.Errorf("expected change by user-123 for sick day, got %+v", recorded)
+	}
+}
+
+func TestService_CancelBooking_Completed(t *testing.T) {
+	deleteCalled := false
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			booking.Status = StatusCompleted
+			return booking, nil
+		},
+		DeleteBookingFunc: func(_ context.Context, _ int, _ StatusChange) error {
+			deleteCalled = true
+			return nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CancelBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, "")
+	if !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+	}
+	if deleteCalled {
+		t.Error("expected no update for an invalid transition")
+	}
+}
+
+func TestService_CancelBooking_AlreadyCancelled(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			booking := testBooking()
+			booking.Status = StatusCancelled
+			return booking, nil
+		},
+		DeleteBookingFunc: func(_ context.Context, _ int, _ StatusChange) error {
+			t.Error("expected no update for an already cancelled booking")
+			return nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	booking, err := service.CancelBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if booking.Status != StatusCancelled {
+		t.Errorf("expected status cancelled, got %s", booking.Status)
+	}
+}
+
+// ============================================================================
+// BulkCancelBookings Tests
+// ============================================================================
+
+// bulkCancelInput returns a valid bulk cancellation of the East wing on testDay
+func bulkCancelInput() *BulkCancelInput {
+	wing := "East"
+	return &BulkCancelInput{
+		Wing:      &wing,
+		StartTime: testDay(),
+		EndTime:   testDay().Add(24 * time.Hour),
+		Reason:    "power outage",
+	}
+}
+
+func TestService_BulkCancelBookings_Validation(t *testing.T) {
+	admin := Actor{UserID: "admin-1", Role: "admin"}
+	north := "North"
+
+	tests := []struct {
+		name     string
+		actor    Actor
+		modify   func(input *BulkCancelInput)
+		expected error
+	}{
+		{"member", Actor{UserID: "user-123", Role: "member"}, func(_ *BulkCancelInput) {}, ErrAdminOnly},
+		{"no desks", admin, func(input *BulkCancelInput) { input.Wing = nil }, ErrInvalidBulkCancel},
+		{"blank reason", admin, func(input *BulkCancelInput) { input.Reason = "  " }, ErrInvalidBulkCancel},
+		{"unknown wing", admin, func(input *BulkCancelInput) { input.Wing = &north }, ErrInvalidWing},
+		{"empty window", admin, func(input *BulkCancelInput) { input.EndTime = input.StartTime }, ErrInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&MockRepository{
+				BulkCancelFunc: func(_ context.Context, _ *BulkCancelInput, _ StatusChange) ([]*Booking, error) {
+					t.Error("expected nothing to be cancelled")
+					return nil, nil
+				},
+			})
+
+			input := bulkCancelInput()
+			tt.modify(input)
+			if _, err := service.BulkCancelBookings(context.Background(), tt.actor, input); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_BulkCancelBookings_DryRun(t *testing.T) {
+	later, earlier := testBooking(), testBooking()
+	later.ID, later.StartTime = 2, later.StartTime.Add(time.Hour)
+	service := NewService(&MockRepository{
+		GetBulkCancelFunc: func(_ context.Context, _ *BulkCancelInput) ([]*Booking, error) {
+			return []*Booking{later, earlier}, nil
+		},
+		BulkCancelFunc: func(_ context.Context, _ *BulkCancelInput, _ StatusChange) ([]*Booking, error) {
+			t.Error("expected a dry run not to cancel anything")
+			return nil, nil
+		},
+	})
+
+	input := bulkCancelInput()
+	input.DryRun = true
+	result, err := service.BulkCancelBookings(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !result.DryRun || result.Count != 2 {
+		t.Errorf("expected a dry run previewing 2 bookings, got %+v", result)
+	}
+	if result.Bookings[0] != earlier {
+		t.Error("expected the preview to be ordered by start time")
+	}
+}
+
+func TestService_BulkCancelBookings_RecordsReason(t *testing.T) {
+	var gotChange StatusChange
+	service := NewService(&MockRepository{
+		BulkCancelFunc: func(_ context.Context, _ *BulkCancelInput, change StatusChange) ([]*Booking, error) {
+			gotChange = change
+			return []*Booking{testBooking()}, nil
+		},
+	})
+
+	result, err := service.BulkCancelBookings(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, bulkCancelInput())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.DryRun || result.Count != 1 {
+		t.Errorf("expected 1 cancelled booking, got %+v", result)
+	}
+	if gotChange.Reason != "power outage" || gotChange.ActorID == nil || *gotChange.ActorID != "admin-1" {
+		t.Errorf("expected the admin and reason to be recorded, got %+v", gotChange)
+	}
+}
+
+// ============================================================================
+// Maintenance Tests
+// ============================================================================
+
+// maintenanceInput returns a valid block of desk 1 for the afternoon of testDay
+func maintenanceInput() *CreateMaintenanceInput {
+	return &CreateMaintenanceInput{
+		DeskID:    1,
+		StartTime: testDay().Add(13 * time.Hour),
+		EndTime:   testDay().Add(18 * time.Hour),
+		Reason:    "monitor replacement",
+	}
+}
+
+func TestService_CreateMaintenanceBlock_Validation(t *testing.T) {
+	admin := Actor{UserID: "admin-1", Role: "admin"}
+
+	tests := []struct {
+		name     string
+		actor    Actor
+		modify   func(input *CreateMaintenanceInput)
+		expected error
+	}{
+		{"member", Actor{UserID: "user-123", Role: "member"}, func(_ *CreateMaintenanceInput) {}, ErrAdminOnly},
+		{"blank reason", admin, func(input *CreateMaintenanceInput) { input.Reason = " " }, ErrInvalidMaintenance},
+		{"unknown policy", admin, func(input *CreateMaintenanceInput) { input.Policy = "ignore" }, ErrInvalidMaintenance},
+		{"empty window", admin, func(input *CreateMaintenanceInput) { input.EndTime = input.StartTime }, ErrInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&MockRepository{
+				CreateMaintenanceFunc: func(_ context.Context, _ *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+					t.Error("expected no block to be created")
+					return nil, nil
+				},
+			})
+
+			input := maintenanceInput()
+			tt.modify(input)
+			if _, err := service.CreateMaintenanceBlock(context.Background(), tt.actor, input); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_CreateMaintenanceBlock_DefaultsToReject(t *testing.T) {
+	var got *CreateMaintenanceInput
+	service := NewService(&MockRepository{
+		CreateMaintenanceFunc: func(_ context.Context, input *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+			got = input
+			return &MaintenanceInsertResult{Block: &MaintenanceBlock{ID: 1, DeskID: input.DeskID}}, nil
+		},
+	})
+
+	result, err := service.CreateMaintenanceBlock(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, maintenanceInput())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Policy != MaintenanceReject || got.CreatedBy != "admin-1" {
+		t.Errorf("expected the reject policy and the admin to be recorded, got %+v", got)
+	}
+	if result.Block.ID != 1 || result.Cancelled == nil || result.Moved == nil {
+		t.Errorf("expected the block with empty cancelled and moved lists, got %+v", result)
+	}
+}
+
+func TestService_CreateMaintenanceBlock_Conflict(t *testing.T) {
+	later, earlier := testBooking(), testBooking()
+	later.ID, later.StartTime = 2, later.StartTime.Add(time.Hour)
+	service := NewService(&MockRepository{
+		CreateMaintenanceFunc: func(_ context.Context, _ *CreateMaintenanceInput) (*MaintenanceInsertResult, error) {
+			return &MaintenanceInsertResult{Conflicts: []*Booking{later, earlier}}, ErrBookingConflict
+		},
+	})
+
+	input := maintenanceInput()
+	input.Policy = MaintenanceMove
+	_, err := service.CreateMaintenanceBlock(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, input)
+
+	var maintenanceErr *MaintenanceError
+	if !errors.As(err, &maintenanceErr) {
+		t.Fatalf("expected a MaintenanceError, got %v", err)
+	}
+	if maintenanceErr.Policy != MaintenanceMove || len(maintenanceErr.Bookings) != 2 {
+		t.Fatalf("expected 2 unmovable bookings, got %+v", maintenanceErr)
+	}
+	if maintenanceErr.Bookings[0] != earlier {
+		t.Error("expected the bookings to be ordered by start time")
+	}
+}
+
+func TestService_DeleteMaintenanceBlock_AdminOnly(t *testing.T) {
+	service := NewService(&MockRepository{
+		DeleteMaintenanceFunc: func(_ context.Context, _ int) (*MaintenanceBlock, error) {
+			t.Error("expected no block to be deleted")
+			return nil, nil
+		},
+	})
+
+	_, err := service.DeleteMaintenanceBlock(context.Background(), Actor{UserID: "user-123", Role: "member"}, 1)
+	if !errors.Is(err, ErrAdminOnly) {
+		t.Errorf("expected ErrAdminOnly, got %v", err)
+	}
+}
+
+func TestService_ListMaintenanceBlocks_InvalidRange(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	start := testDay()
+	_, err := service.ListMaintenanceBlocks(context.Background(), &MaintenanceFilter{StartTime: &start, EndTime: &start})
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("expected ErrInvalidTimeRange, got %v", err)
+	}
+}
+
+// ============================================================================
+// Closure Tests
+// ============================================================================
+
+func TestService_CreateClosure_WholeDays(t *testing.T) {
+	var got *CreateClosureInput
+	service := NewService(&MockRepository{
+		CreateClosureFunc: func(_ context.Context, input *CreateClosureInput) (*Closure, error) {
+			got = input
+			return &Closure{ID: 1}, nil
+		},
+	})
+
+	date, endDate := testDay().Add(15*time.Hour), testDay().AddDate(0, 0, 2)
+	_, err := service.CreateClosure(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, &CreateClosureInput{
+		Date:    &date,
+		EndDate: &endDate,
+		Reason:  "office shutdown",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !got.StartTime.Equal(testDay()) || !got.EndTime.Equal(testDay().AddDate(0, 0, 3)) {
+		t.Errorf("expected three whole days from %v, got %v-%v", testDay(), got.StartTime, got.EndTime)
+	}
+	if got.CreatedBy != "admin-1" {
+		t.Errorf("expected the admin to be recorded, got %q", got.CreatedBy)
+	}
+}
+
+func TestService_CreateClosure_Validation(t *testing.T) {
+	admin := Actor{UserID: "admin-1", Role: "admin"}
+	north := "North"
+
+	tests := []struct {
+		name     string
+		actor    Actor
+		modify   func(input *CreateClosureInput)
+		expected error
+	}{
+		{"member", Actor{UserID: "user-123", Role: "member"}, func(_ *CreateClosureInput) {}, ErrAdminOnly},
+		{"blank reason", admin, func(input *CreateClosureInput) { input.Reason = "" }, ErrInvalidClosure},
+		{"unknown wing", admin, func(input *CreateClosureInput) { input.Wing = &north }, ErrInvalidWing},
+		{"empty range", admin, func(input *CreateClosureInput) { input.EndTime = input.StartTime }, ErrInvalidTimeRange},
+		{"end date before date", admin, func(input *CreateClosureInput) {
+			date, endDate := testDay(), testDay().AddDate(0, 0, -1)
+			input.Date, input.EndDate = &date, &endDate
+		}, ErrInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&MockRepository{
+				CreateClosureFunc: func(_ context.Context, _ *CreateClosureInput) (*Closure, error) {
+					t.Error("expected no closure to be created")
+					return nil, nil
+				},
+			})
+
+			input := &CreateClosureInput{
+				StartTime: testDay().Add(12 * time.Hour),
+				EndTime:   testDay().Add(14 * time.Hour),
+				Reason:    "fire drill",
+			}
+			tt.modify(input)
+			if _, err := service.CreateClosure(context.Background(), tt.actor, input); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_CreateBooking_Closed(t *testing.T) {
+	var gotFilter *ClosureFilter
+	mockRepo := &MockRepository{
+		GetClosuresFunc: func(_ context.Context, filter *ClosureFilter) ([]*Closure, error) {
+			gotFilter = filter
+			return []*Closure{{ID: 3, StartTime: testDay().Add(12 * time.Hour), EndTime: testDay().Add(14 * time.Hour)}}, nil
+		},
+		CreateBookingFunc: func(_ context.Context, _ *CreateBookingInput) (*Booking, error) {
+			t.Error("expected no booking to be created")
+			return nil, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    4,
+		UserID:    "user-123",
+		StartTime: testDay().Add(13 * time.Hour),
+		EndTime:   testDay().Add(15 * time.Hour),
+	})
+	if !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+	if gotFilter == nil || gotFilter.DeskID == nil || *gotFilter.DeskID != 4 {
+		t.Errorf("expected the closures of desk 4 to be checked, got %+v", gotFilter)
+	}
+	if !gotFilter.StartTime.Equal(testDay().Add(13*time.Hour)) || !gotFilter.EndTime.Equal(testDay().Add(15*time.Hour)) {
+		t.Errorf("expected the booking's range to be checked, got %v-%v", gotFilter.StartTime, gotFilter.EndTime)
+	}
+}
+
+func TestService_CreateGroupBooking_ClosedWing(t *testing.T) {
+	west := WingWest
+	mockRepo := &MockRepository{
+		GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+			wing := WingEast
+			if id == 2 {
+				wing = WingWest
+			}
+			return &Desk{ID: id, Wing: wing, Status: "available"}, nil
+		},
+		GetClosuresFunc: func(_ context.Context, _ *ClosureFilter) ([]*Closure, error) {
+			return []*Closure{{ID: 3, Wing: &west, StartTime: testDay(), EndTime: testDay().Add(24 * time.Hour)}}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	desk1, desk2 := 1, 2
+	_, err := service.CreateGroupBooking(context.Background(), &CreateGroupInput{
+		OrganizerID: "user-123",
+		StartTime:   testDay().Add(9 * time.Hour),
+		EndTime:     testDay().Add(11 * time.Hour),
+		Attendees:   []GroupAttendee{{UserID: "user-123", DeskID: &desk1}, {UserID: "user-456", DeskID: &desk2}},
+	})
+
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected a GroupError, got %v", err)
+	}
+	if len(groupErr.Failures) != 1 || groupErr.Failures[0].UserID != "user-456" || groupErr.Failures[0].Reason != ErrCodeClosed {
+		t.Errorf("expected only the West wing desk to fail as closed, got %+v", groupErr.Failures)
+	}
+}
+
+// ============================================================================
+// Opening Hours Tests
+// ============================================================================
+
+func TestService_SetOpeningHours_Validation(t *testing.T) {
+	admin := Actor{UserID: "admin-1", Role: "admin"}
+	north := "North"
+	at := func(hours int) *TimeOfDay {
+		offset := TimeOfDay(time.Duration(hours) * time.Hour)
+		return &offset
+	}
+
+	tests := []struct {
+		name     string
+		actor    Actor
+		input    SetOpeningHoursInput
+		expected error
+	}{
+		{"member", Actor{UserID: "user-123", Role: "member"}, SetOpeningHoursInput{Weekday: time.Saturday}, ErrAdminOnly},
+		{"unknown wing", admin, SetOpeningHoursInput{Wing: &north, Weekday: time.Saturday}, ErrInvalidWing},
+		{"weekday out of range", admin, SetOpeningHoursInput{Weekday: 7}, ErrInvalidOpeningHours},
+		{"missing closing time", admin, SetOpeningHoursInput{Weekday: time.Saturday, Opens: at(10)}, ErrInvalidOpeningHours},
+		{"closes before opening", admin, SetOpeningHoursInput{Weekday: time.Saturday, Opens: at(16), Closes: at(10)}, ErrInvalidOpeningHours},
+		{"closes after midnight", admin, SetOpeningHoursInput{Weekday: time.Saturday, Opens: at(10), Closes: at(25)}, ErrInvalidOpeningHours},
+		{"closed day", admin, SetOpeningHoursInput{Weekday: time.Sunday}, nil},
+		{"late opening", admin, SetOpeningHoursInput{Weekday: time.Saturday, Opens: at(10), Closes: at(24)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved bool
+			service := NewService(&MockRepository{
+				SetOpeningHoursFunc: func(_ context.Context, input *SetOpeningHoursInput) (*OpeningHours, error) {
+					saved = true
+					return &OpeningHours{ID: 1, Weekday: input.Weekday}, nil
+				},
+			})
+
+			input := tt.input
+			_, err := service.SetOpeningHours(context.Background(), tt.actor, &input)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if saved != (tt.expected == nil) {
+				t.Errorf("expected the entry to be saved only when valid, saved=%v", saved)
+			}
+		})
+	}
+}
+
+func TestService_ListOpeningHours_Empty(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	schedule, err := service.ListOpeningHours(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if schedule == nil || len(schedule) != 0 {
+		t.Errorf("expected an empty schedule, got %v", schedule)
+	}
+}
+
+// ============================================================================
+// Site Timezone Tests
+// ============================================================================
+
+// siteTestSettings returns the default settings for a site in the named zone
+func siteTestSettings(t *testing.T, name string) *Settings {
+	t.Helper()
+	settings := defaultTestSettings()
+	settings.Location = loadLocation(t, name)
+	return settings
+}
+
+func TestService_CreateBooking_SiteTimezone(t *testing.T) {
+	settings := siteTestSettings(t, "Asia/Singapore")
+
+	var gotDay time.Time
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return settings, nil
+		},
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, date time.Time) (float64, error) {
+			gotDay = date
+			return 0, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// 01:00-03:00 UTC is 09:00-11:00 in Singapore, whatever zone the client sent
+	start := time.Date(2026, 3, 10, 1, 0, 0, 0, time.UTC)
+	if _, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gotDay.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, settings.Location)) {
+		t.Errorf("expected the daily limit to count 10 March in Singapore, got %v", gotDay)
+	}
+
+	// 15:00-17:00 UTC spans midnight in Singapore
+	start = time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+	})
+	if !errors.Is(err, ErrOutsideOpeningHours) {
+		t.Errorf("expected ErrOutsideOpeningHours across local midnight, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_DSTChange(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return siteTestSettings(t, "Europe/London"), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// On 29 March 2026 London opens at 08:00 BST, which is 07:00 UTC
+	testCases := []struct {
+		name     string
+		start    time.Time
+		expected error
+	}{
+		{"opening after the clocks change", time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC), nil},
+		{"closing after the clocks change", time.Date(2026, 3, 29, 21, 0, 0, 0, time.UTC), ErrOutsideOpeningHours},
+		{"opening on the day before", time.Date(2026, 3, 28, 7, 0, 0, 0, time.UTC), ErrOutsideOpeningHours},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+				DeskID:    1,
+				UserID:    "user-123",
+				StartTime: tc.start,
+				EndTime:   tc.start.Add(time.Hour),
+			})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestService_GetTimeline_DSTChange(t *testing.T) {
+	var gotStart, gotEnd time.Time
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return siteTestSettings(t, "Europe/London"), nil
+		},
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, startTime, endTime time.Time) ([]*DeskBookings, error) {
+			gotStart, gotEnd = startTime, endTime
+			return []*DeskBookings{{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast, Status: "available"}}}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	timelines, err := service.GetTimeline(context.Background(), Actor{UserID: "user-123", Role: "member"},
+		time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gotStart.Equal(time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)) || gotEnd.Sub(gotStart) != 23*time.Hour {
+		t.Errorf("expected the 23 hour local day, got %v-%v", gotStart, gotEnd)
+	}
+
+	free := timelines[0].Segments
+	if len(free) != 1 || !free[0].StartTime.Equal(time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC)) ||
+		!free[0].EndTime.Equal(time.Date(2026, 3, 29, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the desk to be free 08:00-22:00 BST, got %+v", free)
+	}
+}
+
+func TestService_CreateRecurringBooking_DSTChange(t *testing.T) {
+	var inserted []Occurrence
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return siteTestSettings(t, "Europe/London"), nil
+		},
+		CreateSeriesFunc: func(_ context.Context, _ *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+			inserted = occurrences
+			return &SeriesInsertResult{Series: &Series{ID: 1}}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// Weekly at 09:00 London time, starting the Tuesday before the clocks change. The exception
+	// date comes anchored to the first occurrence's UTC time, as date-only exdates are.
+	start := time.Date(2026, 3, 24, 9, 0, 0, 0, time.UTC)
+	_, err := service.CreateRecurringBooking(context.Background(), &CreateSeriesInput{
+		UserID:    "user-123",
+		DeskID:    1,
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+		RRule:     "FREQ=WEEKLY;COUNT=3",
+		ExDates:   []time.Time{time.Date(2026, 4, 7, 9, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(inserted) != 2 {
+		t.Fatalf("expected 2 occurrences after the exception, got %d", len(inserted))
+	}
+	if !inserted[0].StartTime.Equal(start) || !inserted[1].StartTime.Equal(time.Date(2026, 3, 31, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected occurrences at 09:00 local time, got %v and %v", inserted[0].StartTime, inserted[1].StartTime)
+	}
+	if inserted[1].EndTime.Sub(inserted[1].StartTime) != 2*time.Hour {
+		t.Errorf("expected occurrences to keep their length, got %v", inserted[1].EndTime.Sub(inserted[1].StartTime))
+	}
+}
+
+func TestService_CreateClosure_SiteTimezone(t *testing.T) {
+	var got *CreateClosureInput
+	service := NewService(&MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return siteTestSettings(t, "Europe/London"), nil
+		},
+		CreateClosureFunc: func(_ context.Context, input *CreateClosureInput) (*Closure, error) {
+			got = input
+			return &Closure{ID: 1}, nil
+		},
+	})
+
+	// The autumn change makes 25 October 25 hours long
+	date := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	if _, err := service.CreateClosure(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, &CreateClosureInput{
+		Date:   &date,
+		Reason: "office shutdown",
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !got.StartTime.Equal(time.Date(2026, 10, 24, 23, 0, 0, 0, time.UTC)) || got.EndTime.Sub(got.StartTime) != 25*time.Hour {
+		t.Errorf("expected the whole local day, got %v-%v", got.StartTime, got.EndTime)
+	}
+}
+
+// ============================================================================
+// Quota Tests
+// ============================================================================
+
+// quotaTestSettings returns the default settings with a weekly member quota of 20 hours and 5 bookings
+func quotaTestSettings() *Settings {
+	settings := defaultTestSettings()
+	hours, bookings := 20, 5
+	settings.Quotas = []*Quota{{ID: 1, Role: roleMember, Period: QuotaWeek, MaxHours: &hours, MaxBookings: &bookings}}
+	return settings
+}
+
+func TestService_CreateBooking_WeeklyQuota(t *testing.T) {
+	var gotStart, gotEnd time.Time
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, _ string, startTime, endTime time.Time) (*QuotaUsage, error) {
+			gotStart, gotEnd = startTime, endTime
+			return &QuotaUsage{Hours: 19, Bookings: 3}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+	})
+	var quotaErr *QuotaError
+	if !errors.As(err, &quotaErr) || !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected a quota error, got %v", err)
+	}
+	if quotaErr.Quota.Period != QuotaWeek {
+		t.Errorf("expected the weekly quota to be reported, got %s", quotaErr.Quota.Period)
+	}
+
+	// testDay is a Tuesday; the week runs from Monday to Monday
+	monday := testDay().AddDate(0, 0, -1)
+	if !gotStart.Equal(monday) || !gotEnd.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("expected usage for the week from %v, got %v-%v", monday, gotStart, gotEnd)
+	}
+}
+
+func TestService_CreateBooking_QuotaBookingCount(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, _ string, _, _ time.Time) (*QuotaUsage, error) {
+			return &QuotaUsage{Hours: 5, Bookings: 5}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(10 * time.Hour),
+	})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected ErrQuotaExceeded for a sixth booking, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_QuotaScopedByRole(t *testing.T) {
+	var usageQueried bool
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		GetUserRoleFunc: func(_ context.Context, _ string) (string, error) {
+			return roleAdmin, nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, _ string, _, _ time.Time) (*QuotaUsage, error) {
+			usageQueried = true
+			return &QuotaUsage{Hours: 20, Bookings: 5}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "admin-1",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected the member quota not to apply to admins, got %v", err)
+	}
+	if usageQueried {
+		t.Error("expected no usage lookup for a role without quotas")
+	}
+}
+
+func TestService_UpdateBooking_QuotaDiscountsExisting(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		// The week is full, including the booking being moved
+		GetQuotaUsageFunc: func(_ context.Context, _ string, _, _ time.Time) (*QuotaUsage, error) {
+			return &QuotaUsage{Hours: 20, Bookings: 5}, nil
+		},
+	}
+	service := NewService(mockRepo)
+	actor := Actor{UserID: "user-123", Role: "member"}
+
+	later := testDay().Add(14 * time.Hour)
+	laterEnd := later.Add(2 * time.Hour)
+	if _, err := service.UpdateBooking(context.Background(), actor, 42, &UpdateBookingInput{StartTime: &later, EndTime: &laterEnd}); err != nil {
+		t.Fatalf("expected moving the booking within the week to fit, got %v", err)
+	}
+
+	laterEnd = later.Add(3 * time.Hour)
+	_, err := service.UpdateBooking(context.Background(), actor, 42, &UpdateBookingInput{StartTime: &later, EndTime: &laterEnd})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected lengthening the booking to exceed the quota, got %v", err)
+	}
+}
+
+func TestService_CreateRecurringBooking_QuotaAcrossPeriods(t *testing.T) {
+	settings := defaultTestSettings()
+	perWeek := 1
+	settings.Quotas = []*Quota{{ID: 1, Role: roleMember, Period: QuotaWeek, MaxBookings: &perWeek}}
+
+	var usageCalls int
+	var inserted []Occurrence
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return settings, nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, _ string, _, _ time.Time) (*QuotaUsage, error) {
+			usageCalls++
+			return &QuotaUsage{}, nil
+		},
+		CreateSeriesFunc: func(_ context.Context, _ *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+			inserted = occurrences
+			return &SeriesInsertResult{Series: &Series{ID: 1}}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// Tuesdays and Thursdays over two weeks: only one occurrence a week fits
+	_, err := service.CreateRecurringBooking(context.Background(), testSeriesInput())
+	var seriesErr *SeriesError
+	if !errors.As(err, &seriesErr) {
+		t.Fatalf("expected a series error, got %v", err)
+	}
+	if inserted != nil {
+		t.Error("expected nothing to be booked")
+	}
+	if len(seriesErr.Skipped) != 2 {
+		t.Fatalf("expected both Thursdays to be refused, got %+v", seriesErr.Skipped)
+	}
+	for _, skipped := range seriesErr.Skipped {
+		if skipped.Reason != ErrCodeQuotaExceeded || skipped.StartTime.Weekday() != time.Thursday {
+			t.Errorf("expected a Thursday over quota, got %+v", skipped)
+		}
+	}
+	if usageCalls != 2 {
+		t.Errorf("expected usage to be loaded once per week, got %d lookups", usageCalls)
+	}
+
+	input := testSeriesInput()
+	input.Partial = true
+	result, err := service.CreateRecurringBooking(context.Background(), input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(inserted) != 2 || len(result.Skipped) != 2 {
+		t.Errorf("expected the Tuesdays booked and the Thursdays skipped, got %d booked, %+v", len(inserted), result.Skipped)
+	}
+}
+
+func TestService_CreateGroupBooking_AttendeeOverQuota(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return quotaTestSettings(), nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, userID string, _, _ time.Time) (*QuotaUsage, error) {
+			if userID == "user-2" {
+				return &QuotaUsage{Bookings: 5}, nil
+			}
+			return &QuotaUsage{}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	desk1, desk2 := 1, 2
+	_, err := service.CreateGroupBooking(context.Background(), &CreateGroupInput{
+		OrganizerID: "user-1",
+		StartTime:   testDay().Add(9 * time.Hour),
+		EndTime:     testDay().Add(11 * time.Hour),
+		Attendees:   []GroupAttendee{{UserID: "user-1", DeskID: &desk1}, {UserID: "user-2", DeskID: &desk2}},
+	})
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected a group error, got %v", err)
+	}
+	if len(groupErr.Failures) != 1 || groupErr.Failures[0].UserID != "user-2" || groupErr.Failures[0].Reason != ErrCodeQuotaExceeded {
+		t.Errorf("expected user-2 to be over quota, got %+v", groupErr.Failures)
+	}
+}
+
+func TestService_GetQuotaStatus(t *testing.T) {
+	settings := siteTestSettings(t, "Asia/Singapore")
+	weekHours, monthBookings := 20, 12
+	settings.Quotas = []*Quota{
+		{ID: 1, Role: roleMember, Period: QuotaWeek, MaxHours: &weekHours},
+		{ID: 2, Role: roleMember, Period: QuotaMonth, MaxBookings: &monthBookings},
+	}
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return settings, nil
+		},
+		GetQuotaUsageFunc: func(_ context.Context, _ string, startTime, _ time.Time) (*QuotaUsage, error) {
+			if startTime.Day() == 1 {
+				return &QuotaUsage{Hours: 30, Bookings: 14}, nil
+			}
+			return &QuotaUsage{Hours: 6.5, Bookings: 3}, nil
+		},
+	}
+	service := NewService(mockRepo)
+	// Sunday night in UTC is already Monday in Singapore
+	service.now = func() time.Time { return time.Date(2026, time.March, 15, 20, 0, 0, 0, time.UTC) }
+
+	statuses, err := service.GetQuotaStatus(context.Background(), Actor{UserID: "user-123", Role: "member"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected a status per quota, got %d", len(statuses))
+	}
+
+	week := statuses[0]
+	if !week.PeriodStart.Equal(time.Date(2026, time.March, 16, 0, 0, 0, 0, settings.Location)) {
+		t.Errorf("expected the week to start on Monday in Singapore, got %v", week.PeriodStart)
+	}
+	if week.RemainingHours == nil || *week.RemainingHours != 13.5 || week.RemainingBookings != nil {
+		t.Errorf("expected 13.5 hours left and no booking cap, got %+v", week)
+	}
+
+	month := statuses[1]
+	if month.RemainingBookings == nil || *month.RemainingBookings != 0 || month.RemainingHours != nil {
+		t.Errorf("expected no bookings left and no hour cap, got %+v", month)
+	}
+}
+
+func TestService_SetQuota_Validation(t *testing.T) {
+	admin := Actor{UserID: "admin-1", Role: "admin"}
+	limit, zero := 10, 0
+
+	tests := []struct {
+		name     string
+		actor    Actor
+		input    SetQuotaInput
+		expected error
+	}{
+		{"member", Actor{UserID: "user-123", Role: "member"}, SetQuotaInput{Role: roleMember, Period: QuotaWeek, MaxHours: &limit}, ErrAdminOnly},
+		{"unknown role", admin, SetQuotaInput{Role: "guest", Period: QuotaWeek, MaxHours: &limit}, ErrInvalidQuota},
+		{"unknown period", admin, SetQuotaInput{Role: roleMember, Period: "year", MaxHours: &limit}, ErrInvalidQuota},
+		{"no limit", admin, SetQuotaInput{Role: roleMember, Period: QuotaWeek}, ErrInvalidQuota},
+		{"zero limit", admin, SetQuotaInput{Role: roleMember, Period: QuotaMonth, MaxBookings: &zero}, ErrInvalidQuota},
+		{"hours only", admin, SetQuotaInput{Role: roleMember, Period: QuotaWeek, MaxHours: &limit}, nil},
+		{"bookings only", admin, SetQuotaInput{Role: roleAdmin, Period: QuotaMonth, MaxBookings: &limit}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved bool
+			service := NewService(&MockRepository{
+				SetQuotaFunc: func(_ context.Context, input *SetQuotaInput) (*Quota, error) {
+					saved = true
+					return &Quota{ID: 1, Role: input.Role, Period: input.Period}, nil
+				},
+			})
+
+			input := tt.input
+			_, err := service.SetQuota(context.Background(), tt.actor, &input)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if saved != (tt.expected == nil) {
+				t.Errorf("expected the quota to be saved only when valid, saved=%v", saved)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Booking Window Tests
+// ============================================================================
+
+// windowTestSettings returns the default settings with members limited to booking 14 days ahead
+// and at least 10 minutes before the start
+func windowTestSettings() *Settings {
+	settings := defaultTestSettings()
+	days := 14
+	settings.BookingWindows = map[string]BookingWindow{
+		roleMember: {MaxAdvanceDays: &days, MinLeadMinutes: 10},
+		roleAdmin:  {},
+	}
+	return settings
+}
+
+// newWindowTestService returns a service over the booking window settings with the clock at now
+func newWindowTestService(mockRepo *MockRepository, now time.Time) *Service {
+	mockRepo.GetSettingsFunc = func(_ context.Context) (*Settings, error) {
+		return windowTestSettings(), nil
+	}
+	service := NewService(mockRepo)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestService_CreateBooking_BookingWindow(t *testing.T) {
+	// testDay is Tuesday 10 March; the window reaches the end of Tuesday 24 March
+	now := testDay().Add(8*time.Hour + 55*time.Minute)
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		expected error
+	}{
+		{"within the lead time", testDay().Add(9 * time.Hour), ErrLeadTimeTooShort},
+		{"after the lead time", testDay().Add(9*time.Hour + 5*time.Minute), nil},
+		{"last day of the window", testDay().AddDate(0, 0, 14).Add(20 * time.Hour), nil},
+		{"beyond the window", testDay().AddDate(0, 0, 15).Add(9 * time.Hour), ErrBeyondAdvanceWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newWindowTestService(&MockRepository{}, now)
+
+			_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+				DeskID:    1,
+				UserID:    "user-123",
+				StartTime: tt.start,
+				EndTime:   tt.start.Add(time.Hour),
+			})
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			var windowErr *BookingWindowError
+			if tt.expected != nil && (!errors.As(err, &windowErr) || windowErr.Window.MinLeadMinutes != 10) {
+				t.Errorf("expected the member window to be reported, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_CreateBooking_BookingWindowAdminExempt(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserRoleFunc: func(_ context.Context, _ string) (string, error) {
+			return roleAdmin, nil
+		},
+	}
+	service := newWindowTestService(mockRepo, testDay().Add(8*time.Hour+55*time.Minute))
+
+	for _, start := range []time.Time{testDay().Add(9 * time.Hour), testDay().AddDate(0, 3, 0).Add(9 * time.Hour)} {
+		_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+			DeskID:    1,
+			UserID:    "admin-1",
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+		})
+		if err != nil {
+			t.Errorf("expected admins to book at %v, got %v", start, err)
+		}
+	}
+}
+
+func TestService_UpdateBooking_BookingWindowKeepsStart(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	// The 09:00 booking starts in 5 minutes
+	service := newWindowTestService(mockRepo, testDay().Add(8*time.Hour+55*time.Minute))
+	actor := Actor{UserID: "user-123", Role: "member"}
+
+	endTime := testDay().Add(12 * time.Hour)
+	if _, err := service.UpdateBooking(context.Background(), actor, 42, &UpdateBookingInput{EndTime: &endTime}); err != nil {
+		t.Fatalf("expected extending a booking that keeps its start to succeed, got %v", err)
+	}
+
+	startTime := testDay().Add(9*time.Hour + time.Minute)
+	_, err := service.UpdateBooking(context.Background(), actor, 42, &UpdateBookingInput{StartTime: &startTime})
+	if !errors.Is(err, ErrLeadTimeTooShort) {
+		t.Errorf("expected a new start within the lead time to be refused, got %v", err)
+	}
+}
+
+func TestService_CreateRecurringBooking_BeyondAdvanceWindow(t *testing.T) {
+	var inserted []Occurrence
+	mockRepo := &MockRepository{
+		CreateSeriesFunc: func(_ context.Context, _ *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+			inserted = occurrences
+			return &SeriesInsertResult{Series: &Series{ID: 1}}, nil
+		},
+	}
+	service := newWindowTestService(mockRepo, testDay().Add(-7*24*time.Hour))
+
+	// Tuesdays and Thursdays from 10 March; the window from 3 March ends with 17 March
+	input := testSeriesInput()
+	input.Partial = true
+	result, err := service.CreateRecurringBooking(context.Background(), input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(inserted) != 3 {
+		t.Errorf("expected 3 occurrences to be booked, got %+v", inserted)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != ErrCodeBeyondAdvanceWindow {
+		t.Fatalf("expected the last occurrence to be beyond the window, got %+v", result.Skipped)
+	}
+	if !result.Skipped[0].StartTime.Equal(testDay().AddDate(0, 0, 9).Add(9 * time.Hour)) {
+		t.Errorf("expected 19 March to be skipped, got %v", result.Skipped[0].StartTime)
+	}
+}
+
+func TestService_CreateRecurringBooking_LoadsPoliciesOnce(t *testing.T) {
+	var settingsLoads, roleLookups int
+	mockRepo := &MockRepository{
+		GetUserRoleFunc: func(_ context.Context, _ string) (string, error) {
+			roleLookups++
+			return roleMember, nil
+		},
+	}
+	service := newWindowTestService(mockRepo, testDay().Add(-24*time.Hour))
+	mockRepo.GetSettingsFunc = func(_ context.Context) (*Settings, error) {
+		settingsLoads++
+		return windowTestSettings(), nil
+	}
+
+	if _, err := service.CreateRecurringBooking(context.Background(), testSeriesInput()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if settingsLoads != 1 || roleLookups != 1 {
+		t.Errorf("expected settings and role to be loaded once for the series, got %d and %d", settingsLoads, roleLookups)
+	}
+}
+
+func TestService_CreateGroupBooking_AttendeeBookingWindow(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserRoleFunc: func(_ context.Context, userID string) (string, error) {
+			if userID == "admin-1" {
+				return roleAdmin, nil
+			}
+			return roleMember, nil
+		},
+	}
+	// The window ends with Tuesday 24 March; admins are not limited
+	service := newWindowTestService(mockRepo, testDay().Add(8*time.Hour))
+
+	start := testDay().AddDate(0, 0, 15).Add(9 * time.Hour)
+	desk1, desk2 := 1, 2
+	_, err := service.CreateGroupBooking(context.Background(), &CreateGroupInput{
+		OrganizerID: "admin-1",
+		StartTime:   start,
+		EndTime:     start.Add(2 * time.Hour),
+		Attendees:   []GroupAttendee{{UserID: "admin-1", DeskID: &desk1}, {UserID: "user-2", DeskID: &desk2}},
+	})
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected a group error, got %v", err)
+	}
+	if len(groupErr.Failures) != 1 || groupErr.Failures[0].UserID != "user-2" || groupErr.Failures[0].Reason != ErrCodeBeyondAdvanceWindow {
+		t.Errorf("expected only user-2 to be beyond their window, got %+v", groupErr.Failures)
+	}
+}
+
+// ============================================================================
+// CreateRecurringBooking Tests
+// ============================================================================
+
+// testSeriesInput returns a weekly Tuesday/Thursday series of four 09:00-11:00 occurrences
+func testSeriesInput() *CreateSeriesInput {
+	return &CreateSeriesInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+		RRule:     "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4",
+	}
+}
+
+func TestService_CreateRecurringBooking_Success(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	result, err := service.CreateRecurringBooking(context.Background(), testSeriesInput())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Bookings) != 4 {
+		t.Fatalf("expected 4 bookings, got %d", len(result.Bookings))
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("expected no skipped occurrences, got %d", len(result.Skipped))
+	}
+}
+
+func TestService_CreateRecurringBooking_InvalidRule(t *testing.T) {
+	input := testSeriesInput()
+	input.RRule = "FREQ=WEEKLY"
+	service := NewService(&MockRepository{})
+
+	_, err := service.CreateRecurringBooking(context.Background(), input)
+	if !errors.Is(err, ErrInvalidRecurrence) {
+		t.Errorf("expected ErrInvalidRecurrence, got %v", err)
+	}
+}
+
+func TestService_CreateRecurringBooking_ConflictAllOrNothing(t *testing.T) {
+	conflict := Occurrence{StartTime: testDay().AddDate(0, 0, 2).Add(9 * time.Hour), EndTime: testDay().AddDate(0, 0, 2).Add(11 * time.Hour)}
+	mockRepo := &MockRepository{
+		CreateSeriesFunc: func(_ context.Context, _ *CreateSeriesInput, _ []Occurrence) (*SeriesInsertResult, error) {
+			return &SeriesInsertResult{Conflicts: []Occurrence{conflict}}, ErrBookingConflict
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateRecurringBooking(context.Background(), testSeriesInput())
+
+	var seriesErr *SeriesError
+	if !errors.As(err, &seriesErr) {
+		t.Fatalf("expected SeriesError, got %v", err)
+	}
+	if len(seriesErr.Skipped) != 1 || !seriesErr.Skipped[0].StartTime.Equal(conflict.StartTime) {
+		t.Errorf("expected the conflicting occurrence to be reported, got %+v", seriesErr.Skipped)
+	}
+	if seriesErr.Skipped[0].Reason != ErrCodeBookingConflict {
+		t.Errorf("expected reason %s, got %s", ErrCodeBookingConflict, seriesErr.Skipped[0].Reason)
+	}
+}
+
+func TestService_CreateRecurringBooking_PolicyViolationRejectsSeries(t *testing.T) {
+	createCalled := false
+	mockRepo := &MockRepository{
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, date time.Time) (float64, error) {
+			// The second occurrence (Thursday) is already fully booked
+			if date.Equal(testDay().AddDate(0, 0, 2)) {
+				return 10, nil
+			}
+			return 0, nil
+		},
+		CreateSeriesFunc: func(_ context.Context, _ *CreateSeriesInput, _ []Occurrence) (*SeriesInsertResult, error) {
+			createCalled = true
+			return nil, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.CreateRecurringBooking(context.Background(), testSeriesInput())
+
+	var seriesErr *SeriesError
+	if !errors.As(err, &seriesErr) {
+		t.Fatalf("expected SeriesError, got %v", err)
+	}
+	if len(seriesErr.Skipped) != 1 || seriesErr.Skipped[0].Reason != ErrCodeDailyLimitExceeded {
+		t.Errorf("expected one daily limit skip, got %+v", seriesErr.Skipped)
+	}
+	if createCalled {
+		t.Error("expected no series to be created")
+	}
+}
+
+func TestService_CreateRecurringBooking_PartialSkipsOccurrences(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetUserDailyHoursFunc: func(_ context.Context, _ string, date time.Time) (float64, error) {
+			if date.Equal(testDay().AddDate(0, 0, 2)) {
+				return 10, nil
+			}
+			return 0, nil
+		},
+	}
+	service := NewService(mockRepo)
+	input := testSeriesInput()
+	input.Partial = true
+
+	result, err := service.CreateRecurringBooking(context.Background(), input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Bookings) != 3 {
+		t.Errorf("expected 3 bookings, got %d", len(result.Bookings))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != ErrCodeDailyLimitExceeded {
+		t.Errorf("expected one daily limit skip, got %+v", result.Skipped)
+	}
+}
+
+func TestService_CreateRecurringBooking_SkipsClosures(t *testing.T) {
+	holiday := testDay().AddDate(0, 0, 2)
+	var inserted []Occurrence
+	mockRepo := &MockRepository{
+		GetClosuresFunc: func(_ context.Context, filter *ClosureFilter) ([]*Closure, error) {
+			if filter.DeskID == nil || *filter.DeskID != 1 {
+				t.Errorf("expected closures for desk 1, got %+v", filter)
+			}
+			return []*Closure{{ID: 3, StartTime: holiday, EndTime: holiday.Add(24 * time.Hour), Reason: "public holiday"}}, nil
+		},
+		CreateSeriesFunc: func(_ context.Context, input *CreateSeriesInput, occurrences []Occurrence) (*SeriesInsertResult, error) {
+			inserted = occurrences
+			return &SeriesInsertResult{Series: &Series{ID: 1}}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// Not partial: a closed date is skipped without failing the series
+	result, err := service.CreateRecurringBooking(context.Background(), testSeriesInput())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(inserted) != 3 {
+		t.Errorf("expected 3 occurrences to be booked, got %d", len(inserted))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != ErrCodeClosed {
+		t.Fatalf("expected one closed date to be reported, got %+v", result.Skipped)
+	}
+	if !result.Skipped[0].StartTime.Equal(holiday.Add(9 * time.Hour)) {
+		t.Errorf("expected the holiday occurrence to be skipped, got %v", result.Skipped[0].StartTime)
+	}
+}
+
+// ============================================================================
+// Series Edit Tests
+// ============================================================================
+
+// testSeriesBookings returns three daily 09:00-11:00 occurrences of series 7 starting on testDay
+func testSeriesBookings() []*Booking {
+	seriesID := 7
+	var bookings []*Booking
+	for i := 0; i < 3; i++ {
+		booking := testBooking()
+		booking.ID = 100 + i
+		booking.SeriesID = &seriesID
+		booking.StartTime = booking.StartTime.AddDate(0, 0, i)
+		booking.EndTime = booking.EndTime.AddDate(0, 0, i)
+		bookings = append(bookings, booking)
+	}
+	return bookings
+}
+
+// newSeriesTestService returns a service over the series occurrences with the clock set before testDay
+func newSeriesTestService(mockRepo *MockRepository, occurrences []*Booking) *Service {
+	if mockRepo.GetBookingByIDFunc == nil {
+		mockRepo.GetBookingByIDFunc = func(_ context.Context, id int) (*Booking, error) {
+			for _, occurrence := range occurrences {
+				if occurrence.ID == id {
+					return occurrence, nil
+				}
+			}
+			return nil, ErrBookingNotFound
+		}
+	}
+	if mockRepo.GetSeriesBookingsFunc == nil {
+		mockRepo.GetSeriesBookingsFunc = func(_ context.Context, _ int, from time.Time) ([]*Booking, error) {
+			var result []*Booking
+			for _, occurrence := range occurrences {
+				if !occurrence.StartTime.Before(from) {
+					result = append(result, occurrence)
+				}
+			}
+			return result, nil
+		}
+	}
+
+	service := NewService(mockRepo)
+	service.now = func() time.Time { return testDay().Add(-24 * time.Hour) }
+	return service
+}
+
+func TestService_UpdateSeriesBookings_Following(t *testing.T) {
+	var received []BookingReschedule
+	mockRepo := &MockRepository{
+		RescheduleFunc: func(_ context.Context, changes []BookingReschedule) (*RescheduleResult, error) {
+			received = changes
+			return (&MockRepository{}).RescheduleBookings(context.Background(), changes)
+		},
+	}
+	occurrences := testSeriesBookings()
+	service := newSeriesTestService(mockRepo, occurrences)
+
+	newStart := occurrences[1].StartTime.Add(time.Hour)
+	newEnd := occurrences[1].EndTime.Add(2 * time.Hour)
+	bookings, err := service.UpdateSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 101, ScopeFollowing, &UpdateBookingInput{
+		StartTime: &newStart,
+		EndTime:   &newEnd,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(bookings) != 2 || len(received) != 2 {
+		t.Fatalf("expected 2 occurrences to be rescheduled, got %d", len(bookings))
+	}
+	// Later shifts are applied first so siblings never overlap mid-transaction
+	if received[0].BookingID != 102 {
+		t.Errorf("expected the last occurrence to move first, got booking %d", received[0].BookingID)
+	}
+	for i, booking := range bookings {
+		expectedStart := occurrences[i+1].StartTime.Add(time.Hour)
+		if !booking.StartTime.Equal(expectedStart) || booking.EndTime.Sub(booking.StartTime) != 3*time.Hour {
+			t.Errorf("occurrence %d: unexpected window %v - %v", i, booking.StartTime, booking.EndTime)
+		}
+	}
+}
+
+func TestService_UpdateSeriesBookings_ConflictRejectsAll(t *testing.T) {
+	mockRepo := &MockRepository{
+		RescheduleFunc: func(_ context.Context, changes []BookingReschedule) (*RescheduleResult, error) {
+			return &RescheduleResult{Conflicts: []Occurrence{{StartTime: changes[0].StartTime, EndTime: changes[0].EndTime}}}, ErrBookingConflict
+		},
+	}
+	occurrences := testSeriesBookings()
+	service := newSeriesTestService(mockRepo, occurrences)
+
+	newEnd := occurrences[0].EndTime.Add(time.Hour)
+	_, err := service.UpdateSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 100, ScopeAll, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+
+	var seriesErr *SeriesError
+	if !errors.As(err, &seriesErr) {
+		t.Fatalf("expected SeriesError, got %v", err)
+	}
+	if len(seriesErr.Skipped) != 1 || seriesErr.Skipped[0].Reason != ErrCodeBookingConflict {
+		t.Errorf("expected one conflicting occurrence, got %+v", seriesErr.Skipped)
+	}
+}
+
+func TestService_UpdateSeriesBookings_PolicyViolation(t *testing.T) {
+	rescheduled := false
+	mockRepo := &MockRepository{
+		RescheduleFunc: func(_ context.Context, _ []BookingReschedule) (*RescheduleResult, error) {
+			rescheduled = true
+			return &RescheduleResult{}, nil
+		},
+	}
+	occurrences := testSeriesBookings()
+	service := newSeriesTestService(mockRepo, occurrences)
+
+	// Moving the end past closing time is rejected for every occurrence
+	newEnd := occurrences[0].StartTime.Add(14 * time.Hour)
+	_, err := service.UpdateSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 100, ScopeFollowing, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+
+	var seriesErr *SeriesError
+	if !errors.As(err, &seriesErr) {
+		t.Fatalf("expected SeriesError, got %v", err)
+	}
+	if len(seriesErr.Skipped) != 3 {
+		t.Errorf("expected 3 skipped occurrences, got %d", len(seriesErr.Skipped))
+	}
+	if rescheduled {
+		t.Error("expected no occurrences to be rescheduled")
+	}
+}
+
+func TestService_UpdateSeriesBookings_StartedOccurrence(t *testing.T) {
+	occurrences := testSeriesBookings()
+	service := newSeriesTestService(&MockRepository{}, occurrences)
+	service.now = func() time.Time { return occurrences[0].StartTime.Add(time.Minute) }
+
+	newEnd := occurrences[0].EndTime.Add(time.Hour)
+	_, err := service.UpdateSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 100, ScopeFollowing, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if !errors.Is(err, ErrBookingStarted) {
+		t.Errorf("expected ErrBookingStarted, got %v", err)
+	}
+}
+
+func TestService_UpdateSeriesBookings_AllSkipsPastOccurrences(t *testing.T) {
+	var received []BookingReschedule
+	mockRepo := &MockRepository{
+		RescheduleFunc: func(_ context.Context, changes []BookingReschedule) (*RescheduleResult, error) {
+			received = changes
+			return &RescheduleResult{}, nil
+		},
+	}
+	occurrences := testSeriesBookings()
+	service := newSeriesTestService(mockRepo, occurrences)
+	// The first occurrence is over; editing the second with ScopeAll must leave it alone
+	service.now = func() time.Time { return occurrences[0].EndTime.Add(time.Hour) }
+
+	newEnd := occurrences[1].EndTime.Add(time.Hour)
+	_, err := service.UpdateSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 101, ScopeAll, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected 2 occurrences to be rescheduled, got %d", len(received))
+	}
+	for _, change := range received {
+		if change.BookingID == 100 {
+			t.Error("expected the past occurrence to be left unchanged")
+		}
+	}
+}
+
+func TestService_UpdateSeriesBookings_NotSeries(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return testBooking(), nil
+		},
+	}
+	service := newSeriesTestService(mockRepo, nil)
+
+	newEnd := testBooking().EndTime.Add(time.Hour)
+	_, err := service.UpdateSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42, ScopeFollowing, &UpdateBookingInput{
+		EndTime: &newEnd,
+	})
+	if !errors.Is(err, ErrNotSeriesBooking) {
+		t.Errorf("expected ErrNotSeriesBooking, got %v", err)
+	}
+}
+
+func TestService_CancelSeriesBookings_Following(t *testing.T) {
+	occurrences := testSeriesBookings()
+	var cancelFrom time.Time
+	mockRepo := &MockRepository{
+		CancelSeriesFunc: func(_ context.Context, seriesID int, from time.Time, _ StatusChange) ([]*Booking, error) {
+			if seriesID != 7 {
+				t.Errorf("expected series 7, got %d", seriesID)
+			}
+			cancelFrom = from
+			return occurrences[1:], nil
+		},
+	}
+	service := newSeriesTestService(mockRepo, occurrences)
+
+	bookings, err := service.CancelSeriesBookings(context.Background(), Actor{UserID: "user-123", Role: "member"}, 101, ScopeFollowing, "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cancelFrom.Equal(occurrences[1].StartTime) {
+		t.Errorf("expected cancellation from %v, got %v", occurrences[1].StartTime, cancelFrom)
+	}
+	if len(bookings) != 2 {
+		t.Errorf("expected 2 cancelled bookings, got %d", len(bookings))
+	}
+}
+
+func TestService_CancelBooking_StartedSeriesOccurrence(t *testing.T) {
+	occurrences := testSeriesBookings()
+	service := newSeriesTestService(&MockRepository{}, occurrences)
+	service.now = func() time.Time { return occurrences[0].EndTime.Add(time.Hour) }
+
+	_, err := service.CancelBooking(context.Background(), Actor{UserID: "user-123", Role: "member"}, 100, "")
+	if !errors.Is(err, ErrBookingStarted) {
+		t.Errorf("expected ErrBookingStarted, got %v", err)
+	}
+}
+
+// ============================================================================
+// Waitlist Tests
+// ============================================================================
+
+func TestService_JoinWaitlist_Desk(t *testing.T) {
+	service := NewService(&MockRepository{})
+	deskID := 1
+
+	entry, err := service.JoinWaitlist(context.Background(), &JoinWaitlistInput{
+		UserID:    "user-123",
+		DeskID:    &deskID,
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if entry.Status != WaitlistWaiting {
+		t.Errorf("expected status waiting, got %s", entry.Status)
+	}
+}
+
+func TestService_JoinWaitlist_InvalidTarget(t *testing.T) {
+	deskID := 1
+	wing := WingEast
+	unknownWing := "North"
+
+	tests := []struct {
+		name   string
+		deskID *int
+		wing   *string
+	}{
+		{"no target", nil, nil},
+		{"desk and wing", &deskID, &wing},
+		{"unknown wing", nil, &unknownWing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&MockRepository{})
+			_, err := service.JoinWaitlist(context.Background(), &JoinWaitlistInput{
+				UserID:    "user-123",
+				DeskID:    tt.deskID,
+				Wing:      tt.wing,
+				StartTime: testDay().Add(9 * time.Hour),
+				EndTime:   testDay().Add(11 * time.Hour),
+			})
+			if !errors.Is(err, ErrInvalidWaitlistTarget) {
+				t.Errorf("expected ErrInvalidWaitlistTarget, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_JoinWaitlist_OutsideOpeningHours(t *testing.T) {
+	service := NewService(&MockRepository{})
+	wing := WingWest
+
+	_, err := service.JoinWaitlist(context.Background(), &JoinWaitlistInput{
+		UserID:    "user-123",
+		Wing:      &wing,
+		StartTime: testDay().Add(6 * time.Hour),
+		EndTime:   testDay().Add(9 * time.Hour),
+	})
+	if !errors.Is(err, ErrOutsideOpeningHours) {
+		t.Errorf("expected ErrOutsideOpeningHours, got %v", err)
+	}
+}
+
+func TestService_LeaveWaitlist_OtherMember(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetWaitlistEntryFunc: func(_ context.Context, id int) (*WaitlistEntry, error) {
+			return &WaitlistEntry{ID: id, UserID: "other-user", Status: WaitlistWaiting}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.LeaveWaitlist(context.Background(), Actor{UserID: "user-123", Role: "member"}, 5)
+	if !errors.Is(err, ErrNotBookingOwner) {
+		t.Errorf("expected ErrNotBookingOwner, got %v", err)
+	}
+}
+
+func TestService_LeaveWaitlist_Owner(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetWaitlistEntryFunc: func(_ context.Context, id int) (*WaitlistEntry, error) {
+			return &WaitlistEntry{ID: id, UserID: "user-123", Status: WaitlistWaiting}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	entry, err := service.LeaveWaitlist(context.Background(), Actor{UserID: "user-123", Role: "member"}, 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if entry.Status != WaitlistCancelled {
+		t.Errorf("expected status cancelled, got %s", entry.Status)
+	}
+}
+
+// ============================================================================
+// CheckIn Tests
+// ============================================================================
+
+// newCheckInTestService returns a service over testBooking with the clock set to now
+func newCheckInTestService(booking *Booking, now time.Time) *Service {
+	service := NewService(&MockRepository{
+		GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+			return booking, nil
+		},
+	})
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestService_CheckIn_Window(t *testing.T) {
+	start := testBooking().StartTime
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected error
+	}{
+		{"too early", start.Add(-CheckInOpensBefore - time.Minute), ErrCheckInNotOpen},
+		{"window opens", start.Add(-CheckInOpensBefore), nil},
+		{"at start", start, nil},
+		{"end of grace period", start.Add(15 * time.Minute), nil},
+		{"after grace period", start.Add(16 * time.Minute), ErrCheckInClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newCheckInTestService(testBooking(), tt.now)
+
+			booking, err := service.CheckIn(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if tt.expected == nil && (booking.CheckedInAt == nil || !booking.CheckedInAt.Equal(tt.now)) {
+				t.Errorf("expected checked_in_at %v, got %v", tt.now, booking.CheckedInAt)
+			}
+		})
+	}
+}
+
+func TestService_CheckIn_AlreadyCheckedIn(t *testing.T) {
+	booking := testBooking()
+	checkedIn := booking.StartTime
+	booking.CheckedInAt = &checkedIn
+	service := newCheckInTestService(booking, booking.StartTime.Add(time.Minute))
+
+	_, err := service.CheckIn(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if !errors.Is(err, ErrAlreadyCheckedIn) {
+		t.Errorf("expected ErrAlreadyCheckedIn, got %v", err)
+	}
+}
+
+func TestService_CheckIn_NoShow(t *testing.T) {
+	booking := testBooking()
+	booking.Status = StatusNoShow
+	service := newCheckInTestService(booking, booking.StartTime)
+
+	_, err := service.CheckIn(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if !errors.Is(err, ErrBookingNotActive) {
+		t.Errorf("expected ErrBookingNotActive, got %v", err)
+	}
+}
+
+func TestService_CheckIn_OtherMember(t *testing.T) {
+	service := newCheckInTestService(testBooking(), testBooking().StartTime)
+
+	_, err := service.CheckIn(context.Background(), Actor{UserID: "other-user", Role: "member"}, 42)
+	if !errors.Is(err, ErrNotBookingOwner) {
+		t.Errorf("expected ErrNotBookingOwner, got %v", err)
+	}
+}
+
+// ============================================================================
+// SweepNoShows Tests
+// ============================================================================
+
+func TestService_SweepNoShows_Batches(t *testing.T) {
+	calls := 0
+	mockRepo := &MockRepository{
+		MarkNoShowsFunc: func(_ context.Context, gracePeriodMinutes, limit int, _ StatusChange) ([]*Booking, error) {
+			if gracePeriodMinutes != 15 {
+				t.Errorf("expected grace period 15, got %d", gracePeriodMinutes)
+			}
+			calls++
+			if calls == 1 {
+				return make([]*Booking, limit), nil
+			}
+			return make([]*Booking, 3), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	marked, err := service.SweepNoShows(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if marked != noShowBatchSize+3 {
+		t.Errorf("expected %d marked, got %d", noShowBatchSize+3, marked)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 batches, got %d", calls)
+	}
+}
+
+// ============================================================================
+// Hold Tests
+// ============================================================================
+
+// heldBooking returns testBooking as a hold expiring at the given time
+func heldBooking(expiresAt time.Time) *Booking {
+	booking := testBooking()
+	booking.Status = StatusHeld
+	booking.HoldExpiresAt = &expiresAt
+	return booking
+}
+
+func TestService_CreateBooking_PassesHold(t *testing.T) {
+	var gotHold bool
+	service := NewService(&MockRepository{
+		CreateBookingFunc: func(_ context.Context, input *CreateBookingInput) (*Booking, error) {
+			gotHold = input.Hold
+			return heldBooking(input.StartTime), nil
+		},
+	})
+
+	booking, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    1,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+		Hold:      true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gotHold {
+		t.Error("expected the hold flag to reach the repository")
+	}
+	if booking.Status != StatusHeld {
+		t.Errorf("expected status held, got %s", booking.Status)
+	}
+}
+
+func TestService_ConfirmHold(t *testing.T) {
+	now := testDay().Add(8 * time.Hour)
+
+	tests := []struct {
+		name     string
+		booking  *Booking
+		expected error
+	}{
+		{"unexpired hold", heldBooking(now.Add(time.Minute)), nil},
+		{"expired hold", heldBooking(now), ErrHoldExpired},
+		{"already confirmed", testBooking(), ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotChange StatusChange
+			service := NewService(&MockRepository{
+				GetBookingByIDFunc: func(_ context.Context, _ int) (*Booking, error) {
+					return tt.booking, nil
+				},
+				ConfirmHoldFunc: func(_ context.Context, id int, change StatusChange) (*Booking, error) {
+					gotChange = change
+					booking := testBooking()
+					booking.ID = id
+					return booking, nil
+				},
+			})
+			service.now = func() time.Time { return now }
+
+			_, err := service.ConfirmHold(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if err == nil && (gotChange.ActorID == nil || *gotChange.ActorID != "user-123") {
+				t.Error("expected the confirmation to be attributed to the actor")
+			}
+		})
+	}
+}
+
+func TestService_ConfirmHold_OtherMember(t *testing.T) {
+	now := testDay().Add(8 * time.Hour)
+	service := newCheckInTestService(heldBooking(now.Add(time.Minute)), now)
+
+	_, err := service.ConfirmHold(context.Background(), Actor{UserID: "other-user", Role: "member"}, 42)
+	if !errors.Is(err, ErrNotBookingOwner) {
+		t.Errorf("expected ErrNotBookingOwner, got %v", err)
+	}
+}
+
+func TestService_SweepExpiredHolds_Batches(t *testing.T) {
+	calls := 0
+	mockRepo := &MockRepository{
+		ExpireHoldsFunc: func(_ context.Context, limit int, change StatusChange) ([]*Booking, error) {
+			if change.ActorID != nil {
+				t.Error("expected expiry to be recorded as a system transition")
+			}
+			calls++
+			if calls == 1 {
+				return make([]*Booking, limit), nil
+			}
+			return nil, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	released, err := service.SweepExpiredHolds(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if released != holdBatchSize {
+		t.Errorf("expected %d released, got %d", holdBatchSize, released)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 batches, got %d", calls)
+	}
+}
+
+// ============================================================================
+// CheckOut Tests
+// ============================================================================
+
+// checkedInBooking returns testBooking checked in at the given offset from its start
+func checkedInBooking(offset time.Duration) *Booking {
+	booking := testBooking()
+	checkedIn := booking.StartTime.Add(offset)
+	booking.CheckedInAt = &checkedIn
+	return booking
+}
+
+func TestService_CheckOut_Early(t *testing.T) {
+	booking := checkedInBooking(10 * time.Minute)
+	service := newCheckInTestService(booking, booking.StartTime.Add(time.Hour))
+
+	result, err := service.CheckOut(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != StatusCompleted {
+		t.Errorf("expected status completed, got %s", result.Status)
+	}
+	if !result.ActualEndTime.Equal(booking.StartTime.Add(time.Hour)) {
+		t.Errorf("expected actual end at check-out time, got %v", result.ActualEndTime)
+	}
+	if *result.ActualDurationMinutes != 50 {
+		t.Errorf("expected 50 minutes used since check-in, got %d", *result.ActualDurationMinutes)
+	}
+	if !result.EndTime.Equal(booking.EndTime) {
+		t.Errorf("expected original end time to be kept, got %v", result.EndTime)
+	}
+}
+
+func TestService_CheckOut_AfterEndIsClamped(t *testing.T) {
+	booking := checkedInBooking(-5 * time.Minute)
+	service := newCheckInTestService(booking, booking.EndTime.Add(time.Hour))
+
+	result, err := service.CheckOut(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !result.ActualEndTime.Equal(booking.EndTime) {
+		t.Errorf("expected actual end clamped to %v, got %v", booking.EndTime, result.ActualEndTime)
+	}
+	if *result.ActualDurationMinutes != 120 {
+		t.Errorf("expected 120 minutes counted from the start, got %d", *result.ActualDurationMinutes)
+	}
+}
+
+func TestService_CheckOut_NoShow(t *testing.T) {
+	booking := checkedInBooking(0)
+	booking.Status = StatusNoShow
+	service := newCheckInTestService(booking, booking.StartTime.Add(time.Hour))
+
+	_, err := service.CheckOut(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+	}
+}
+
+func TestService_CheckOut_NotCheckedIn(t *testing.T) {
+	booking := testBooking()
+	service := newCheckInTestService(booking, booking.StartTime.Add(time.Hour))
+
+	_, err := service.CheckOut(context.Background(), Actor{UserID: "user-123", Role: "member"}, 42)
+	if !errors.Is(err, ErrNotCheckedIn) {
+		t.Errorf("expected ErrNotCheckedIn, got %v", err)
+	}
+}
+
+// ============================================================================
+// SearchAvailability Tests
+// ============================================================================
+
+func TestService_SearchAvailability(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+
+	var gotFilter *AvailabilityFilter
+	mockRepo := &MockRepository{
+		GetDeskOccupancyFunc: func(_ context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			gotFilter = filter
+			return []*DeskOccupancy{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}},
+				{Desk: Desk{ID: 2, DeskNumber: "E2", Wing: WingEast}, Busy: []Interval{
+					{StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour)},
+				}},
+				{Desk: Desk{ID: 3, DeskNumber: "E3", Wing: WingEast}, Busy: []Interval{
+					{StartTime: start.Add(-time.Hour), EndTime: end},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	wing := WingEast
+	availability, err := service.SearchAvailability(context.Background(), &AvailabilityFilter{
+		StartTime: start,
+		EndTime:   end,
+		Wing:      &wing,
+		Features:  []string{"standing"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotFilter == nil || *gotFilter.Wing != WingEast || len(gotFilter.Features) != 1 {
+		t.Errorf("expected the filter to be passed through, got %+v", gotFilter)
+	}
+
+	// The fully booked desk is left out
+	if len(availability) != 2 {
+		t.Fatalf("expected 2 desks, got %d", len(availability))
+	}
+	if !availability[0].FullyAvailable || len(availability[0].FreeIntervals) != 1 {
+		t.Errorf("expected desk 1 to be fully available, got %+v", availability[0])
+	}
+	if availability[1].FullyAvailable || len(availability[1].FreeIntervals) != 2 {
+		t.Errorf("expected desk 2 to have two free intervals, got %+v", availability[1])
+	}
+}
+
+func TestService_SearchAvailability_OpeningHours(t *testing.T) {
+	day := testDay()
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return scheduleTestSettings(), nil
+		},
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			return []*DeskOccupancy{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}},
+				{Desk: Desk{ID: 2, DeskNumber: "W1", Wing: WingWest}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	// Tuesday 06:00 until Monday 00:00, across the Sunday closure
+	availability, err := service.SearchAvailability(context.Background(), &AvailabilityFilter{
+		StartTime: day.Add(6 * time.Hour),
+		EndTime:   day.AddDate(0, 0, 6),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(availability) != 2 {
+		t.Fatalf("expected 2 desks, got %d", len(availability))
+	}
+
+	east, west := availability[0].FreeIntervals, availability[1].FreeIntervals
+	if len(east) != 5 || len(west) != 5 {
+		t.Fatalf("expected one free interval for each of the five open days, got %v and %v", east, west)
+	}
+	if !east[0].StartTime.Equal(day.Add(7*time.Hour)) || !west[0].StartTime.Equal(day.Add(8*time.Hour)) {
+		t.Errorf("expected East to open at 07:00 and West at 08:00, got %v and %v", east[0].StartTime, west[0].StartTime)
+	}
+	if !east[4].EndTime.Equal(day.AddDate(0, 0, 4).Add(22 * time.Hour)) {
+		t.Errorf("expected the last free interval to end on Saturday night, got %v", east[4].EndTime)
+	}
+	if availability[0].FullyAvailable {
+		t.Error("expected no desk to be fully available outside opening hours")
+	}
+}
+
+func TestService_SearchAvailability_InvalidInput(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	north := "North"
+
+	tests := []struct {
+		name     string
+		filter   *AvailabilityFilter
+		expected error
+	}{
+		{"end before start", &AvailabilityFilter{StartTime: start, EndTime: start.Add(-time.Hour)}, ErrInvalidTimeRange},
+		{"unknown wing", &AvailabilityFilter{StartTime: start, EndTime: start.Add(time.Hour), Wing: &north}, ErrInvalidWing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&MockRepository{
+				GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+					t.Error("expected no query for invalid input")
+					return nil, nil
+				},
+			})
+
+			_, err := service.SearchAvailability(context.Background(), tt.filter)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// SuggestSlots Tests
+// ============================================================================
+
+// testWingOccupancy returns the East wing on testDay: desk 2 (E2) is booked 09:00-11:00 and
+// 12:00-17:00, desk 3 (E3) overlaps 09:00-11:00 and desks 1, 4 and 5 are free
+func testWingOccupancy() []*DeskOccupancy {
+	at := func(hours int) time.Time { return testDay().Add(time.Duration(hours) * time.Hour) }
+	return []*DeskOccupancy{
+		{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}},
+		{Desk: Desk{ID: 2, DeskNumber: "E2", Wing: WingEast}, Busy: []Interval{
+			{StartTime: at(9), EndTime: at(11)},
+			{StartTime: at(12), EndTime: at(17)},
+		}},
+		{Desk: Desk{ID: 3, DeskNumber: "E3", Wing: WingEast}, Busy: []Interval{
+			{StartTime: at(10), EndTime: at(12)},
+		}},
+		{Desk: Desk{ID: 4, DeskNumber: "E4", Wing: WingEast}},
+		{Desk: Desk{ID: 5, DeskNumber: "E5", Wing: WingEast}},
+	}
+}
+
+func TestService_SuggestSlots(t *testing.T) {
+	var gotFilter *AvailabilityFilter
+	mockRepo := &MockRepository{
+		GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+			return &Desk{ID: id, DeskNumber: "E2", Wing: WingEast, Status: "available"}, nil
+		},
+		GetDeskOccupancyFunc: func(_ context.Context, filter *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			gotFilter = filter
+			return testWingOccupancy(), nil
+		},
+	}
+	service := NewService(mockRepo)
+	service.now = func() time.Time { return testDay() }
+
+	suggestions, err := service.SuggestSlots(context.Background(), 2, testDay().Add(9*time.Hour), testDay().Add(11*time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The search never looks into the past
+	if gotFilter == nil || !gotFilter.StartTime.Equal(testDay()) || gotFilter.Wing == nil || *gotFilter.Wing != WingEast {
+		t.Fatalf("unexpected occupancy filter: %+v", gotFilter)
+	}
+
+	expected := []Suggestion{
+		// The one-hour gaps at 08:00 and 11:00 are too short; 17:00 is the nearest fit
+		{Kind: SuggestionSameDesk, DeskID: 2, StartTime: testDay().Add(17 * time.Hour)},
+		{Kind: SuggestionSameDesk, DeskID: 2, StartTime: testDay().Add(33 * time.Hour)},
+		{Kind: SuggestionSameDesk, DeskID: 2, StartTime: testDay().Add(57 * time.Hour)},
+		// E3 overlaps the window, so the nearest free desks are E1, E4 and E5
+		{Kind: SuggestionNearbyDesk, DeskID: 1, StartTime: testDay().Add(9 * time.Hour)},
+		{Kind: SuggestionNearbyDesk, DeskID: 4, StartTime: testDay().Add(9 * time.Hour)},
+		{Kind: SuggestionNearbyDesk, DeskID: 5, StartTime: testDay().Add(9 * time.Hour)},
+	}
+	if len(suggestions) != len(expected) {
+		t.Fatalf("expected %d suggestions, got %d: %+v", len(expected), len(suggestions), suggestions)
+	}
+	for i, want := range expected {
+		got := suggestions[i]
+		if got.Kind != want.Kind || got.DeskID != want.DeskID || !got.StartTime.Equal(want.StartTime) {
+			t.Errorf("suggestion %d: expected %s desk %d at %v, got %s desk %d at %v",
+				i, want.Kind, want.DeskID, want.StartTime, got.Kind, got.DeskID, got.StartTime)
+		}
+		if got.EndTime.Sub(got.StartTime) != 2*time.Hour {
+			t.Errorf("suggestion %d: expected a two-hour window, got %v", i, got.EndTime.Sub(got.StartTime))
+		}
+	}
+}
+
+func TestService_SuggestSlots_RespectsOpeningHours(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+			return &Desk{ID: id, DeskNumber: "E1", Wing: WingEast, Status: "available"}, nil
+		},
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			return []*DeskOccupancy{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast}, Busy: []Interval{
+					{StartTime: testDay().Add(8 * time.Hour), EndTime: testDay().Add(21 * time.Hour)},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+	service.now = func() time.Time { return testDay() }
+
+	// The desk is free before 08:00, but outside opening hours; the 21:00 gap is too short,
+	// so the nearest window is the same time the next day
+	suggestions, err := service.SuggestSlots(context.Background(), 1, testDay().Add(20*time.Hour), testDay().Add(22*time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggestions) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	for _, suggestion := range suggestions {
+		if !withinOpeningHours(suggestion.StartTime, suggestion.EndTime, nil, defaultTestSettings()) {
+			t.Errorf("suggestion %v-%v is outside opening hours", suggestion.StartTime, suggestion.EndTime)
+		}
+	}
+	if !suggestions[0].StartTime.Equal(testDay().Add(44 * time.Hour)) {
+		t.Errorf("expected the nearest suggestion at 20:00 the next day, got %v", suggestions[0].StartTime)
+	}
+}
+
+func TestService_SuggestSlots_DeskNotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetDeskFunc: func(_ context.Context, _ int) (*Desk, error) {
+			return nil, ErrDeskNotFound
+		},
+	}
+	service := NewService(mockRepo)
+
+	_, err := service.SuggestSlots(context.Background(), 99, testDay().Add(9*time.Hour), testDay().Add(11*time.Hour))
+	if !errors.Is(err, ErrDeskNotFound) {
+		t.Errorf("expected ErrDeskNotFound, got %v", err)
+	}
+}
+
+func TestService_CreateBooking_ConflictSuggestions(t *testing.T) {
+	mockRepo := &MockRepository{
+		CreateBookingFunc: func(_ context.Context, _ *CreateBookingInput) (*Booking, error) {
+			return nil, ErrBookingConflict
+		},
+		GetDeskFunc: func(_ context.Context, id int) (*Desk, error) {
+			return &Desk{ID: id, DeskNumber: "E2", Wing: WingEast, Status: "available"}, nil
+		},
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			return testWingOccupancy(), nil
+		},
+	}
+	service := NewService(mockRepo)
+	service.now = func() time.Time { return testDay() }
+
+	_, err := service.CreateBooking(context.Background(), &CreateBookingInput{
+		DeskID:    2,
+		UserID:    "user-123",
+		StartTime: testDay().Add(9 * time.Hour),
+		EndTime:   testDay().Add(11 * time.Hour),
+	})
+
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if !errors.Is(err, ErrBookingConflict) {
+		t.Error("expected ConflictError to match ErrBookingConflict")
+	}
+	if len(conflictErr.Suggestions) != 6 {
+		t.Errorf("expected 6 suggestions, got %d", len(conflictErr.Suggestions))
+	}
+}
+
+// ============================================================================
+// GetTimeline Tests
+// ============================================================================
+
+// testDeskBookings returns desk 1 with bookings by user-123 (09:00-11:00, checked out at 10:00)
+// and user-456 (14:00-16:00) on testDay, and desk 2 under maintenance
+func testDeskBookings() []*DeskBookings {
+	at := func(hours int) time.Time { return testDay().Add(time.Duration(hours) * time.Hour) }
+	checkedOut := at(10)
+	return []*DeskBookings{
+		{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast, Status: "available"}, Bookings: []*Booking{
+			{ID: 10, DeskID: 1, UserID: "user-123", StartTime: at(9), EndTime: at(11), Status: StatusCompleted, ActualEndTime: &checkedOut},
+			{ID: 11, DeskID: 1, UserID: "user-456", StartTime: at(14), EndTime: at(16), Status: StatusConfirmed},
+		}},
+		{Desk: Desk{ID: 2, DeskNumber: "E2", Wing: WingEast, Status: "maintenance"}},
+	}
+}
+
+func TestService_GetTimeline(t *testing.T) {
+	var gotStart, gotEnd time.Time
+	mockRepo := &MockRepository{
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, startTime, endTime time.Time) ([]*DeskBookings, error) {
+			gotStart, gotEnd = startTime, endTime
+			return testDeskBookings(), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	wing := WingEast
+	timelines, err := service.GetTimeline(context.Background(), Actor{UserID: "user-123", Role: "member"},
+		testDay().Add(15*time.Hour), &wing)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gotStart.Equal(testDay()) || !gotEnd.Equal(testDay().Add(24*time.Hour)) {
+		t.Errorf("expected the whole day to be queried, got %v-%v", gotStart, gotEnd)
+	}
+	if len(timelines) != 2 {
+		t.Fatalf("expected 2 desks, got %d", len(timelines))
+	}
+
+	at := func(hours int) time.Time { return testDay().Add(time.Duration(hours) * time.Hour) }
+	expected := []struct {
+		segmentType SegmentType
+		start, end  time.Time
+	}{
+		{SegmentFree, at(8), at(9)},
+		{SegmentBusy, at(9), at(10)},
+		{SegmentFree, at(10), at(14)},
+		{SegmentBusy, at(14), at(16)},
+		{SegmentFree, at(16), at(22)},
+	}
+	segments := timelines[0].Segments
+	if len(segments) != len(expected) {
+		t.Fatalf("expected %d segments, got %d: %+v", len(expected), len(segments), segments)
+	}
+	for i, want := range expected {
+		got := segments[i]
+		if got.Type != want.segmentType || !got.StartTime.Equal(want.start) || !got.EndTime.Equal(want.end) {
+			t.Errorf("segment %d: expected %s %v-%v, got %s %v-%v",
+				i, want.segmentType, want.start, want.end, got.Type, got.StartTime, got.EndTime)
+		}
+	}
+
+	// Members see their own bookings' owner but not anyone else's
+	if segments[1].UserID == nil || *segments[1].UserID != "user-123" || *segments[1].BookingID != 10 {
+		t.Errorf("expected own booking 10 with owner, got %+v", segments[1])
+	}
+	if segments[3].UserID != nil || *segments[3].BookingID != 11 || *segments[3].Status != StatusConfirmed {
+		t.Errorf("expected booking 11 with a masked owner, got %+v", segments[3])
+	}
+
+	// Desks under maintenance have no free segments
+	if len(timelines[1].Segments) != 0 {
+		t.Errorf("expected no segments for a desk under maintenance, got %+v", timelines[1].Segments)
+	}
+}
+
+func TestService_GetTimeline_ClosedDay(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetSettingsFunc: func(_ context.Context) (*Settings, error) {
+			return scheduleTestSettings(), nil
+		},
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, _, _ time.Time) ([]*DeskBookings, error) {
+			return []*DeskBookings{{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast, Status: "available"}}}, nil
+		},
+	}
+	service := NewService(mockRepo)
+	actor := Actor{UserID: "user-123", Role: "member"}
+
+	timelines, err := service.GetTimeline(context.Background(), actor, testDay().AddDate(0, 0, 5), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(timelines[0].Segments) != 0 {
+		t.Errorf("expected no free segments on a Sunday, got %+v", timelines[0].Segments)
+	}
+
+	timelines, err = service.GetTimeline(context.Background(), actor, testDay(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if free := timelines[0].Segments; len(free) != 1 || !free[0].StartTime.Equal(testDay().Add(7*time.Hour)) {
+		t.Errorf("expected the East wing to be free from 07:00, got %+v", free)
+	}
+}
+
+func TestService_GetTimeline_AdminSeesOwners(t *testing.T) {
+	mockRepo := &MockRepository{
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, _, _ time.Time) ([]*DeskBookings, error) {
+			return testDeskBookings(), nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	timelines, err := service.GetTimeline(context.Background(), Actor{UserID: "admin-1", Role: "admin"}, testDay(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, segment := range timelines[0].Segments {
+		if segment.Type == SegmentBusy && segment.UserID == nil {
+			t.Errorf("expected admins to see the owner of booking %d", *segment.BookingID)
+		}
+	}
+}
+
+func TestService_GetTimeline_InvalidWing(t *testing.T) {
+	service := NewService(&MockRepository{})
+
+	north := "North"
+	_, err := service.GetTimeline(context.Background(), Actor{UserID: "user-123", Role: "member"}, testDay(), &north)
+	if !errors.Is(err, ErrInvalidWing) {
+		t.Errorf("expected ErrInvalidWing, got %v", err)
+	}
+}
+
+func TestService_GetTimeline_Maintenance(t *testing.T) {
+	at := func(hours, minutes int) time.Time {
+		return testDay().Add(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	}
+	mockRepo := &MockRepository{
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, _, _ time.Time) ([]*DeskBookings, error) {
+			return []*DeskBookings{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Status: "available", CleaningBufferMinutes: 15}, Maintenance: []*MaintenanceBlock{
+					{ID: 5, DeskID: 1, StartTime: at(13, 0), EndTime: at(18, 0), Reason: "monitor replacement"},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	timelines, err := service.GetTimeline(context.Background(), Actor{UserID: "user-123", Role: "member"}, testDay(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	segments := timelines[0].Segments
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", segments)
+	}
+	if segments[0].Type != SegmentFree || !segments[0].EndTime.Equal(at(12, 45)) {
+		t.Errorf("expected the morning to be free until 12:45, got %+v", segments[0])
+	}
+	block := segments[1]
+	if block.Type != SegmentMaintenance || *block.MaintenanceID != 5 || *block.Reason != "monitor replacement" {
+		t.Errorf("expected maintenance block 5, got %+v", block)
+	}
+	if !block.StartTime.Equal(at(13, 0)) || !block.EndTime.Equal(at(18, 0)) {
+		t.Errorf("expected maintenance from 13:00 to 18:00, got %v-%v", block.StartTime, block.EndTime)
+	}
+	if segments[2].Type != SegmentFree || !segments[2].StartTime.Equal(at(18, 0)) {
+		t.Errorf("expected the evening to be free from 18:00, got %+v", segments[2])
+	}
+}
+
+func TestService_SearchAvailability_HidesClosures(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	mockRepo := &MockRepository{
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			// Closed 12:00-14:00; no cleaning buffer is kept before a closure
+			return []*DeskOccupancy{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast, CleaningBufferMinutes: 15}, Closed: []Interval{
+					{StartTime: start.Add(3 * time.Hour), EndTime: start.Add(5 * time.Hour)},
+				}},
+				{Desk: Desk{ID: 2, DeskNumber: "E2", Wing: WingEast}, Closed: []Interval{
+					{StartTime: start.Add(-time.Hour), EndTime: start.Add(9 * time.Hour)},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	availability, err := service.SearchAvailability(context.Background(), &AvailabilityFilter{
+		StartTime: start,
+		EndTime:   start.Add(8 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(availability) != 1 || availability[0].Desk.ID != 1 {
+		t.Fatalf("expected only desk 1 to have free time, got %+v", availability)
+	}
+
+	free := availability[0].FreeIntervals
+	if len(free) != 2 || !free[0].EndTime.Equal(start.Add(3*time.Hour)) || !free[1].StartTime.Equal(start.Add(5*time.Hour)) {
+		t.Errorf("expected free time around the 12:00-14:00 closure, got %+v", free)
+	}
+}
+
+func TestService_GetTimeline_Closure(t *testing.T) {
+	at := func(hours int) time.Time { return testDay().Add(time.Duration(hours) * time.Hour) }
+	mockRepo := &MockRepository{
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, _, _ time.Time) ([]*DeskBookings, error) {
+			return []*DeskBookings{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Status: "available", CleaningBufferMinutes: 15}, Closures: []*Closure{
+					{ID: 3, StartTime: at(15), EndTime: at(48), Reason: "office shutdown"},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	timelines, err := service.GetTimeline(context.Background(), Actor{UserID: "user-123", Role: "member"}, testDay(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	segments := timelines[0].Segments
+	if len(segments) != 2 {
+		t.Fatalf("expected 2 segments, got %+v", segments)
+	}
+	if segments[0].Type != SegmentFree || !segments[0].StartTime.Equal(at(8)) || !segments[0].EndTime.Equal(at(15)) {
+		t.Errorf("expected the day to be free from 08:00 until the closure, got %+v", segments[0])
+	}
+	closed := segments[1]
+	if closed.Type != SegmentClosed || *closed.ClosureID != 3 || *closed.Reason != "office shutdown" {
+		t.Errorf("expected closure 3, got %+v", closed)
+	}
+	if !closed.StartTime.Equal(at(15)) || !closed.EndTime.Equal(at(24)) {
+		t.Errorf("expected the closure to be clipped to the day, got %v-%v", closed.StartTime, closed.EndTime)
+	}
+}
+
+func TestService_SearchAvailability_CleaningBuffer(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	mockRepo := &MockRepository{
+		GetDeskOccupancyFunc: func(_ context.Context, _ *AvailabilityFilter) ([]*DeskOccupancy, error) {
+			// Booked 11:00-13:00 with a 15-minute buffer after it
+			return []*DeskOccupancy{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Wing: WingEast, CleaningBufferMinutes: 15}, Busy: []Interval{
+					{StartTime: start.Add(2 * time.Hour), EndTime: start.Add(4*time.Hour + 15*time.Minute)},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	availability, err := service.SearchAvailability(context.Background(), &AvailabilityFilter{
+		StartTime: start,
+		EndTime:   start.Add(8 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(availability) != 1 || len(availability[0].FreeIntervals) != 2 {
+		t.Fatalf("expected one desk with two free intervals, got %+v", availability)
+	}
+
+	free := availability[0].FreeIntervals
+	if !free[0].EndTime.Equal(start.Add(105 * time.Minute)) {
+		t.Errorf("expected the first interval to end at 10:45, got %v", free[0].EndTime)
+	}
+	if !free[1].StartTime.Equal(start.Add(4*time.Hour + 15*time.Minute)) {
+		t.Errorf("expected the second interval to start at 13:15, got %v", free[1].StartTime)
+	}
+}
+
+func TestService_GetTimeline_CleaningBuffer(t *testing.T) {
+	at := func(hours, minutes int) time.Time {
+		return testDay().Add(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	}
+	mockRepo := &MockRepository{
+		GetDeskBookingsFunc: func(_ context.Context, _ *string, _, _ time.Time) ([]*DeskBookings, error) {
+			return []*DeskBookings{
+				{Desk: Desk{ID: 1, DeskNumber: "E1", Status: "available", CleaningBufferMinutes: 30}, Bookings: []*Booking{
+					{ID: 10, UserID: "user-123", StartTime: at(12, 0), EndTime: at(14, 0), Status: StatusConfirmed, BufferMinutes: 30},
+				}},
+			}, nil
+		},
+	}
+	service := NewService(mockRepo)
+
+	timelines, err := service.GetTimeline(context.Background(), Actor{UserID: "user-123", Role: "member"}, testDay(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	segments := timelines[0].Segments
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", segments)
+	}
+	if !segments[0].EndTime.Equal(at(11, 30)) {
+		t.Errorf("expected the morning to be free until 11:30, got %v", segments[0].EndTime)
+	}
+	if segments[1].Type != SegmentBusy || !segments[1].EndTime.Equal(at(14, 0)) {
+		t.Errorf("expected the booking to end at 14:00, got %+v", segments[1])
+	}
+	if !segments[2].StartTime.Equal(at(14, 30)) {
+		t.Errorf("expected the afternoon to be free from 14:30, got %v", segments[2].StartTime)
+	}
+}
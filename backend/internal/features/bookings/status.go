@@ -0,0 +1,40 @@
+package bookings
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidStatusTransition is returned when a booking cannot move from its current status to the requested one
+var ErrInvalidStatusTransition = errors.New("invalid booking status transition")
+
+// statusTransitions lists the statuses each status may move to.
+// Holds are confirmed or released; cancelled, completed and no-show bookings are final.
+var statusTransitions = map[BookingStatus][]BookingStatus{
+	StatusHeld:      {StatusConfirmed, StatusCancelled},
+	StatusConfirmed: {StatusCancelled, StatusCompleted, StatusNoShow},
+}
+
+// CanTransitionTo reports whether a booking in status s may move to status to
+func (s BookingStatus) CanTransitionTo(to BookingStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransition returns ErrInvalidStatusTransition, naming both statuses, when from cannot move to to
+func checkTransition(from, to BookingStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, from, to)
+	}
+	return nil
+}
+
+// StatusChange records who moved a booking to a new status and why
+type StatusChange struct {
+	ActorID *string // nil for transitions made by the system, e.g. the no-show and hold sweepers
+	Reason  string
+}
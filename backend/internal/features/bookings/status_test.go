@@ -0,0 +1,50 @@
+package bookings
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============================================================================
+// Status Transition Tests
+// ============================================================================
+
+func TestBookingStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     BookingStatus
+		to       BookingStatus
+		expected bool
+	}{
+		{StatusHeld, StatusConfirmed, true},
+		{StatusHeld, StatusCancelled, true},
+		{StatusHeld, StatusCompleted, false},
+		{StatusConfirmed, StatusHeld, false},
+		{StatusConfirmed, StatusCancelled, true},
+		{StatusConfirmed, StatusCompleted, true},
+		{StatusConfirmed, StatusNoShow, true},
+		{StatusConfirmed, StatusConfirmed, false},
+		{StatusCancelled, StatusConfirmed, false},
+		{StatusCancelled, StatusCompleted, false},
+		{StatusCompleted, StatusCancelled, false},
+		{StatusNoShow, StatusCompleted, false},
+		{StatusNoShow, StatusConfirmed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckTransition_Invalid(t *testing.T) {
+	err := checkTransition(StatusNoShow, StatusCompleted)
+	if !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Fatalf("expected ErrInvalidStatusTransition, got %v", err)
+	}
+	if err.Error() != "invalid booking status transition: no_show to completed" {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
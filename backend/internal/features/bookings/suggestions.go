@@ -0,0 +1,123 @@
+package bookings
+
+import (
+	"sort"
+	"time"
+)
+
+// suggestionHorizon is how far before and after the requested window same-desk slots are searched
+const suggestionHorizon = 48 * time.Hour
+
+// maxSuggestionsPerKind caps how many slots of each SuggestionKind are offered
+const maxSuggestionsPerKind = 3
+
+// sameDeskSuggestions returns up to limit free windows of the requested length on desk within
+// horizon and opening hours, nearest to the requested start first. Each free stretch of the desk
+// offers the window closest to the requested time of day that fits inside it.
+func sameDeskSuggestions(desk *Desk, occupancy []*DeskOccupancy, requested, horizon Interval, settings *Settings, limit int) []Suggestion {
+	var own *DeskOccupancy
+	for _, occupied := range occupancy {
+		if occupied.Desk.ID == desk.ID {
+			own = occupied
+			break
+		}
+	}
+	// Desks under maintenance are not part of the occupancy and cannot be offered
+	if own == nil {
+		return nil
+	}
+
+	length := requested.EndTime.Sub(requested.StartTime)
+	free := intersectIntervals(occupancyFreeIntervals(horizon, own), openingIntervals(horizon, &desk.Wing, settings))
+
+	var suggestions []Suggestion
+	for _, stretch := range free {
+		if stretch.EndTime.Sub(stretch.StartTime) < length {
+			continue
+		}
+		// Aim for the requested time of day on the stretch's day
+		loc := settings.location()
+		start := atTimeOfDay(startOfDay(stretch.StartTime, loc), wallClock(requested.StartTime, loc))
+		if start.Before(stretch.StartTime) {
+			start = stretch.StartTime
+		}
+		if latest := stretch.EndTime.Add(-length); start.After(latest) {
+			start = latest
+		}
+		suggestions = append(suggestions, Suggestion{
+			Kind:       SuggestionSameDesk,
+			DeskID:     desk.ID,
+			DeskNumber: desk.DeskNumber,
+			StartTime:  start,
+			EndTime:    start.Add(length),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return absDuration(suggestions[i].StartTime.Sub(requested.StartTime)) <
+			absDuration(suggestions[j].StartTime.Sub(requested.StartTime))
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// nearbyDeskSuggestions returns up to limit other desks of the wing that are free for the whole
+// requested window. Desks are ranked by how far apart their desk numbers sort from desk's.
+func nearbyDeskSuggestions(desk *Desk, occupancy []*DeskOccupancy, requested Interval, settings *Settings, now time.Time, limit int) []Suggestion {
+	if requested.StartTime.Before(now) || !withinOpeningHours(requested.StartTime, requested.EndTime, &desk.Wing, settings) {
+		return nil
+	}
+
+	// occupancy is ordered by desk number; position is where desk sorts among it
+	position := sort.Search(len(occupancy), func(i int) bool {
+		return occupancy[i].Desk.DeskNumber >= desk.DeskNumber
+	})
+
+	type rankedDesk struct {
+		desk     Desk
+		distance int
+	}
+	var candidates []rankedDesk
+	for i, occupied := range occupancy {
+		if occupied.Desk.ID == desk.ID || !isWholeWindow(requested, occupancyFreeIntervals(requested, occupied)) {
+			continue
+		}
+		distance := position - i
+		if i >= position {
+			distance = i - position + 1
+			if position < len(occupancy) && occupancy[position].Desk.ID == desk.ID {
+				distance--
+			}
+		}
+		candidates = append(candidates, rankedDesk{desk: occupied.Desk, distance: distance})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	suggestions := make([]Suggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestions = append(suggestions, Suggestion{
+			Kind:       SuggestionNearbyDesk,
+			DeskID:     candidate.desk.ID,
+			DeskNumber: candidate.desk.DeskNumber,
+			StartTime:  requested.StartTime,
+			EndTime:    requested.EndTime,
+		})
+	}
+	return suggestions
+}
+
+// absDuration returns the absolute value of d
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,104 @@
+package bookings
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultNoShowSweepInterval is how often the no-show sweeper runs
+const DefaultNoShowSweepInterval = time.Minute
+
+// DefaultHoldSweepInterval is how often the expired-hold sweeper runs
+const DefaultHoldSweepInterval = 30 * time.Second
+
+// NoShowSweeper periodically marks bookings nobody checked in to as no-show.
+// Every replica may run one; the repository claims rows with SKIP LOCKED.
+type NoShowSweeper struct {
+	service  *Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewNoShowSweeper creates a new no-show sweeper
+func NewNoShowSweeper(service *Service, interval time.Duration, logger *zap.Logger) *NoShowSweeper {
+	return &NoShowSweeper{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run sweeps immediately and then every interval until ctx is cancelled
+func (w *NoShowSweeper) Run(ctx context.Context) {
+	runEvery(ctx, w.interval, w.sweep)
+}
+
+// sweep runs a single pass and logs the outcome
+func (w *NoShowSweeper) sweep(ctx context.Context) {
+	marked, err := w.service.SweepNoShows(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("No-show sweep failed", zap.Error(err), zap.Int("marked", marked))
+		}
+		return
+	}
+
+	if marked > 0 {
+		w.logger.Info("Marked bookings as no-show", zap.Int("count", marked))
+	}
+}
+
+// HoldSweeper periodically releases tentative holds that expired without being confirmed.
+// Every replica may run one; the repository claims rows with SKIP LOCKED.
+type HoldSweeper struct {
+	service  *Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewHoldSweeper creates a new expired-hold sweeper
+func NewHoldSweeper(service *Service, interval time.Duration, logger *zap.Logger) *HoldSweeper {
+	return &HoldSweeper{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run sweeps immediately and then every interval until ctx is cancelled
+func (w *HoldSweeper) Run(ctx context.Context) {
+	runEvery(ctx, w.interval, w.sweep)
+}
+
+// sweep runs a single pass and logs the outcome
+func (w *HoldSweeper) sweep(ctx context.Context) {
+	released, err := w.service.SweepExpiredHolds(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("Hold sweep failed", zap.Error(err), zap.Int("released", released))
+		}
+		return
+	}
+
+	if released > 0 {
+		w.logger.Info("Released expired holds", zap.Int("count", released))
+	}
+}
+
+// runEvery calls sweep immediately and then every interval until ctx is cancelled
+func runEvery(ctx context.Context, interval time.Duration, sweep func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -10,8 +10,10 @@ import (
 
 // Meta contains metadata about the response
 type Meta struct {
-	Timestamp string `json:"timestamp"`
-	RequestID string `json:"request_id"`
+	Timestamp  string `json:"timestamp"`
+	RequestID  string `json:"request_id"`
+	NextCursor string `json:"next_cursor,omitempty"` // Set on paginated lists with a following page
+	PrevCursor string `json:"prev_cursor,omitempty"` // Set on paginated lists with a preceding page
 }
 
 // APIResponse is the standard envelope for all API responses
@@ -24,8 +26,9 @@ type APIResponse struct {
 
 // ErrorInfo contains error details
 type ErrorInfo struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 // Success sends a successful response with data
@@ -38,6 +41,21 @@ func Success(c *fiber.Ctx, status int, data interface{}) error {
 	})
 }
 
+// SuccessWithCursors sends a successful page of a list with its pagination cursors.
+// An empty cursor means there is no page in that direction.
+func SuccessWithCursors(c *fiber.Ctx, status int, data interface{}, nextCursor, prevCursor string) error {
+	meta := buildMeta(c)
+	meta.NextCursor = nextCursor
+	meta.PrevCursor = prevCursor
+
+	return c.Status(status).JSON(APIResponse{
+		Success: true,
+		Data:    data,
+		Error:   nil,
+		Meta:    meta,
+	})
+}
+
 // Error sends an error response
 func Error(c *fiber.Ctx, status int, code, message string) error {
 	return c.Status(status).JSON(APIResponse{
@@ -51,6 +69,20 @@ func Error(c *fiber.Ctx, status int, code, message string) error {
 	})
 }
 
+// ErrorWithDetails sends an error response carrying additional details
+func ErrorWithDetails(c *fiber.Ctx, status int, code, message string, details interface{}) error {
+	return c.Status(status).JSON(APIResponse{
+		Success: false,
+		Data:    nil,
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+		Meta: buildMeta(c),
+	})
+}
+
 // buildMeta creates the meta object for responses
 func buildMeta(c *fiber.Ctx) Meta {
 	requestID := c.Get("X-Request-ID")
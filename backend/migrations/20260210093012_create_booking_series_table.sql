@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create booking_series table for recurring bookings
+CREATE TABLE IF NOT EXISTS booking_series (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    desk_id INTEGER NOT NULL REFERENCES desks(id),
+    rrule TEXT NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    exdates TIMESTAMPTZ[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT booking_series_valid_range CHECK (end_time > start_time)
+);
+
+-- Create index on user_id for user series lookups
+CREATE INDEX idx_booking_series_user_id ON booking_series(user_id);
+
+-- Link bookings to the series they were expanded from
+ALTER TABLE bookings ADD COLUMN series_id INTEGER REFERENCES booking_series(id);
+
+-- Create index on series_id for series-wide operations
+CREATE INDEX idx_bookings_series_id ON bookings(series_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop series link from bookings
+DROP INDEX IF EXISTS idx_bookings_series_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS series_id;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_booking_series_user_id;
+
+-- Drop booking_series table
+DROP TABLE IF EXISTS booking_series;
+-- +goose StatementEnd
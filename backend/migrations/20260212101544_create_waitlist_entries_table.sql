@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create waitlist_status enum
+CREATE TYPE waitlist_status AS ENUM ('waiting', 'fulfilled', 'cancelled');
+
+-- Create waitlist_entries table for members waiting on a desk or any desk in a wing
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    desk_id INTEGER REFERENCES desks(id) ON DELETE CASCADE,
+    wing wing_type,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    status waitlist_status NOT NULL DEFAULT 'waiting',
+    booking_id INTEGER REFERENCES bookings(id) ON DELETE SET NULL,
+    fulfilled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT waitlist_entries_time_check CHECK (end_time > start_time),
+    -- An entry targets either a specific desk or any desk in a wing
+    CONSTRAINT waitlist_entries_target_check CHECK ((desk_id IS NULL) <> (wing IS NULL))
+);
+
+-- Create index on user_id for listing a member's waitlist entries
+CREATE INDEX idx_waitlist_entries_user_id ON waitlist_entries(user_id);
+
+-- Create partial index for FIFO promotion of waiting entries
+CREATE INDEX idx_waitlist_entries_waiting ON waitlist_entries(created_at, id) WHERE status = 'waiting';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop indexes
+DROP INDEX IF EXISTS idx_waitlist_entries_waiting;
+DROP INDEX IF EXISTS idx_waitlist_entries_user_id;
+
+-- Drop waitlist_entries table
+DROP TABLE IF EXISTS waitlist_entries;
+
+-- Drop enum type
+DROP TYPE IF EXISTS waitlist_status;
+-- +goose StatementEnd
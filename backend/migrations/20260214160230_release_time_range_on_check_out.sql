@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Record how long a completed booking was actually used, for reports
+ALTER TABLE bookings ADD COLUMN actual_duration_minutes INTEGER;
+
+-- An early check-out can never end before the booking started
+ALTER TABLE bookings ADD CONSTRAINT bookings_actual_end_time_check
+    CHECK (actual_end_time IS NULL OR actual_end_time >= start_time);
+
+-- Rebuild time_range so an early check-out frees the rest of the booked window.
+-- start_time/end_time keep the original window for history.
+ALTER TABLE bookings DROP CONSTRAINT no_overlapping_bookings;
+DROP INDEX IF EXISTS idx_bookings_time_range;
+ALTER TABLE bookings DROP COLUMN time_range;
+
+ALTER TABLE bookings ADD COLUMN time_range TSTZRANGE
+    GENERATED ALWAYS AS (tstzrange(start_time, COALESCE(actual_end_time, end_time))) STORED;
+
+ALTER TABLE bookings ADD CONSTRAINT no_overlapping_bookings
+    EXCLUDE USING GIST (
+        desk_id WITH =,
+        time_range WITH &&
+    ) WHERE (status NOT IN ('cancelled', 'no_show'));
+
+CREATE INDEX idx_bookings_time_range ON bookings USING GIST(time_range);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Restore time_range over the original booked window
+ALTER TABLE bookings DROP CONSTRAINT no_overlapping_bookings;
+DROP INDEX IF EXISTS idx_bookings_time_range;
+ALTER TABLE bookings DROP COLUMN time_range;
+
+ALTER TABLE bookings ADD COLUMN time_range TSTZRANGE
+    GENERATED ALWAYS AS (tstzrange(start_time, end_time)) STORED;
+
+ALTER TABLE bookings ADD CONSTRAINT no_overlapping_bookings
+    EXCLUDE USING GIST (
+        desk_id WITH =,
+        time_range WITH &&
+    ) WHERE (status NOT IN ('cancelled', 'no_show'));
+
+CREATE INDEX idx_bookings_time_range ON bookings USING GIST(time_range);
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_actual_end_time_check;
+ALTER TABLE bookings DROP COLUMN IF EXISTS actual_duration_minutes;
+-- +goose StatementEnd
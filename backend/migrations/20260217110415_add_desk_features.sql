@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add free-form desk attributes (e.g. 'standing', 'dual_monitor') used to filter availability
+ALTER TABLE desks ADD COLUMN features TEXT[] NOT NULL DEFAULT '{}';
+
+-- Create GIN index for containment filters on features
+CREATE INDEX idx_desks_features ON desks USING GIN(features);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop index
+DROP INDEX IF EXISTS idx_desks_features;
+
+-- Drop features column
+ALTER TABLE desks DROP COLUMN IF EXISTS features;
+-- +goose StatementEnd
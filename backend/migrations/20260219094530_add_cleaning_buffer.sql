@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Global cleaning gap between consecutive bookings on a desk, overridable per desk
+ALTER TABLE settings ADD COLUMN cleaning_buffer_minutes INTEGER NOT NULL DEFAULT 0
+    CHECK (cleaning_buffer_minutes >= 0);
+ALTER TABLE desks ADD COLUMN cleaning_buffer_minutes INTEGER
+    CHECK (cleaning_buffer_minutes >= 0);
+
+-- Effective cleaning buffer of a desk: its own override, else the global setting
+CREATE OR REPLACE FUNCTION desk_cleaning_buffer(p_desk_id INTEGER) RETURNS INTEGER
+LANGUAGE sql STABLE AS $$
+    SELECT COALESCE(
+        (SELECT cleaning_buffer_minutes FROM desks WHERE id = p_desk_id),
+        (SELECT cleaning_buffer_minutes FROM settings WHERE id = 1),
+        0
+    )
+$$;
+
+-- buffer_minutes snapshots the desk's buffer when a booking is placed or rescheduled;
+-- blocked_range is the time the booking keeps the desk, including the buffer after it
+ALTER TABLE bookings ADD COLUMN buffer_minutes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD COLUMN blocked_range TSTZRANGE;
+
+-- blocked_range cannot be a generated column: it reads the desk and settings rows, and adding
+-- an interval to a timestamptz is not immutable
+CREATE OR REPLACE FUNCTION set_booking_blocked_range() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP = 'INSERT'
+       OR NEW.desk_id <> OLD.desk_id
+       OR NEW.start_time <> OLD.start_time
+       OR NEW.end_time <> OLD.end_time THEN
+        NEW.buffer_minutes := desk_cleaning_buffer(NEW.desk_id);
+    END IF;
+    NEW.blocked_range := tstzrange(
+        NEW.start_time,
+        COALESCE(NEW.actual_end_time, NEW.end_time) + make_interval(mins => NEW.buffer_minutes)
+    );
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER bookings_set_blocked_range
+    BEFORE INSERT OR UPDATE ON bookings
+    FOR EACH ROW EXECUTE FUNCTION set_booking_blocked_range();
+
+-- Existing bookings keep a zero buffer
+UPDATE bookings SET blocked_range = tstzrange(start_time, COALESCE(actual_end_time, end_time));
+ALTER TABLE bookings ALTER COLUMN blocked_range SET NOT NULL;
+
+-- Enforce the buffer in the no-double-booking guarantee
+ALTER TABLE bookings DROP CONSTRAINT no_overlapping_bookings;
+ALTER TABLE bookings ADD CONSTRAINT no_overlapping_bookings
+    EXCLUDE USING GIST (
+        desk_id WITH =,
+        blocked_range WITH &&
+    ) WHERE (status NOT IN ('cancelled', 'no_show'));
+
+CREATE INDEX idx_bookings_blocked_range ON bookings USING GIST(blocked_range);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Restore the constraint over the booked range
+ALTER TABLE bookings DROP CONSTRAINT no_overlapping_bookings;
+ALTER TABLE bookings ADD CONSTRAINT no_overlapping_bookings
+    EXCLUDE USING GIST (
+        desk_id WITH =,
+        time_range WITH &&
+    ) WHERE (status NOT IN ('cancelled', 'no_show'));
+
+DROP INDEX IF EXISTS idx_bookings_blocked_range;
+DROP TRIGGER IF EXISTS bookings_set_blocked_range ON bookings;
+DROP FUNCTION IF EXISTS set_booking_blocked_range();
+ALTER TABLE bookings DROP COLUMN IF EXISTS blocked_range;
+ALTER TABLE bookings DROP COLUMN IF EXISTS buffer_minutes;
+
+DROP FUNCTION IF EXISTS desk_cleaning_buffer(INTEGER);
+ALTER TABLE desks DROP COLUMN IF EXISTS cleaning_buffer_minutes;
+ALTER TABLE settings DROP COLUMN IF EXISTS cleaning_buffer_minutes;
+-- +goose StatementEnd
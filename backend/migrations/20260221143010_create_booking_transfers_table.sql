@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create transfer_status enum
+CREATE TYPE transfer_status AS ENUM ('pending', 'accepted', 'declined', 'cancelled');
+
+-- Create booking_transfers table for handing a booking to a colleague, or swapping two bookings
+CREATE TABLE IF NOT EXISTS booking_transfers (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- For swaps: the recipient's booking the offerer receives in return
+    swap_booking_id INTEGER REFERENCES bookings(id) ON DELETE CASCADE,
+    status transfer_status NOT NULL DEFAULT 'pending',
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT booking_transfers_users_check CHECK (from_user_id <> to_user_id),
+    CONSTRAINT booking_transfers_swap_check CHECK (swap_booking_id IS NULL OR swap_booking_id <> booking_id)
+);
+
+-- A booking can only be offered to one colleague at a time
+CREATE UNIQUE INDEX idx_booking_transfers_pending_booking ON booking_transfers(booking_id) WHERE status = 'pending';
+
+-- Create indexes for listing a member's incoming and outgoing offers
+CREATE INDEX idx_booking_transfers_to_user_id ON booking_transfers(to_user_id);
+CREATE INDEX idx_booking_transfers_from_user_id ON booking_transfers(from_user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop indexes
+DROP INDEX IF EXISTS idx_booking_transfers_from_user_id;
+DROP INDEX IF EXISTS idx_booking_transfers_to_user_id;
+DROP INDEX IF EXISTS idx_booking_transfers_pending_booking;
+
+-- Drop booking_transfers table
+DROP TABLE IF EXISTS booking_transfers;
+
+-- Drop enum type
+DROP TYPE IF EXISTS transfer_status;
+-- +goose StatementEnd
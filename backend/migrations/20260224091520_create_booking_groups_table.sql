@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create booking_groups table for team bookings made in one request
+CREATE TABLE IF NOT EXISTS booking_groups (
+    id SERIAL PRIMARY KEY,
+    organizer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT booking_groups_valid_range CHECK (end_time > start_time)
+);
+
+-- Create index on organizer_id for organizer lookups
+CREATE INDEX idx_booking_groups_organizer_id ON booking_groups(organizer_id);
+
+-- Link each attendee's booking to its group; bookings outlive the group record
+ALTER TABLE bookings ADD COLUMN group_id INTEGER REFERENCES booking_groups(id) ON DELETE SET NULL;
+
+-- Create index on group_id for group-wide lookups
+CREATE INDEX idx_bookings_group_id ON bookings(group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop group link from bookings
+DROP INDEX IF EXISTS idx_bookings_group_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS group_id;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_booking_groups_organizer_id;
+
+-- Drop booking_groups table
+DROP TABLE IF EXISTS booking_groups;
+-- +goose StatementEnd
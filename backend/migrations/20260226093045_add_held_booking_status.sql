@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tentative holds keep a desk for a short time until they are confirmed.
+-- The new value cannot be used in the transaction that adds it, so the constraint change lives
+-- in the following migration.
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'held' BEFORE 'confirmed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- PostgreSQL cannot drop an enum value; release any remaining holds instead
+UPDATE bookings SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW() WHERE status = 'held';
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How long a hold keeps its desk before it is released
+ALTER TABLE settings ADD COLUMN hold_ttl_minutes INTEGER NOT NULL DEFAULT 10
+    CHECK (hold_ttl_minutes > 0);
+
+-- Holds expire at hold_expires_at unless confirmed; confirming clears it
+ALTER TABLE bookings ADD COLUMN hold_expires_at TIMESTAMPTZ;
+ALTER TABLE bookings ADD CONSTRAINT bookings_hold_expiry_check
+    CHECK (status <> 'held' OR hold_expires_at IS NOT NULL);
+
+-- List the statuses that occupy a desk rather than those that free it, so holds, and any
+-- status added later, have to be considered explicitly
+ALTER TABLE bookings DROP CONSTRAINT no_overlapping_bookings;
+ALTER TABLE bookings ADD CONSTRAINT no_overlapping_bookings
+    EXCLUDE USING GIST (
+        desk_id WITH =,
+        blocked_range WITH &&
+    ) WHERE (status IN ('held', 'confirmed', 'completed'));
+
+-- Create partial index for the expired-hold sweeper
+CREATE INDEX idx_bookings_hold_expires_at ON bookings(hold_expires_at) WHERE status = 'held';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Release remaining holds before the constraint stops covering them
+UPDATE bookings SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW() WHERE status = 'held';
+
+ALTER TABLE bookings DROP CONSTRAINT no_overlapping_bookings;
+ALTER TABLE bookings ADD CONSTRAINT no_overlapping_bookings
+    EXCLUDE USING GIST (
+        desk_id WITH =,
+        blocked_range WITH &&
+    ) WHERE (status NOT IN ('cancelled', 'no_show'));
+
+DROP INDEX IF EXISTS idx_bookings_hold_expires_at;
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_hold_expiry_check;
+ALTER TABLE bookings DROP COLUMN IF EXISTS hold_expires_at;
+ALTER TABLE settings DROP COLUMN IF EXISTS hold_ttl_minutes;
+-- +goose StatementEnd
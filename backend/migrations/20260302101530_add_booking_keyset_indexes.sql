@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keyset pagination walks bookings in (start_time, id) order, per user or across everyone
+CREATE INDEX idx_bookings_user_start_time_id ON bookings(user_id, start_time, id);
+CREATE INDEX idx_bookings_start_time_id ON bookings(start_time, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_bookings_start_time_id;
+DROP INDEX IF EXISTS idx_bookings_user_start_time_id;
+-- +goose StatementEnd
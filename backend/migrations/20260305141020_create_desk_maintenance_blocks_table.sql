@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Time-bounded maintenance on a desk, e.g. "desk 7 is out Monday 13:00-18:00".
+-- desk_status = 'maintenance' still takes a desk out indefinitely.
+CREATE TABLE IF NOT EXISTS desk_maintenance_blocks (
+    id SERIAL PRIMARY KEY,
+    desk_id INTEGER NOT NULL REFERENCES desks(id) ON DELETE CASCADE,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    time_range TSTZRANGE GENERATED ALWAYS AS (tstzrange(start_time, end_time)) STORED,
+    reason TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT desk_maintenance_blocks_valid_range CHECK (end_time > start_time)
+);
+
+-- Create GIST index for overlap checks against bookings
+CREATE INDEX idx_desk_maintenance_blocks_desk_time_range ON desk_maintenance_blocks USING GIST(desk_id, time_range);
+
+-- Active bookings may not overlap a maintenance block on their desk; like the cleaning gap
+-- between bookings, the booking's buffer has to end before the block starts. Violations are
+-- raised as exclusion violations so they surface exactly like no_overlapping_bookings.
+-- The desk row is locked first: creating a block locks it FOR UPDATE, so a booking and a block
+-- on the same desk never pass their overlap checks concurrently.
+CREATE OR REPLACE FUNCTION check_booking_maintenance() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    PERFORM 1 FROM desks WHERE id = NEW.desk_id FOR KEY SHARE;
+    IF EXISTS (
+        SELECT 1 FROM desk_maintenance_blocks
+        WHERE desk_id = NEW.desk_id
+          AND time_range && NEW.blocked_range
+    ) THEN
+        RAISE EXCEPTION 'booking overlaps maintenance on desk %', NEW.desk_id
+            USING ERRCODE = 'exclusion_violation', CONSTRAINT = 'desk_maintenance_blocks';
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+-- AFTER, so blocked_range has been set by bookings_set_blocked_range
+CREATE TRIGGER bookings_check_maintenance
+    AFTER INSERT OR UPDATE OF desk_id, start_time, end_time, status ON bookings
+    FOR EACH ROW
+    WHEN (NEW.status IN ('held', 'confirmed'))
+    EXECUTE FUNCTION check_booking_maintenance();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS bookings_check_maintenance ON bookings;
+DROP FUNCTION IF EXISTS check_booking_maintenance();
+DROP INDEX IF EXISTS idx_desk_maintenance_blocks_desk_time_range;
+DROP TABLE IF EXISTS desk_maintenance_blocks;
+-- +goose StatementEnd
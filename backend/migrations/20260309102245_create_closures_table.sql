@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Public holidays, office shutdowns and partial-day blackouts. Whole-day closures are stored as
+-- ranges covering the day, so every closure is a time range.
+CREATE TABLE IF NOT EXISTS closures (
+    id SERIAL PRIMARY KEY,
+    wing wing_type, -- NULL closes every wing
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    time_range TSTZRANGE GENERATED ALWAYS AS (tstzrange(start_time, end_time)) STORED,
+    reason TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT closures_valid_range CHECK (end_time > start_time)
+);
+
+-- Create GIST index for overlap checks against bookings and search windows
+CREATE INDEX idx_closures_time_range ON closures USING GIST(time_range);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_closures_time_range;
+DROP TABLE IF EXISTS closures;
+-- +goose StatementEnd
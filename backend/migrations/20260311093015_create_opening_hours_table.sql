@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Weekly opening schedule. A row without a wing sets a weekday's hours for the whole office and a
+-- wing's own row overrides it. Weekdays without a row keep settings.opening_start/opening_end, and
+-- a row without hours closes the day.
+CREATE TABLE IF NOT EXISTS opening_hours (
+    id SERIAL PRIMARY KEY,
+    wing wing_type, -- NULL applies to every wing
+    weekday SMALLINT NOT NULL, -- 0 is Sunday, as EXTRACT(DOW)
+    opens_at TIME,
+    closes_at TIME,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT opening_hours_wing_weekday_key UNIQUE NULLS NOT DISTINCT (wing, weekday),
+    CONSTRAINT opening_hours_valid_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT opening_hours_valid_hours CHECK (
+        (opens_at IS NULL AND closes_at IS NULL)
+        OR (opens_at IS NOT NULL AND closes_at IS NOT NULL AND opens_at < closes_at)
+    )
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS opening_hours;
+-- +goose StatementEnd
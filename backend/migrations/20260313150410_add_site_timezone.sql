@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- IANA zone of the site. Days, opening hours and daily hour limits are interpreted in it, so
+-- opening_start, opening_end and the opening_hours times are local wall-clock times.
+ALTER TABLE settings ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Unknown zone names are refused on write; a CHECK cannot do the lookup since it must be
+-- immutable. GetSettings additionally loads the zone with time.LoadLocation.
+CREATE OR REPLACE FUNCTION check_settings_timezone() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = NEW.timezone) THEN
+        RAISE EXCEPTION 'unknown time zone %', NEW.timezone
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'settings_valid_timezone';
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER settings_check_timezone
+    BEFORE INSERT OR UPDATE OF timezone ON settings
+    FOR EACH ROW
+    EXECUTE FUNCTION check_settings_timezone();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS settings_check_timezone ON settings;
+DROP FUNCTION IF EXISTS check_settings_timezone();
+ALTER TABLE settings DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
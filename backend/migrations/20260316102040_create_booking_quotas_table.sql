@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create quota_period enum; weeks start on Monday and both periods are in the site timezone
+CREATE TYPE quota_period AS ENUM ('week', 'month');
+
+-- Weekly and monthly booking quotas per role, on top of settings.daily_hour_limit. A NULL limit
+-- leaves that measure uncapped; roles without a row have no quota for the period.
+CREATE TABLE IF NOT EXISTS booking_quotas (
+    id SERIAL PRIMARY KEY,
+    role user_role NOT NULL,
+    period quota_period NOT NULL,
+    max_hours INTEGER,
+    max_bookings INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT booking_quotas_role_period_key UNIQUE (role, period),
+    CONSTRAINT booking_quotas_valid_limits CHECK (
+        (max_hours IS NOT NULL OR max_bookings IS NOT NULL)
+        AND (max_hours IS NULL OR max_hours > 0)
+        AND (max_bookings IS NULL OR max_bookings > 0)
+    )
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS booking_quotas;
+DROP TYPE IF EXISTS quota_period;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How far ahead, in days of the site timezone, and how soon before its start a booking may be
+-- made, per role. A NULL advance window and a zero lead time leave the role unrestricted, so
+-- admins are exempt unless their own columns are set.
+ALTER TABLE settings ADD COLUMN member_max_advance_days INTEGER
+    CHECK (member_max_advance_days >= 0);
+ALTER TABLE settings ADD COLUMN member_min_lead_minutes INTEGER NOT NULL DEFAULT 0
+    CHECK (member_min_lead_minutes >= 0);
+ALTER TABLE settings ADD COLUMN admin_max_advance_days INTEGER
+    CHECK (admin_max_advance_days >= 0);
+ALTER TABLE settings ADD COLUMN admin_min_lead_minutes INTEGER NOT NULL DEFAULT 0
+    CHECK (admin_min_lead_minutes >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE settings DROP COLUMN IF EXISTS admin_min_lead_minutes;
+ALTER TABLE settings DROP COLUMN IF EXISTS admin_max_advance_days;
+ALTER TABLE settings DROP COLUMN IF EXISTS member_min_lead_minutes;
+ALTER TABLE settings DROP COLUMN IF EXISTS member_max_advance_days;
+-- +goose StatementEnd